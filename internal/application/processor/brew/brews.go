@@ -0,0 +1,232 @@
+package brew
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+const (
+	defaultBrewsPageSize = 100
+	maxBrewsPageSize     = 1000
+
+	maxCancelledByLength  = 200
+	maxCancelReasonLength = 1000
+)
+
+// BrewListQuery запрос страницы варок от новых к старым.
+type BrewListQuery struct {
+	States    []domain.BrewState
+	RecipeID  int64
+	PageSize  int
+	PageToken string
+}
+
+type BrewPage struct {
+	Brews []domain.Brew
+	// NextPageToken пустой, если страница последняя.
+	NextPageToken string
+}
+
+// BrewCancellation запрос отмены варки.
+type BrewCancellation struct {
+	BrewID string
+	// CancelledBy кто отменяет варку со слов клиента, по умолчанию вызывающий из WithCaller.
+	CancelledBy string
+	Reason      string
+}
+
+// GetBrew возвращает варку по идентификатору из ответа BrewPot.
+func (p *Processor) GetBrew(ctx context.Context, id string) (*domain.Brew, error) {
+	if err := validateBrewID(id); err != nil {
+		return nil, err
+	}
+
+	if p.sessions == nil {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	return p.sessions.repo.GetBrew(ctx, id)
+}
+
+// CancelBrew отменяет варку в очереди или в котле. Завершённую варку отменить нельзя.
+// Вызывающий из контекста сохраняется рядом с CancelledBy, который клиент может указать любым.
+func (p *Processor) CancelBrew(ctx context.Context, cancellation BrewCancellation) (*domain.Brew, error) {
+	caller := callerFrom(ctx)
+	cancellation.CancelledBy = strings.TrimSpace(cancellation.CancelledBy)
+	cancellation.Reason = strings.TrimSpace(cancellation.Reason)
+	if cancellation.CancelledBy == "" {
+		cancellation.CancelledBy = caller
+	}
+
+	var violations []domainErrors.FieldViolation
+	if err := validateBrewID(cancellation.BrewID); err != nil {
+		violations = append(violations, err.Violations...)
+	}
+
+	switch {
+	case cancellation.CancelledBy == "":
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "cancelled_by",
+			Description: "cancelled by is required",
+		})
+	case utf8.RuneCountInString(cancellation.CancelledBy) > maxCancelledByLength:
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "cancelled_by",
+			Description: fmt.Sprintf("cancelled by must be at most %d characters", maxCancelledByLength),
+		})
+	}
+
+	if utf8.RuneCountInString(cancellation.Reason) > maxCancelReasonLength {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "reason",
+			Description: fmt.Sprintf("reason must be at most %d characters", maxCancelReasonLength),
+		})
+	}
+
+	if len(violations) > 0 {
+		return nil, domainErrors.NewValidationError(errors.New("invalid brew cancellation"), violations...)
+	}
+
+	if p.sessions == nil {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	return p.sessions.Cancel(ctx, cancellation.BrewID, caller, cancellation.CancelledBy, cancellation.Reason)
+}
+
+func validateBrewID(id string) *domainErrors.AppError {
+	if _, err := uuid.Parse(id); err != nil {
+		return domainErrors.NewValidationError(errors.New("invalid brew id"), domainErrors.FieldViolation{
+			Field:       "brew_id",
+			Description: "brew id must be a UUID",
+		})
+	}
+
+	return nil
+}
+
+// ListBrews возвращает страницу варок, отфильтрованных по состояниям и рецепту.
+func (p *Processor) ListBrews(ctx context.Context, query BrewListQuery) (*BrewPage, error) {
+	query, err := normalizeBrewListQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodeBrewPageToken(query.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.sessions == nil {
+		return &BrewPage{Brews: make([]domain.Brew, 0)}, nil
+	}
+
+	// Лишняя запись показывает, есть ли следующая страница.
+	brews, err := p.sessions.repo.FindBrews(ctx, domain.BrewQuery{
+		States:   query.States,
+		RecipeID: query.RecipeID,
+		After:    after,
+		Limit:    query.PageSize + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find brews: %w", err)
+	}
+
+	page := &BrewPage{Brews: brews}
+	if len(brews) > query.PageSize {
+		page.Brews = brews[:query.PageSize]
+		last := page.Brews[len(page.Brews)-1]
+		page.NextPageToken = encodeBrewPageToken(domain.BrewCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return page, nil
+}
+
+func normalizeBrewListQuery(query BrewListQuery) (BrewListQuery, error) {
+	var violations []domainErrors.FieldViolation
+	for i, state := range query.States {
+		if !state.Valid() {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("states[%d]", i),
+				Description: fmt.Sprintf("unknown brew state %q", state),
+			})
+		}
+	}
+
+	if query.RecipeID < 0 {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "recipe_id",
+			Description: "recipe id must not be negative",
+		})
+	}
+
+	if query.PageSize < 0 {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "page_size",
+			Description: "page size must not be negative",
+		})
+	}
+
+	if len(violations) > 0 {
+		return query, domainErrors.NewValidationError(errors.New("invalid brews query"), violations...)
+	}
+
+	switch {
+	case query.PageSize == 0:
+		query.PageSize = defaultBrewsPageSize
+	case query.PageSize > maxBrewsPageSize:
+		query.PageSize = maxBrewsPageSize
+	}
+
+	return query, nil
+}
+
+// brewPageToken непрозрачный для клиента курсор: ключ последней записи страницы.
+type brewPageToken struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+func encodeBrewPageToken(last domain.BrewCursor) string {
+	data, _ := json.Marshal(brewPageToken{CreatedAt: last.CreatedAt, ID: last.ID})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeBrewPageToken(raw string) (*domain.BrewCursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	invalid := domainErrors.NewValidationError(errors.New("invalid page token"), domainErrors.FieldViolation{
+		Field:       "page_token",
+		Description: "page token is malformed",
+	})
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var token brewPageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, invalid
+	}
+
+	if _, err := uuid.Parse(token.ID); err != nil {
+		return nil, invalid
+	}
+
+	return &domain.BrewCursor{CreatedAt: token.CreatedAt, ID: token.ID}, nil
+}
@@ -0,0 +1,246 @@
+package brew
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_ListBrews_Pagination(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	brews := []domain.Brew{
+		{ID: "00000000-0000-0000-0000-000000000003", CreatedAt: createdAt.Add(2 * time.Minute)},
+		{ID: "00000000-0000-0000-0000-000000000002", CreatedAt: createdAt.Add(time.Minute)},
+		{ID: "00000000-0000-0000-0000-000000000001", CreatedAt: createdAt},
+	}
+
+	mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+	gomock.InOrder(
+		mockBrews.EXPECT().FindBrews(gomock.Any(), domain.BrewQuery{
+			States: []domain.BrewState{domain.BrewFinished},
+			Limit:  3,
+		}).Return(brews, nil),
+		mockBrews.EXPECT().FindBrews(gomock.Any(), domain.BrewQuery{
+			States: []domain.BrewState{domain.BrewFinished},
+			After:  &domain.BrewCursor{CreatedAt: brews[1].CreatedAt, ID: brews[1].ID},
+			Limit:  3,
+		}).Return(brews[2:], nil),
+	)
+
+	processor := NewGRPCProcessor(nil, WithSessions(NewSessions(mockBrews, SessionConfig{})))
+	query := BrewListQuery{States: []domain.BrewState{domain.BrewFinished}, PageSize: 2}
+
+	// Act
+	first, err := processor.ListBrews(context.Background(), query)
+	require.NoError(t, err)
+
+	query.PageToken = first.NextPageToken
+	second, err := processor.ListBrews(context.Background(), query)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, brews[:2], first.Brews)
+	assert.NotEmpty(t, first.NextPageToken)
+	assert.Equal(t, brews[2:], second.Brews)
+	assert.Empty(t, second.NextPageToken)
+}
+
+func TestProcessor_ListBrews_Validation(t *testing.T) {
+	tests := []struct {
+		name               string
+		query              BrewListQuery
+		expectedViolations []string
+	}{
+		{
+			name:               "неизвестное состояние и отрицательные параметры",
+			query:              BrewListQuery{States: []domain.BrewState{domain.BrewQueued, "boiling"}, RecipeID: -1, PageSize: -1},
+			expectedViolations: []string{"states[1]", "recipe_id", "page_size"},
+		},
+		{
+			name:               "испорченный токен страницы",
+			query:              BrewListQuery{PageToken: "not-a-token"},
+			expectedViolations: []string{"page_token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			processor := NewGRPCProcessor(nil)
+
+			// Act
+			_, err := processor.ListBrews(context.Background(), tt.query)
+
+			// Assert
+			var appErr *domainErrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, domainErrors.ValidationError, appErr.Type)
+
+			fields := make([]string, 0, len(appErr.Violations))
+			for _, violation := range appErr.Violations {
+				fields = append(fields, violation.Field)
+			}
+			assert.Equal(t, tt.expectedViolations, fields)
+		})
+	}
+}
+
+func TestProcessor_GetBrew(t *testing.T) {
+	const id = "00000000-0000-0000-0000-000000000001"
+
+	tests := []struct {
+		name         string
+		id           string
+		mockSetup    func(*mock_repository.MockBrewRepositoryInterface)
+		expectedType string
+	}{
+		{
+			name: "варка найдена",
+			id:   id,
+			mockSetup: func(repo *mock_repository.MockBrewRepositoryInterface) {
+				repo.EXPECT().GetBrew(gomock.Any(), id).Return(&domain.Brew{ID: id, State: domain.BrewBrewing}, nil)
+			},
+		},
+		{
+			name: "варка не найдена",
+			id:   id,
+			mockSetup: func(repo *mock_repository.MockBrewRepositoryInterface) {
+				repo.EXPECT().GetBrew(gomock.Any(), id).Return(nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound))
+			},
+			expectedType: domainErrors.NotFound,
+		},
+		{
+			name:         "идентификатор не UUID",
+			id:           "42",
+			mockSetup:    func(repo *mock_repository.MockBrewRepositoryInterface) {},
+			expectedType: domainErrors.ValidationError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+			tt.mockSetup(mockBrews)
+
+			processor := NewGRPCProcessor(nil, WithSessions(NewSessions(mockBrews, SessionConfig{})))
+
+			// Act
+			brew, err := processor.GetBrew(context.Background(), tt.id)
+
+			// Assert
+			if tt.expectedType == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.BrewBrewing, brew.State)
+				return
+			}
+
+			var appErr *domainErrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.expectedType, appErr.Type)
+		})
+	}
+}
+
+func TestProcessor_CancelBrew_Caller(t *testing.T) {
+	const id = "00000000-0000-0000-0000-000000000001"
+
+	tests := []struct {
+		name                string
+		cancelledBy         string
+		expectedCancelledBy string
+	}{
+		{
+			name:                "автор по умолчанию — вызывающий",
+			expectedCancelledBy: "10.0.0.7",
+		},
+		{
+			name:                "автор со слов клиента хранится рядом с вызывающим",
+			cancelledBy:         " alchemist ",
+			expectedCancelledBy: "alchemist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+			mockBrews.EXPECT().GetBrew(gomock.Any(), id).Return(&domain.Brew{ID: id, State: domain.BrewQueued}, nil)
+			mockBrews.EXPECT().UpdateBrew(gomock.Any(), gomock.Any(), domain.BrewQueued).Return(true, nil)
+
+			processor := NewGRPCProcessor(nil, WithSessions(NewSessions(mockBrews, SessionConfig{})))
+			ctx := WithCaller(context.Background(), "10.0.0.7")
+
+			// Act
+			brew, err := processor.CancelBrew(ctx, BrewCancellation{BrewID: id, CancelledBy: tt.cancelledBy})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCancelledBy, brew.CancelledBy)
+			assert.Equal(t, "10.0.0.7", brew.CancelledByCaller)
+		})
+	}
+}
+
+func TestProcessor_CancelBrew_Validation(t *testing.T) {
+	tests := []struct {
+		name               string
+		cancellation       BrewCancellation
+		expectedViolations []string
+	}{
+		{
+			name:               "без идентификатора и автора",
+			cancellation:       BrewCancellation{CancelledBy: "  "},
+			expectedViolations: []string{"brew_id", "cancelled_by"},
+		},
+		{
+			name: "слишком длинная причина",
+			cancellation: BrewCancellation{
+				BrewID:      "00000000-0000-0000-0000-000000000001",
+				CancelledBy: "alchemist",
+				Reason:      strings.Repeat("а", maxCancelReasonLength+1),
+			},
+			expectedViolations: []string{"reason"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			processor := NewGRPCProcessor(nil)
+
+			// Act
+			_, err := processor.CancelBrew(context.Background(), tt.cancellation)
+
+			// Assert
+			var appErr *domainErrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, domainErrors.ValidationError, appErr.Type)
+
+			fields := make([]string, 0, len(appErr.Violations))
+			for _, violation := range appErr.Violations {
+				fields = append(fields, violation.Field)
+			}
+			assert.Equal(t, tt.expectedViolations, fields)
+		})
+	}
+}
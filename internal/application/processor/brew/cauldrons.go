@@ -0,0 +1,124 @@
+package brew
+
+import (
+	"errors"
+	"expvar"
+	"slices"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+// Метрики пула котлов публикуются через expvar (GET /debug/vars на служебном порту ADMIN_ADDR):
+// глубина очереди, занятые котлы, суммарное и общее число ожиданий котла и число варок,
+// отклонённых из-за полной очереди.
+var (
+	queueDepth         = expvar.NewInt("brew_queue_depth")
+	cauldronsBusy      = expvar.NewInt("brew_cauldrons_busy")
+	queueWaitSeconds   = expvar.NewFloat("brew_queue_wait_seconds_sum")
+	queueWaitTotal     = expvar.NewInt("brew_queue_wait_total")
+	queueRejectedTotal = expvar.NewInt("brew_queue_rejected_total")
+)
+
+// cauldrons пул котлов с ограниченной очередью FIFO перед ним. Поля защищены Sessions.mu.
+type cauldrons struct {
+	busy int
+	// reserved места, занятые новыми варками, которые ещё сохраняются.
+	reserved int
+	queue    []*worker
+}
+
+// reserve занимает место для новой варки или возвращает ResourceExhausted, если котлы
+// заняты и очередь заполнена. Место освобождается unreserve после постановки в очередь.
+func (s *Sessions) reserve() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pool := &s.cauldrons
+	if pool.busy+len(pool.queue)+pool.reserved >= s.config.Cauldrons+s.config.QueueSize {
+		queueRejectedTotal.Add(1)
+		return domainErrors.NewAppError(errors.New("all cauldrons are busy and the brew queue is full"), domainErrors.ResourceExhausted)
+	}
+
+	pool.reserved++
+
+	return nil
+}
+
+func (s *Sessions) unreserve() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cauldrons.reserved--
+}
+
+// enqueueLocked отдаёт варке свободный котёл или ставит её в конец очереди.
+// Возвращает позицию в очереди, 0 — варка получила котёл.
+func (s *Sessions) enqueueLocked(w *worker, brew domain.Brew) int {
+	w.brew = brew
+	w.enqueuedAt = s.now()
+
+	pool := &s.cauldrons
+	if pool.busy < s.config.Cauldrons {
+		s.takeLocked(w)
+		return 0
+	}
+
+	pool.queue = append(pool.queue, w)
+	s.publishLocked()
+
+	return len(pool.queue)
+}
+
+// takeLocked занимает котёл и начинает варку w.
+func (s *Sessions) takeLocked(w *worker) {
+	s.cauldrons.busy++
+	w.cauldron = true
+
+	queueWaitSeconds.Add(s.now().Sub(w.enqueuedAt).Seconds())
+	queueWaitTotal.Add(1)
+	s.publishLocked()
+
+	go s.advance(w, w.brew)
+}
+
+// occupyLocked отмечает котёл занятым варкой, начатой до перезапуска. Такие варки
+// занимают котёл, даже если котлов стало меньше.
+func (s *Sessions) occupyLocked(w *worker) {
+	if w.cauldron {
+		return
+	}
+
+	s.cauldrons.busy++
+	w.cauldron = true
+	s.publishLocked()
+}
+
+// leaveLocked освобождает котёл варки w или убирает её из очереди.
+func (s *Sessions) leaveLocked(w *worker) {
+	pool := &s.cauldrons
+	if !w.cauldron {
+		pool.queue = slices.DeleteFunc(pool.queue, func(queued *worker) bool { return queued == w })
+		s.publishLocked()
+		return
+	}
+
+	w.cauldron = false
+	pool.busy--
+
+	// Освободившийся котёл получает первая неотменённая варка из очереди.
+	for pool.busy < s.config.Cauldrons && len(pool.queue) > 0 {
+		next := pool.queue[0]
+		pool.queue = pool.queue[1:]
+		if next.ctx.Err() == nil {
+			s.takeLocked(next)
+		}
+	}
+
+	s.publishLocked()
+}
+
+func (s *Sessions) publishLocked() {
+	queueDepth.Set(int64(len(s.cauldrons.queue)))
+	cauldronsBusy.Set(int64(s.cauldrons.busy))
+}
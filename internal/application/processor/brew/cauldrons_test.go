@@ -0,0 +1,147 @@
+package brew
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+// startedBrews записывает варки, получившие котёл, в порядке начала.
+func startedBrews(repo *mock_repository.MockBrewRepositoryInterface) (func() []string, <-chan string) {
+	var mu sync.Mutex
+	started := make([]string, 0)
+	events := make(chan string, 10)
+
+	repo.EXPECT().SaveBrew(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	repo.EXPECT().UpdateBrew(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, brew *domain.Brew, _ domain.BrewState) (bool, error) {
+			if brew.State == domain.BrewBrewing {
+				mu.Lock()
+				started = append(started, brew.ID)
+				mu.Unlock()
+				events <- brew.ID
+			}
+			return true, nil
+		}).AnyTimes()
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), started...)
+	}, events
+}
+
+func waitStarted(t *testing.T, events <-chan string, id string) {
+	t.Helper()
+
+	select {
+	case started := <-events:
+		require.Equal(t, id, started)
+	case <-time.After(time.Second):
+		t.Fatalf("brew %s did not get a cauldron", id)
+	}
+}
+
+func TestSessions_Begin_Backpressure(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+	mockBrews.EXPECT().FindBrews(gomock.Any(), gomock.Any()).Return(nil, nil)
+	_, events := startedBrews(mockBrews)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sessions := NewSessions(mockBrews, SessionConfig{DefaultDuration: time.Hour, Cauldrons: 1, QueueSize: 2})
+	require.NoError(t, sessions.Start(ctx))
+
+	// Act
+	positions := make([]int, 0, 3)
+	for _, id := range []string{"b1", "b2", "b3"} {
+		position, err := sessions.begin(ctx, &domain.Brew{ID: id, Duration: time.Hour})
+		require.NoError(t, err)
+		positions = append(positions, position)
+	}
+	waitStarted(t, events, "b1")
+
+	_, err := sessions.begin(ctx, &domain.Brew{ID: "b4", Duration: time.Hour})
+
+	// Assert
+	assert.Equal(t, []int{0, 1, 2}, positions)
+
+	var appErr *domainErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, domainErrors.ResourceExhausted, appErr.Type)
+}
+
+func TestSessions_Cauldrons_FIFO(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+	mockBrews.EXPECT().FindBrews(gomock.Any(), gomock.Any()).Return(nil, nil)
+	started, events := startedBrews(mockBrews)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sessions := NewSessions(mockBrews, SessionConfig{Cauldrons: 1, QueueSize: 10})
+	require.NoError(t, sessions.Start(ctx))
+
+	// Act
+	for _, id := range []string{"b1", "b2", "b3"} {
+		_, err := sessions.begin(ctx, &domain.Brew{ID: id, Duration: 10 * time.Millisecond})
+		require.NoError(t, err)
+	}
+	for _, id := range []string{"b1", "b2", "b3"} {
+		waitStarted(t, events, id)
+	}
+
+	// Assert
+	assert.Equal(t, []string{"b1", "b2", "b3"}, started())
+}
+
+func TestSessions_Cancel_LeavesQueue(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+	mockBrews.EXPECT().FindBrews(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockBrews.EXPECT().GetBrew(gomock.Any(), "b2").Return(&domain.Brew{ID: "b2", State: domain.BrewQueued}, nil)
+	_, events := startedBrews(mockBrews)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sessions := NewSessions(mockBrews, SessionConfig{Cauldrons: 1, QueueSize: 1})
+	require.NoError(t, sessions.Start(ctx))
+
+	_, err := sessions.begin(ctx, &domain.Brew{ID: "b1", Duration: time.Hour})
+	require.NoError(t, err)
+	_, err = sessions.begin(ctx, &domain.Brew{ID: "b2", Duration: time.Hour})
+	require.NoError(t, err)
+	waitStarted(t, events, "b1")
+
+	// Act
+	_, err = sessions.Cancel(ctx, "b2", "", "alchemist", "")
+	require.NoError(t, err)
+
+	position, err := sessions.begin(ctx, &domain.Brew{ID: "b3", Duration: time.Hour})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, position)
+}
@@ -0,0 +1,118 @@
+package brew
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+)
+
+type FuzzyConfig struct {
+	// Algorithm способ сравнения названий, по умолчанию расстояние редактирования.
+	Algorithm names.Algorithm
+	// Threshold минимальная похожесть подсказки от 0 до 1, 0 отключает подсказки.
+	Threshold float64
+	// MaxSuggestions сколько подсказок возвращать на ингредиент, 0 без ограничения.
+	MaxSuggestions int
+	// AutoCorrect заменяет неизвестный ингредиент лучшей подсказкой, если её похожесть
+	// не ниже AutoCorrectThreshold и вторая подсказка не так же похожа.
+	AutoCorrect          bool
+	AutoCorrectThreshold float64
+}
+
+// WithFuzzyConfig задаёт нечёткий поиск неизвестных ингредиентов при варке.
+func WithFuzzyConfig(config FuzzyConfig) Option {
+	return func(p *Processor) {
+		p.fuzzy = config
+	}
+}
+
+// Correction неизвестный ингредиент Name, заменённый при варке известным Corrected.
+type Correction struct {
+	Name      string
+	Corrected string
+	Score     float64
+}
+
+// vocabulary известные ингредиенты: названия из рецептов и заменители из правил замены.
+func (p *Processor) vocabulary(normalizer *normalizer, recipesList []domain.Recipe, rules []domain.Substitution) *names.Vocabulary {
+	var known []string
+	for _, recipe := range recipesList {
+		for _, ingredient := range recipe.Ingredients {
+			known = append(known, normalizer.names.Canonical(ingredient.Name))
+		}
+	}
+
+	// Названия правил уже канонические.
+	for _, rule := range rules {
+		known = append(known, rule.Substitute)
+	}
+
+	return names.NewVocabulary(p.fuzzy.Algorithm, known)
+}
+
+// autoCorrect заменяет неизвестные ингредиенты уверенно распознанными известными.
+func (p *Processor) autoCorrect(vocabulary *names.Vocabulary, normalizer *normalizer, ingredients []Ingredient) ([]Ingredient, []Correction) {
+	if !p.fuzzy.AutoCorrect || p.fuzzy.Threshold <= 0 {
+		return ingredients, nil
+	}
+
+	var corrections []Correction
+	corrected := make([]Ingredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		name := normalizer.names.Canonical(ingredient.Name)
+		if !vocabulary.Contains(name) {
+			matches := vocabulary.Lookup(name, max(p.fuzzy.Threshold, p.fuzzy.AutoCorrectThreshold), 2)
+			if len(matches) == 1 || (len(matches) == 2 && matches[0].Score > matches[1].Score) {
+				corrections = append(corrections, Correction{Name: ingredient.Name, Corrected: matches[0].Name, Score: matches[0].Score})
+				ingredient.Name = matches[0].Name
+			}
+		}
+
+		corrected = append(corrected, ingredient)
+	}
+
+	return corrected, corrections
+}
+
+// unknownIngredients возвращает ошибку с подсказками "возможно, имелось в виду"
+// для неизвестных ингредиентов, на которые нашлись похожие известные.
+func (p *Processor) unknownIngredients(vocabulary *names.Vocabulary, normalizer *normalizer, ingredients []Ingredient) error {
+	if p.fuzzy.Threshold <= 0 {
+		return nil
+	}
+
+	var violations []domainErrors.FieldViolation
+	var suggestions []domainErrors.Suggestion
+	for i, ingredient := range ingredients {
+		name := normalizer.names.Canonical(ingredient.Name)
+		if vocabulary.Contains(name) {
+			continue
+		}
+
+		matches := vocabulary.Lookup(name, p.fuzzy.Threshold, p.fuzzy.MaxSuggestions)
+		if len(matches) == 0 {
+			continue
+		}
+
+		field := fmt.Sprintf("ingredients[%d].name", i)
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       field,
+			Description: fmt.Sprintf("unknown ingredient %q, did you mean %q?", ingredient.Name, matches[0].Name),
+		})
+
+		suggestion := domainErrors.Suggestion{Field: field, Value: ingredient.Name}
+		for _, match := range matches {
+			suggestion.Candidates = append(suggestion.Candidates, domainErrors.Candidate{Value: match.Name, Score: match.Score})
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if len(suggestions) == 0 {
+		return nil
+	}
+
+	return domainErrors.NewValidationError(errors.New("unknown brew ingredients"), violations...).WithSuggestions(suggestions...)
+}
@@ -0,0 +1,111 @@
+package brew
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_BrewPot_Fuzzy(t *testing.T) {
+	potion := domain.Recipe{
+		ID:   1,
+		Name: "Зелье",
+		Ingredients: []domain.Ingredient{
+			{Name: "крапива", Quantity: 2},
+			{Name: "вода", Quantity: 1},
+		},
+	}
+
+	fuzzy := FuzzyConfig{
+		Algorithm:            names.AlgorithmEditDistance,
+		Threshold:            0.6,
+		MaxSuggestions:       3,
+		AutoCorrectThreshold: 0.8,
+	}
+	autoCorrect := fuzzy
+	autoCorrect.AutoCorrect = true
+	disabled := fuzzy
+	disabled.Threshold = 0
+
+	// score похожесть названия длины length с distance правками, как её считает names.Similarity.
+	score := func(distance, length float64) float64 { return 1 - distance/length }
+
+	tests := []struct {
+		name                string
+		fuzzy               FuzzyConfig
+		ingredients         []Ingredient
+		expectedStarted     bool
+		expectedCorrections []Correction
+		expectedSuggestions []domainErrors.Suggestion
+	}{
+		{
+			name:        "опечатка возвращает подсказку",
+			fuzzy:       fuzzy,
+			ingredients: []Ingredient{{Name: "крапва", Quantity: 2}, {Name: "вода", Quantity: 1}},
+			expectedSuggestions: []domainErrors.Suggestion{
+				{Field: "ingredients[0].name", Value: "крапва", Candidates: []domainErrors.Candidate{{Value: "крапива", Score: score(1, 7)}}},
+			},
+		},
+		{
+			name:        "непохожий ингредиент не подсказывается",
+			fuzzy:       fuzzy,
+			ingredients: []Ingredient{{Name: "мандрагора", Quantity: 2}, {Name: "вода", Quantity: 1}},
+		},
+		{
+			name:        "подсказки отключены",
+			fuzzy:       disabled,
+			ingredients: []Ingredient{{Name: "крапва", Quantity: 2}, {Name: "вода", Quantity: 1}},
+		},
+		{
+			name:                "автоисправление варит рецепт",
+			fuzzy:               autoCorrect,
+			ingredients:         []Ingredient{{Name: "Крапва", Quantity: 2}, {Name: "вода", Quantity: 1}},
+			expectedStarted:     true,
+			expectedCorrections: []Correction{{Name: "Крапва", Corrected: "крапива", Score: score(1, 7)}},
+		},
+		{
+			name:        "неуверенная подсказка не применяется автоматически",
+			fuzzy:       autoCorrect,
+			ingredients: []Ingredient{{Name: "кропва", Quantity: 2}, {Name: "вода", Quantity: 1}},
+			expectedSuggestions: []domainErrors.Suggestion{
+				{Field: "ingredients[0].name", Value: "кропва", Candidates: []domainErrors.Candidate{{Value: "крапива", Score: score(2, 7)}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{potion}, nil)
+
+			processor := NewGRPCProcessor(mockRepo, WithDefaultMatch(Match{Mode: MatchExact}), WithFuzzyConfig(tt.fuzzy))
+
+			// Act
+			result, err := processor.BrewPot(context.Background(), tt.ingredients, Match{})
+
+			// Assert
+			if tt.expectedSuggestions != nil {
+				var appErr *domainErrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, domainErrors.ValidationError, appErr.Type)
+				assert.Equal(t, tt.expectedSuggestions, appErr.Suggestions)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStarted, result.Started)
+			assert.Equal(t, tt.expectedCorrections, result.Corrections)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package brew
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/repository"
+)
+
+// BrewHistoryQuery запрос страницы истории вызовов BrewPot от новых к старым.
+// RequestedFrom включается в выборку, RequestedTo — нет.
+type BrewHistoryQuery struct {
+	RecipeID      int64
+	Outcomes      []domain.BrewOutcome
+	RequestedFrom *time.Time
+	RequestedTo   *time.Time
+	PageSize      int
+	PageToken     string
+}
+
+type BrewHistoryPage struct {
+	Records []domain.BrewRecord
+	// NextPageToken пустой, если страница последняя.
+	NextPageToken string
+}
+
+// WithHistory записывает каждый вызов BrewPot в историю.
+func WithHistory(history repository.BrewHistoryRepositoryInterface) Option {
+	return func(p *Processor) {
+		p.history = history
+	}
+}
+
+type callerKey struct{}
+
+// WithCaller возвращает контекст, в котором транспорт сообщает, кто вызвал BrewPot или CancelBrew.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+func callerFrom(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+
+	return caller
+}
+
+type withoutHistoryKey struct{}
+
+// WithoutHistory возвращает контекст вызова, автора которого транспорт не может подтвердить.
+// Такие вызовы не пишутся в историю, чтобы её нельзя было заполнить от чужого имени.
+func WithoutHistory(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutHistoryKey{}, true)
+}
+
+// record пишет итог вызова BrewPot в историю. Ошибка записи не влияет на варку.
+func (p *Processor) record(ctx context.Context, ingredients []Ingredient, match Match, startAt *time.Time, result *Result, err error) {
+	if skip, _ := ctx.Value(withoutHistoryKey{}).(bool); p.history == nil || skip {
+		return
+	}
+
+	record := &domain.BrewRecord{
+		ID:          uuid.NewString(),
+		Caller:      callerFrom(ctx),
+		Ingredients: toDomainIngredients(ingredients),
+		MatchMode:   string(match.Mode),
+		Outcome:     outcome(result, err),
+		StartAt:     startAt,
+		RequestedAt: time.Now(),
+	}
+
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	if result != nil && result.Started {
+		record.RecipeID = result.RecipeID
+		record.RecipeName = result.RecipeName
+		record.BrewID = result.BrewID
+		record.StartAt = result.StartAt
+	}
+
+	// Запись не отменяется вместе с запросом, иначе история теряла бы прерванные вызовы.
+	if err := p.history.SaveBrewRecord(context.WithoutCancel(ctx), record); err != nil {
+		log.Printf("brew history: failed to save record: %v", err)
+	}
+}
+
+func outcome(result *Result, err error) domain.BrewOutcome {
+	var appErr *domainErrors.AppError
+	switch {
+	case errors.As(err, &appErr) && (appErr.Type == domainErrors.ValidationError ||
+		appErr.Type == domainErrors.ResourceExhausted || appErr.Type == domainErrors.FailedPrecondition):
+		return domain.BrewOutcomeRejected
+	case err != nil:
+		return domain.BrewOutcomeError
+	case !result.Started:
+		return domain.BrewOutcomeNoMatch
+	case result.StartAt != nil:
+		return domain.BrewOutcomeScheduled
+	}
+
+	return domain.BrewOutcomeStarted
+}
+
+// ListBrewHistory возвращает страницу истории вызовов BrewPot, отфильтрованную по рецепту,
+// итогам и времени вызова.
+func (p *Processor) ListBrewHistory(ctx context.Context, query BrewHistoryQuery) (*BrewHistoryPage, error) {
+	query, err := normalizeBrewHistoryQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodeBrewPageToken(query.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.history == nil {
+		return &BrewHistoryPage{Records: make([]domain.BrewRecord, 0)}, nil
+	}
+
+	// Лишняя запись показывает, есть ли следующая страница.
+	records, err := p.history.FindBrewRecords(ctx, domain.BrewHistoryQuery{
+		RecipeID:      query.RecipeID,
+		Outcomes:      query.Outcomes,
+		RequestedFrom: query.RequestedFrom,
+		RequestedTo:   query.RequestedTo,
+		After:         after,
+		Limit:         query.PageSize + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find brew history: %w", err)
+	}
+
+	page := &BrewHistoryPage{Records: records}
+	if len(records) > query.PageSize {
+		page.Records = records[:query.PageSize]
+		last := page.Records[len(page.Records)-1]
+		page.NextPageToken = encodeBrewPageToken(domain.BrewCursor{CreatedAt: last.RequestedAt, ID: last.ID})
+	}
+
+	return page, nil
+}
+
+func normalizeBrewHistoryQuery(query BrewHistoryQuery) (BrewHistoryQuery, error) {
+	var violations []domainErrors.FieldViolation
+	for i, outcome := range query.Outcomes {
+		if !outcome.Valid() {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("outcomes[%d]", i),
+				Description: fmt.Sprintf("unknown brew outcome %q", outcome),
+			})
+		}
+	}
+
+	if query.RecipeID < 0 {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "recipe_id",
+			Description: "recipe id must not be negative",
+		})
+	}
+
+	if query.RequestedFrom != nil && query.RequestedTo != nil && !query.RequestedTo.After(*query.RequestedFrom) {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "requested_to",
+			Description: "requested to must be after requested from",
+		})
+	}
+
+	if query.PageSize < 0 {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "page_size",
+			Description: "page size must not be negative",
+		})
+	}
+
+	if len(violations) > 0 {
+		return query, domainErrors.NewValidationError(errors.New("invalid brew history query"), violations...)
+	}
+
+	switch {
+	case query.PageSize == 0:
+		query.PageSize = defaultBrewsPageSize
+	case query.PageSize > maxBrewsPageSize:
+		query.PageSize = maxBrewsPageSize
+	}
+
+	return query, nil
+}
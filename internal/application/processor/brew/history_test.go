@@ -0,0 +1,268 @@
+package brew
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_BrewPot_History(t *testing.T) {
+	recipe := domain.Recipe{ID: 1, Name: "Зелье", Ingredients: []domain.Ingredient{{Name: "вода", Quantity: 1}}}
+
+	tests := []struct {
+		name            string
+		ingredients     []Ingredient
+		mockSetup       func(*mock_repository.MockRecipeRepositoryInterface)
+		saveErr         error
+		expectedOutcome domain.BrewOutcome
+		expectedRecipe  int64
+		expectErr       bool
+	}{
+		{
+			name:        "варка начата",
+			ingredients: []Ingredient{{Name: "вода", Quantity: 1}},
+			mockSetup: func(repo *mock_repository.MockRecipeRepositoryInterface) {
+				repo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{recipe}, nil)
+			},
+			expectedOutcome: domain.BrewOutcomeStarted,
+			expectedRecipe:  1,
+		},
+		{
+			name:        "рецепт не подошёл",
+			ingredients: []Ingredient{{Name: "вода", Quantity: 5}},
+			mockSetup: func(repo *mock_repository.MockRecipeRepositoryInterface) {
+				repo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{recipe}, nil)
+			},
+			expectedOutcome: domain.BrewOutcomeNoMatch,
+		},
+		{
+			name:            "запрос отклонён валидацией",
+			ingredients:     []Ingredient{{Name: "вода", Quantity: -1}},
+			mockSetup:       func(repo *mock_repository.MockRecipeRepositoryInterface) {},
+			expectedOutcome: domain.BrewOutcomeRejected,
+			expectErr:       true,
+		},
+		{
+			name:        "ошибка репозитория",
+			ingredients: []Ingredient{{Name: "вода", Quantity: 1}},
+			mockSetup: func(repo *mock_repository.MockRecipeRepositoryInterface) {
+				repo.EXPECT().GetRecipes(gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			expectedOutcome: domain.BrewOutcomeError,
+			expectErr:       true,
+		},
+		{
+			name:        "ошибка записи истории не мешает варке",
+			ingredients: []Ingredient{{Name: "вода", Quantity: 1}},
+			mockSetup: func(repo *mock_repository.MockRecipeRepositoryInterface) {
+				repo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{recipe}, nil)
+			},
+			saveErr:         errors.New("connection refused"),
+			expectedOutcome: domain.BrewOutcomeStarted,
+			expectedRecipe:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			tt.mockSetup(mockRepo)
+
+			var saved domain.BrewRecord
+			mockHistory := mock_repository.NewMockBrewHistoryRepositoryInterface(ctrl)
+			mockHistory.EXPECT().SaveBrewRecord(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, record *domain.BrewRecord) error {
+					saved = *record
+					return tt.saveErr
+				})
+
+			processor := NewGRPCProcessor(mockRepo, WithHistory(mockHistory))
+			ctx := WithCaller(context.Background(), "alchemist")
+
+			// Act
+			result, err := processor.BrewPot(ctx, tt.ingredients, Match{Mode: MatchExact})
+
+			// Assert
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Equal(t, err.Error(), saved.Error)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, result.BrewID, saved.BrewID)
+				assert.Empty(t, saved.Error)
+			}
+
+			assert.NotEmpty(t, saved.ID)
+			assert.Equal(t, "alchemist", saved.Caller)
+			assert.Equal(t, tt.expectedOutcome, saved.Outcome)
+			assert.Equal(t, tt.expectedRecipe, saved.RecipeID)
+			assert.Equal(t, "exact", saved.MatchMode)
+			assert.Equal(t, toDomainIngredients(tt.ingredients), saved.Ingredients)
+			assert.False(t, saved.RequestedAt.IsZero())
+		})
+	}
+}
+
+func TestProcessor_BrewPot_WithoutHistory(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetRecipes(gomock.Any()).Return(nil, nil)
+
+	// SaveBrewRecord не ожидается: неподтверждённый вызов не пишется в историю.
+	mockHistory := mock_repository.NewMockBrewHistoryRepositoryInterface(ctrl)
+
+	processor := NewGRPCProcessor(mockRepo, WithHistory(mockHistory))
+	ctx := WithoutHistory(WithCaller(context.Background(), "admin"))
+
+	// Act
+	result, err := processor.BrewPot(ctx, []Ingredient{{Name: "вода", Quantity: 1}}, Match{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, result.Started)
+}
+
+func TestProcessor_ScheduleBrew_History(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetRecipes(gomock.Any()).Return(nil, nil)
+
+	var saved domain.BrewRecord
+	mockHistory := mock_repository.NewMockBrewHistoryRepositoryInterface(ctrl)
+	mockHistory.EXPECT().SaveBrewRecord(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, record *domain.BrewRecord) error {
+			saved = *record
+			return nil
+		})
+
+	sessions := NewSessions(mock_repository.NewMockBrewRepositoryInterface(ctrl), SessionConfig{})
+	processor := NewGRPCProcessor(mockRepo, WithSessions(sessions), WithHistory(mockHistory))
+	startAt := time.Now().Add(time.Hour)
+
+	// Act
+	_, err := processor.ScheduleBrew(context.Background(), []Ingredient{{Name: "вода", Quantity: 1}}, Match{}, startAt)
+
+	// Assert
+	var appErr *domainErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, domainErrors.ValidationError, appErr.Type)
+	assert.Equal(t, domain.BrewOutcomeNoMatch, saved.Outcome)
+	require.NotNil(t, saved.StartAt)
+	assert.True(t, startAt.Equal(*saved.StartAt))
+}
+
+func TestProcessor_ListBrewHistory_Pagination(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	requestedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	from := requestedAt.Add(-time.Hour)
+	records := []domain.BrewRecord{
+		{ID: "00000000-0000-0000-0000-000000000003", RequestedAt: requestedAt.Add(2 * time.Minute)},
+		{ID: "00000000-0000-0000-0000-000000000002", RequestedAt: requestedAt.Add(time.Minute)},
+		{ID: "00000000-0000-0000-0000-000000000001", RequestedAt: requestedAt},
+	}
+
+	mockHistory := mock_repository.NewMockBrewHistoryRepositoryInterface(ctrl)
+	gomock.InOrder(
+		mockHistory.EXPECT().FindBrewRecords(gomock.Any(), domain.BrewHistoryQuery{
+			Outcomes:      []domain.BrewOutcome{domain.BrewOutcomeStarted},
+			RequestedFrom: &from,
+			Limit:         3,
+		}).Return(records, nil),
+		mockHistory.EXPECT().FindBrewRecords(gomock.Any(), domain.BrewHistoryQuery{
+			Outcomes:      []domain.BrewOutcome{domain.BrewOutcomeStarted},
+			RequestedFrom: &from,
+			After:         &domain.BrewCursor{CreatedAt: records[1].RequestedAt, ID: records[1].ID},
+			Limit:         3,
+		}).Return(records[2:], nil),
+	)
+
+	processor := NewGRPCProcessor(nil, WithHistory(mockHistory))
+	query := BrewHistoryQuery{Outcomes: []domain.BrewOutcome{domain.BrewOutcomeStarted}, RequestedFrom: &from, PageSize: 2}
+
+	// Act
+	first, err := processor.ListBrewHistory(context.Background(), query)
+	require.NoError(t, err)
+
+	query.PageToken = first.NextPageToken
+	second, err := processor.ListBrewHistory(context.Background(), query)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, records[:2], first.Records)
+	assert.NotEmpty(t, first.NextPageToken)
+	assert.Equal(t, records[2:], second.Records)
+	assert.Empty(t, second.NextPageToken)
+}
+
+func TestProcessor_ListBrewHistory_Validation(t *testing.T) {
+	from := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		query              BrewHistoryQuery
+		expectedViolations []string
+	}{
+		{
+			name: "неизвестный итог и отрицательные параметры",
+			query: BrewHistoryQuery{
+				Outcomes: []domain.BrewOutcome{domain.BrewOutcomeError, "boiled"},
+				RecipeID: -1,
+				PageSize: -1,
+			},
+			expectedViolations: []string{"outcomes[1]", "recipe_id", "page_size"},
+		},
+		{
+			name:               "конец периода не позже начала",
+			query:              BrewHistoryQuery{RequestedFrom: &from, RequestedTo: &from},
+			expectedViolations: []string{"requested_to"},
+		},
+		{
+			name:               "испорченный токен страницы",
+			query:              BrewHistoryQuery{PageToken: "not-a-token"},
+			expectedViolations: []string{"page_token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			processor := NewGRPCProcessor(nil)
+
+			// Act
+			_, err := processor.ListBrewHistory(context.Background(), tt.query)
+
+			// Assert
+			var appErr *domainErrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, domainErrors.ValidationError, appErr.Type)
+
+			fields := make([]string, 0, len(appErr.Violations))
+			for _, violation := range appErr.Violations {
+				fields = append(fields, violation.Field)
+			}
+			assert.Equal(t, tt.expectedViolations, fields)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package brew
+
+import (
+	"fmt"
+	"math"
+
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+type MatchMode string
+
+const (
+	// MatchExact в котле ровно ингредиенты рецепта в нужном количестве.
+	MatchExact MatchMode = "exact"
+	// MatchSuperset в котле есть как минимум всё, что требует рецепт; лишнее остаётся в остатке.
+	MatchSuperset MatchMode = "superset"
+	// MatchTolerance в котле ровно ингредиенты рецепта, количество каждого отличается
+	// от рецептурного не больше чем на TolerancePercent процентов.
+	MatchTolerance MatchMode = "tolerance"
+
+	// MatchSubset прежний режим: котёл, входящий в рецепт, варил его, даже если в котле
+	// была одна крапива. Запросы с этим режимом отклоняются ошибкой валидации.
+	//
+	// Deprecated: используйте MatchSuperset.
+	MatchSubset MatchMode = "subset"
+)
+
+// Match правила сопоставления ингредиентов котла с рецептом.
+// Незаполненные поля берутся из настроек процессора.
+type Match struct {
+	Mode MatchMode
+	// TolerancePercent допуск для MatchTolerance, nil — допуск из настроек, 0 — точное совпадение.
+	TolerancePercent *int
+}
+
+// resolve дополняет match значениями по умолчанию и проверяет его.
+func (m Match) resolve(defaults Match) (Match, error) {
+	if m.Mode == "" {
+		m.Mode = defaults.Mode
+	}
+	if m.TolerancePercent == nil {
+		m.TolerancePercent = defaults.TolerancePercent
+	}
+
+	var violations []domainErrors.FieldViolation
+	switch m.Mode {
+	case MatchExact, MatchSuperset, MatchTolerance:
+	case MatchSubset:
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "match_mode",
+			Description: "match mode subset is no longer supported, use superset",
+		})
+	default:
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "match_mode",
+			Description: fmt.Sprintf("unknown match mode %q", m.Mode),
+		})
+	}
+
+	if m.tolerance() < 0 || m.tolerance() > 100 {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "tolerance_percent",
+			Description: "tolerance must be between 0 and 100 percent",
+		})
+	}
+
+	if len(violations) > 0 {
+		return m, domainErrors.NewValidationError(fmt.Errorf("invalid match %q", m.Mode), violations...)
+	}
+
+	return m, nil
+}
+
+// tolerance допуск в процентах, 0 — если не задан ни в запросе, ни в настройках.
+func (m Match) tolerance() int {
+	if m.TolerancePercent == nil {
+		return 0
+	}
+
+	return *m.TolerancePercent
+}
+
+// matches сравнивает котёл и рецепт, переведённые в базовые единицы.
+// Рецепт без ингредиентов не подходит ни к одному котлу, иначе в superset он варился бы из чего угодно.
+func (p *Processor) matches(match Match, brewIngredients map[ingredientKey]float64, recipeIngredients []Ingredient) bool {
+	if len(recipeIngredients) == 0 {
+		return false
+	}
+
+	switch match.Mode {
+	case MatchExact:
+		return matchTolerance(brewIngredients, recipeIngredients, 0)
+	case MatchTolerance:
+		return matchTolerance(brewIngredients, recipeIngredients, match.tolerance())
+	default:
+		return matchSuperset(brewIngredients, recipeIngredients)
+	}
+}
+
+func matchSuperset(brewIngredients map[ingredientKey]float64, recipeIngredients []Ingredient) bool {
+	for _, ingredient := range recipeIngredients {
+		if less(brewIngredients[ingredient.key()], ingredient.Quantity) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchTolerance(brewIngredients map[ingredientKey]float64, recipeIngredients []Ingredient, percent int) bool {
+	if len(brewIngredients) != len(recipeIngredients) {
+		return false
+	}
+
+	for _, ingredient := range recipeIngredients {
+		quantity, ok := brewIngredients[ingredient.key()]
+		if !ok {
+			return false
+		}
+
+		// |quantity - need| / need <= percent / 100.
+		if less(ingredient.Quantity*float64(percent), math.Abs(quantity-ingredient.Quantity)*100) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,246 @@
+package brew
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_BrewPot_Match(t *testing.T) {
+	cake := domain.Recipe{
+		ID:   1,
+		Name: "Торт",
+		Ingredients: []domain.Ingredient{
+			{Name: "мука", Quantity: 100},
+			{Name: "сахар", Quantity: 50},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		defaultMatch      Match
+		match             Match
+		ingredients       []Ingredient
+		expectedStarted   bool
+		expectedLeftovers []Ingredient
+		expectedErrorType string
+	}{
+		{
+			name:            "exact - точное соответствие",
+			match:           Match{Mode: MatchExact},
+			ingredients:     []Ingredient{{Name: "мука", Quantity: 100}, {Name: "сахар", Quantity: 50}},
+			expectedStarted: true,
+		},
+		{
+			name:        "exact - муки меньше нужного",
+			match:       Match{Mode: MatchExact},
+			ingredients: []Ingredient{{Name: "мука", Quantity: 90}, {Name: "сахар", Quantity: 50}},
+		},
+		{
+			name:        "exact - лишний ингредиент",
+			match:       Match{Mode: MatchExact},
+			ingredients: []Ingredient{{Name: "мука", Quantity: 100}, {Name: "сахар", Quantity: 50}, {Name: "перец", Quantity: 1}},
+		},
+		{
+			name:              "superset - лишнее уходит в остаток",
+			match:             Match{Mode: MatchSuperset},
+			ingredients:       []Ingredient{{Name: "мука", Quantity: 150}, {Name: "сахар", Quantity: 50}, {Name: "перец", Quantity: 1}},
+			expectedStarted:   true,
+			expectedLeftovers: []Ingredient{{Name: "мука", Quantity: 50, Unit: units.Piece}, {Name: "перец", Quantity: 1, Unit: units.Piece}},
+		},
+		{
+			name:        "superset - не хватает ингредиента",
+			match:       Match{Mode: MatchSuperset},
+			ingredients: []Ingredient{{Name: "мука", Quantity: 150}},
+		},
+		{
+			name:              "пустой котёл",
+			match:             Match{Mode: MatchSuperset},
+			ingredients:       []Ingredient{},
+			expectedErrorType: domainErrors.ValidationError,
+		},
+		{
+			name:            "tolerance - отклонение в пределах допуска",
+			match:           Match{Mode: MatchTolerance, TolerancePercent: percent(10)},
+			ingredients:     []Ingredient{{Name: "мука", Quantity: 95}, {Name: "сахар", Quantity: 55}},
+			expectedStarted: true,
+		},
+		{
+			name:        "tolerance - отклонение больше допуска",
+			match:       Match{Mode: MatchTolerance, TolerancePercent: percent(10)},
+			ingredients: []Ingredient{{Name: "мука", Quantity: 80}, {Name: "сахар", Quantity: 50}},
+		},
+		{
+			name:        "tolerance - лишний ингредиент",
+			match:       Match{Mode: MatchTolerance, TolerancePercent: percent(10)},
+			ingredients: []Ingredient{{Name: "мука", Quantity: 100}, {Name: "сахар", Quantity: 50}, {Name: "перец", Quantity: 1}},
+		},
+		{
+			name:            "tolerance - допуск из настроек процессора",
+			defaultMatch:    Match{Mode: MatchExact, TolerancePercent: percent(20)},
+			match:           Match{Mode: MatchTolerance},
+			ingredients:     []Ingredient{{Name: "мука", Quantity: 120}, {Name: "сахар", Quantity: 40}},
+			expectedStarted: true,
+		},
+		{
+			name:         "tolerance - явный нулевой допуск важнее настроек",
+			defaultMatch: Match{Mode: MatchExact, TolerancePercent: percent(20)},
+			match:        Match{Mode: MatchTolerance, TolerancePercent: percent(0)},
+			ingredients:  []Ingredient{{Name: "мука", Quantity: 120}, {Name: "сахар", Quantity: 40}},
+		},
+		{
+			name:        "режим по умолчанию - superset",
+			ingredients: []Ingredient{{Name: "мука", Quantity: 10}},
+		},
+		{
+			name:            "режим из настроек процессора",
+			defaultMatch:    Match{Mode: MatchTolerance, TolerancePercent: percent(50)},
+			ingredients:     []Ingredient{{Name: "мука", Quantity: 60}, {Name: "сахар", Quantity: 30}},
+			expectedStarted: true,
+		},
+		{
+			name:              "устаревший режим subset",
+			match:             Match{Mode: MatchSubset},
+			ingredients:       []Ingredient{{Name: "мука", Quantity: 10}},
+			expectedErrorType: domainErrors.ValidationError,
+		},
+		{
+			name:              "неизвестный режим",
+			match:             Match{Mode: "fuzzy"},
+			ingredients:       []Ingredient{{Name: "мука", Quantity: 100}},
+			expectedErrorType: domainErrors.ValidationError,
+		},
+		{
+			name:              "допуск больше 100 процентов",
+			match:             Match{Mode: MatchTolerance, TolerancePercent: percent(150)},
+			ingredients:       []Ingredient{{Name: "мука", Quantity: 100}},
+			expectedErrorType: domainErrors.ValidationError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			if tt.expectedErrorType == "" {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{cake}, nil)
+			}
+
+			var opts []Option
+			if tt.defaultMatch.Mode != "" {
+				opts = append(opts, WithDefaultMatch(tt.defaultMatch))
+			}
+			processor := NewGRPCProcessor(mockRepo, opts...)
+
+			// Act
+			result, err := processor.BrewPot(context.Background(), tt.ingredients, tt.match)
+
+			// Assert
+			if tt.expectedErrorType != "" {
+				var appErr *domainErrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedErrorType, appErr.Type)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStarted, result.Started)
+			if tt.expectedLeftovers != nil {
+				assert.Equal(t, tt.expectedLeftovers, result.Leftovers)
+			}
+		})
+	}
+}
+
+func TestProcessor_BrewPot_EmptyRecipe(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cake := domain.Recipe{ID: 1, Name: "Торт", Ingredients: []domain.Ingredient{{Name: "мука", Quantity: 1}}}
+	mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{{ID: 7, Name: "Пустышка"}, cake}, nil)
+
+	processor := NewGRPCProcessor(mockRepo)
+
+	// Act
+	result, err := processor.BrewPot(context.Background(), []Ingredient{{Name: "мука", Quantity: 1}}, Match{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.Started)
+	assert.Equal(t, int64(1), result.RecipeID)
+}
+
+func TestProcessor_matches(t *testing.T) {
+	tests := []struct {
+		name              string
+		match             Match
+		brewIngredients   map[string]int
+		recipeIngredients []domain.Ingredient
+		expected          bool
+	}{
+		{
+			name:              "superset - точное соответствие",
+			match:             Match{Mode: MatchSuperset},
+			brewIngredients:   map[string]int{"мука": 100},
+			recipeIngredients: []domain.Ingredient{{Name: "мука", Quantity: 100}},
+			expected:          true,
+		},
+		{
+			name:              "пустой рецепт - нельзя использовать ингредиенты",
+			match:             Match{Mode: MatchSuperset},
+			brewIngredients:   map[string]int{"мука": 100},
+			recipeIngredients: []domain.Ingredient{},
+			expected:          false, // в рецепте нет ингредиентов, поэтому мука не подходит
+		},
+		{
+			name:              "exact - пустой рецепт не подходит",
+			match:             Match{Mode: MatchExact},
+			brewIngredients:   map[string]int{"мука": 100},
+			recipeIngredients: []domain.Ingredient{},
+		},
+		{
+			name:              "tolerance - пустой рецепт не подходит",
+			match:             Match{Mode: MatchTolerance, TolerancePercent: percent(100)},
+			brewIngredients:   map[string]int{"мука": 100},
+			recipeIngredients: []domain.Ingredient{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			processor := NewGRPCProcessor(nil)
+			normalizer, err := processor.normalizer(context.Background())
+			assert.NoError(t, err)
+			pot := make([]Ingredient, 0, len(tt.brewIngredients))
+			for name, quantity := range tt.brewIngredients {
+				pot = append(pot, Ingredient{Name: name, Quantity: float64(quantity)})
+			}
+			normalized, err := normalizer.normalize(pot)
+			assert.NoError(t, err)
+			recipe := domain.Recipe{Ingredients: tt.recipeIngredients}
+
+			// Act
+			result := processor.matches(tt.match, amounts(normalized), normalizer.recipeIngredients(recipe))
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func percent(value int) *int {
+	return &value
+}
@@ -0,0 +1,168 @@
+package brew
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_BrewPot_Names(t *testing.T) {
+	potion := domain.Recipe{
+		ID:   1,
+		Name: "Зелье",
+		Ingredients: []domain.Ingredient{
+			{Name: "nettle", Quantity: 2},
+			{Name: "вода", Quantity: 1},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		synonyms          []domain.Synonym
+		ingredients       []Ingredient
+		expectedStarted   bool
+		expectedConsumed  []Ingredient
+		expectedLeftovers []Ingredient
+		// expectedViolations поля, отклонённые как повтор ингредиента.
+		expectedViolations []string
+	}{
+		{
+			name:              "регистр и пробелы не влияют на сопоставление",
+			ingredients:       []Ingredient{{Name: " Nettle ", Quantity: 2}, {Name: "ВОДА", Quantity: 1}},
+			expectedStarted:   true,
+			expectedConsumed:  []Ingredient{{Name: "nettle", Quantity: 2, Unit: units.Piece}, {Name: "вода", Quantity: 1, Unit: units.Piece}},
+			expectedLeftovers: []Ingredient{},
+		},
+		{
+			name:              "синоним заменяется каноническим названием",
+			synonyms:          []domain.Synonym{{Name: "крапива", Canonical: "nettle"}},
+			ingredients:       []Ingredient{{Name: "Крапива", Quantity: 2}, {Name: "вода", Quantity: 1}},
+			expectedStarted:   true,
+			expectedConsumed:  []Ingredient{{Name: "nettle", Quantity: 2, Unit: units.Piece}, {Name: "вода", Quantity: 1, Unit: units.Piece}},
+			expectedLeftovers: []Ingredient{},
+		},
+		{
+			name:               "синоним и каноническое название - повтор ингредиента",
+			synonyms:           []domain.Synonym{{Name: "крапива", Canonical: "nettle"}},
+			ingredients:        []Ingredient{{Name: "nettle", Quantity: 1}, {Name: "вода", Quantity: 1}, {Name: "крапива", Quantity: 2}},
+			expectedViolations: []string{"ingredients[2].name"},
+		},
+		{
+			name:               "названия, различающиеся регистром и пробелами, - повтор ингредиента",
+			ingredients:        []Ingredient{{Name: "Крапива", Quantity: 1}, {Name: "крапива ", Quantity: 2}, {Name: "вода", Quantity: 1}},
+			expectedViolations: []string{"ingredients[1].name"},
+		},
+		{
+			name:               "килограммы и граммы одного ингредиента - повтор",
+			ingredients:        []Ingredient{{Name: "вода", Quantity: 1, Unit: units.Kilogram}, {Name: "Вода", Quantity: 500, Unit: units.Gram}},
+			expectedViolations: []string{"ingredients[1].name"},
+		},
+		{
+			name:              "несводимые единицы одного ингредиента не повтор",
+			ingredients:       []Ingredient{{Name: "nettle", Quantity: 2}, {Name: "вода", Quantity: 1}, {Name: "вода", Quantity: 200, Unit: units.Gram}},
+			expectedStarted:   true,
+			expectedConsumed:  []Ingredient{{Name: "nettle", Quantity: 2, Unit: units.Piece}, {Name: "вода", Quantity: 1, Unit: units.Piece}},
+			expectedLeftovers: []Ingredient{{Name: "вода", Quantity: 200, Unit: units.Gram}},
+		},
+		{
+			name:        "без синонима название не совпадает",
+			ingredients: []Ingredient{{Name: "крапива", Quantity: 2}, {Name: "вода", Quantity: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{potion}, nil)
+
+			mockSynonyms := mock_repository.NewMockSynonymRepositoryInterface(ctrl)
+			mockSynonyms.EXPECT().GetSynonyms(gomock.Any()).Return(tt.synonyms, nil)
+
+			processor := NewGRPCProcessor(mockRepo, WithDefaultMatch(Match{Mode: MatchSuperset}), WithNames(names.NewNormalizer(), mockSynonyms))
+
+			// Act
+			result, err := processor.BrewPot(context.Background(), tt.ingredients, Match{})
+
+			// Assert
+			if tt.expectedViolations != nil {
+				var appErr *domainErrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, domainErrors.ValidationError, appErr.Type)
+
+				fields := make([]string, 0, len(appErr.Violations))
+				for _, violation := range appErr.Violations {
+					fields = append(fields, violation.Field)
+				}
+				assert.Equal(t, tt.expectedViolations, fields)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStarted, result.Started)
+			if tt.expectedStarted {
+				assert.Equal(t, tt.expectedConsumed, result.Consumed)
+				assert.Equal(t, tt.expectedLeftovers, result.Leftovers)
+			}
+		})
+	}
+}
+
+func TestProcessor_BrewPot_DensitySynonym(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recipe := domain.Recipe{ID: 1, Name: "Сбитень", Ingredients: []domain.Ingredient{{Name: "honey", Quantity: 14, Unit: units.Gram}}}
+	mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{recipe}, nil)
+
+	mockSynonyms := mock_repository.NewMockSynonymRepositoryInterface(ctrl)
+	mockSynonyms.EXPECT().GetSynonyms(gomock.Any()).Return([]domain.Synonym{{Name: "мёд", Canonical: "honey"}}, nil)
+
+	processor := NewGRPCProcessor(mockRepo,
+		WithNames(names.NewNormalizer(), mockSynonyms),
+		WithUnits(units.NewRegistry(units.WithDensities(map[string]float64{" Мёд": 1.4}))),
+	)
+
+	// Act
+	result, err := processor.BrewPot(context.Background(), []Ingredient{{Name: "мёд", Quantity: 10, Unit: units.Millilitre}}, Match{Mode: MatchExact})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.Started)
+	assert.Equal(t, []Ingredient{{Name: "honey", Quantity: 14, Unit: units.Gram}}, result.Consumed)
+}
+
+func TestProcessor_BrewPot_SynonymsError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{}, nil)
+
+	mockSynonyms := mock_repository.NewMockSynonymRepositoryInterface(ctrl)
+	mockSynonyms.EXPECT().GetSynonyms(gomock.Any()).Return(nil, errors.New("database error"))
+
+	processor := NewGRPCProcessor(mockRepo, WithNames(names.NewNormalizer(), mockSynonyms))
+
+	// Act
+	result, err := processor.BrewPot(context.Background(), []Ingredient{{Name: "вода", Quantity: 1}}, Match{})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
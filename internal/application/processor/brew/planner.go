@@ -0,0 +1,450 @@
+package brew
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+type PlannerConfig struct {
+	// TimeBudget время на поиск плана, по истечении возвращается лучший найденный.
+	TimeBudget time.Duration
+	// ExactMaxRecipes до скольких рецептов план ищется перебором с отсечениями,
+	// для больших каталогов используется жадный алгоритм с локальным улучшением.
+	ExactMaxRecipes int
+}
+
+// WithPlannerConfig задаёт ограничения планировщика производства.
+func WithPlannerConfig(config PlannerConfig) Option {
+	return func(p *Processor) {
+		p.planner = config
+	}
+}
+
+// PlanTarget рецепт, который может войти в план.
+type PlanTarget struct {
+	RecipeID int64
+	// Value ценность одной варки, 0 означает 1.
+	Value float64
+	// MinBatches сколько варок должно быть в плане обязательно.
+	MinBatches int
+	// MaxBatches больше скольких варок не планировать, 0 без ограничения.
+	MaxBatches int
+}
+
+// PlanQuery запрос плана производства. Без Targets в плане участвуют все рецепты с ценностью 1.
+type PlanQuery struct {
+	Targets     []PlanTarget
+	Ingredients []Ingredient
+}
+
+type PlannedBrew struct {
+	Recipe  domain.Recipe
+	Batches int
+	Value   float64
+}
+
+// Plan набор варок с наибольшей суммарной ценностью, который помещается в запасы.
+type Plan struct {
+	Brews      []PlannedBrew
+	TotalValue float64
+	// Leftovers запасы, оставшиеся после всех варок плана.
+	Leftovers []Ingredient
+	// Optimal план доказанно оптимален; false, если поиск был эвристическим или прерван по времени.
+	Optimal bool
+}
+
+// planItem рецепт плана с количествами, сведёнными по названиям ингредиентов.
+type planItem struct {
+	recipe   domain.Recipe
+	value    float64
+	min      int
+	max      int
+	need     map[ingredientKey]float64
+	batches  int
+	position int
+}
+
+// PlanProduction подбирает количество варок каждого рецепта, максимизирующее суммарную
+// ценность при общих запасах ингредиентов.
+func (p *Processor) PlanProduction(ctx context.Context, query PlanQuery) (*Plan, error) {
+	if err := validatePlanQuery(query); err != nil {
+		return nil, err
+	}
+
+	normalizer, err := p.normalizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredients, err := normalizer.normalize(query.Ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := p.planItems(ctx, normalizer, query.Targets)
+	if err != nil {
+		return nil, err
+	}
+
+	stock := amounts(ingredients)
+
+	// Обязательные варки списываются сразу, оптимизируются только дополнительные.
+	for _, item := range items {
+		if !take(stock, item.need, item.min) {
+			return nil, domainErrors.NewValidationError(errors.New("stock is not enough for min batches"), domainErrors.FieldViolation{
+				Field:       "targets",
+				Description: "stock is not enough to brew the minimum batches of all targets",
+			})
+		}
+	}
+
+	deadline := time.Now().Add(p.planner.TimeBudget)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	planner := &planner{items: items, deadline: deadline}
+	optimal := planner.solve(stock, len(items) <= p.planner.ExactMaxRecipes)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		Brews:     make([]PlannedBrew, 0),
+		Leftovers: make([]Ingredient, 0),
+		Optimal:   optimal,
+	}
+
+	slices.SortFunc(items, func(a, b *planItem) int { return a.position - b.position })
+	for _, item := range items {
+		batches := item.min + planner.best[item.position]
+		take(stock, item.need, planner.best[item.position])
+		if batches == 0 {
+			continue
+		}
+
+		value := float64(batches) * item.value
+		plan.Brews = append(plan.Brews, PlannedBrew{Recipe: item.recipe, Batches: batches, Value: value})
+		plan.TotalValue += value
+	}
+
+	for _, ingredient := range ingredients {
+		if quantity := stock[ingredient.key()]; quantity > epsilon {
+			plan.Leftovers = append(plan.Leftovers, Ingredient{Name: ingredient.Name, Quantity: quantity, Unit: ingredient.Unit})
+		}
+	}
+
+	return plan, nil
+}
+
+func (p *Processor) planItems(ctx context.Context, normalizer *normalizer, targets []PlanTarget) ([]*planItem, error) {
+	if len(targets) == 0 {
+		recipesList, err := p.repo.GetRecipes(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recipes: %w", err)
+		}
+
+		for _, recipe := range recipesList {
+			targets = append(targets, PlanTarget{RecipeID: recipe.ID})
+		}
+
+		return newPlanItems(normalizer, targets, recipesList), nil
+	}
+
+	recipesList := make([]domain.Recipe, 0, len(targets))
+	for _, target := range targets {
+		recipe, err := p.repo.GetRecipe(ctx, target.RecipeID)
+		if err != nil {
+			return nil, err
+		}
+
+		recipesList = append(recipesList, *recipe)
+	}
+
+	return newPlanItems(normalizer, targets, recipesList), nil
+}
+
+func newPlanItems(normalizer *normalizer, targets []PlanTarget, recipesList []domain.Recipe) []*planItem {
+	items := make([]*planItem, 0, len(targets))
+	for i, target := range targets {
+		item := &planItem{
+			recipe:   recipesList[i],
+			value:    target.Value,
+			min:      target.MinBatches,
+			max:      target.MaxBatches,
+			need:     make(map[ingredientKey]float64),
+			position: i,
+		}
+		if item.value == 0 {
+			item.value = 1
+		}
+
+		for _, ingredient := range normalizer.recipeIngredients(recipesList[i]) {
+			if ingredient.Quantity > 0 {
+				item.need[ingredient.key()] = ingredient.Quantity
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func validatePlanQuery(query PlanQuery) error {
+	if err := validateIngredients(query.Ingredients); err != nil {
+		return err
+	}
+
+	var violations []domainErrors.FieldViolation
+	for i, target := range query.Targets {
+		if target.RecipeID < 1 {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("targets[%d].recipe_id", i),
+				Description: "recipe id must be positive",
+			})
+		}
+
+		if target.Value < 0 {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("targets[%d].value", i),
+				Description: "value must not be negative",
+			})
+		}
+
+		if target.MinBatches < 0 {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("targets[%d].min_batches", i),
+				Description: "min batches must not be negative",
+			})
+		}
+
+		if target.MaxBatches < 0 || (target.MaxBatches > 0 && target.MaxBatches < target.MinBatches) {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("targets[%d].max_batches", i),
+				Description: "max batches must be 0 or not less than min batches",
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return domainErrors.NewValidationError(errors.New("invalid production plan query"), violations...)
+	}
+
+	return nil
+}
+
+// take списывает из stock ингредиенты на batches варок, если их хватает.
+func take(stock map[ingredientKey]float64, need map[ingredientKey]float64, batches int) bool {
+	for key, quantity := range need {
+		if less(stock[key], quantity*float64(batches)) {
+			return false
+		}
+	}
+
+	for key, quantity := range need {
+		stock[key] -= quantity * float64(batches)
+	}
+
+	return true
+}
+
+func restore(stock map[ingredientKey]float64, need map[ingredientKey]float64, batches int) {
+	for key, quantity := range need {
+		stock[key] += quantity * float64(batches)
+	}
+}
+
+// planner ищет дополнительные варки сверх обязательных. best хранится по position рецепта.
+type planner struct {
+	items     []*planItem
+	deadline  time.Time
+	best      []int
+	bestValue float64
+	nodes     int
+	expired   bool
+}
+
+// solve возвращает true, если найденный план доказанно оптимален.
+func (pl *planner) solve(stock map[ingredientKey]float64, exact bool) bool {
+	pl.best = make([]int, len(pl.items))
+	pl.bestValue = -1
+
+	// Сначала рецепты с наибольшей ценностью на единицу запасов, которые они расходуют.
+	density := make(map[*planItem]float64, len(pl.items))
+	for _, item := range pl.items {
+		var weight float64
+		for key, quantity := range item.need {
+			weight += quantity / max(stock[key], 1)
+		}
+		density[item] = item.value / max(weight, 1e-9)
+	}
+	slices.SortStableFunc(pl.items, func(a, b *planItem) int {
+		return cmp.Compare(density[b], density[a])
+	})
+
+	pl.greedy(stock)
+
+	if exact {
+		for _, item := range pl.items {
+			item.batches = 0
+		}
+		pl.branch(stock, 0, 0)
+
+		return !pl.expired
+	}
+
+	pl.improve(stock)
+
+	return false
+}
+
+// headroom сколько ещё варок item помещается в stock сверх запланированных с учётом MaxBatches.
+// Рецепт без ингредиентов и без MaxBatches ничем не ограничен, такие варки не планируются.
+func (pl *planner) headroom(stock map[ingredientKey]float64, item *planItem) int {
+	limit := -1
+	if item.max > 0 {
+		limit = item.max - item.min - item.batches
+	}
+
+	for key, quantity := range item.need {
+		if batches := batchesOf(stock[key], quantity); limit < 0 || batches < limit {
+			limit = batches
+		}
+	}
+
+	return max(limit, 0)
+}
+
+// greedy заполняет запасы рецептами по порядку и запоминает результат как лучший план.
+func (pl *planner) greedy(stock map[ingredientKey]float64) {
+	for _, item := range pl.items {
+		item.batches = 0
+		item.batches = pl.headroom(stock, item)
+		take(stock, item.need, item.batches)
+	}
+
+	pl.record()
+
+	for _, item := range pl.items {
+		restore(stock, item.need, item.batches)
+	}
+}
+
+func (pl *planner) value() float64 {
+	var total float64
+	for _, item := range pl.items {
+		total += float64(item.batches) * item.value
+	}
+
+	return total
+}
+
+func (pl *planner) record() {
+	if value := pl.value(); value > pl.bestValue {
+		pl.bestValue = value
+		for _, item := range pl.items {
+			pl.best[item.position] = item.batches
+		}
+	}
+}
+
+func (pl *planner) timeUp() bool {
+	pl.nodes++
+	if !pl.expired && pl.nodes%1024 == 0 && time.Now().After(pl.deadline) {
+		pl.expired = true
+	}
+
+	return pl.expired
+}
+
+// branch перебирает количество варок рецептов начиная с i, отсекая ветви, в которых
+// даже независимый максимум каждого оставшегося рецепта не превзойдёт лучший план.
+func (pl *planner) branch(stock map[ingredientKey]float64, i int, value float64) {
+	if pl.timeUp() {
+		return
+	}
+
+	if i == len(pl.items) {
+		pl.record()
+		return
+	}
+
+	bound := value
+	for _, item := range pl.items[i:] {
+		bound += float64(pl.headroom(stock, item)) * item.value
+	}
+	if bound <= pl.bestValue {
+		return
+	}
+
+	item := pl.items[i]
+	for batches := pl.headroom(stock, item); batches >= 0 && !pl.expired; batches-- {
+		take(stock, item.need, batches)
+		item.batches = batches
+		pl.branch(stock, i+1, value+float64(batches)*item.value)
+		item.batches = 0
+		restore(stock, item.need, batches)
+	}
+}
+
+// improve улучшает жадный план: убирает одну варку рецепта и заново жадно заполняет
+// освободившиеся запасы остальными рецептами, пока это увеличивает ценность плана.
+// Как и остальные шаги поиска, возвращает stock в исходное состояние.
+func (pl *planner) improve(stock map[ingredientKey]float64) {
+	for _, item := range pl.items {
+		take(stock, item.need, item.batches)
+	}
+	defer func() {
+		for _, item := range pl.items {
+			restore(stock, item.need, item.batches)
+		}
+	}()
+
+	snapshot := make([]int, len(pl.items))
+	for improved := true; improved; {
+		improved = false
+		for _, removed := range pl.items {
+			if removed.batches == 0 {
+				continue
+			}
+			if pl.timeUp() {
+				return
+			}
+
+			for i, item := range pl.items {
+				snapshot[i] = item.batches
+			}
+
+			removed.batches--
+			restore(stock, removed.need, 1)
+			for _, item := range pl.items {
+				if item == removed {
+					continue
+				}
+
+				extra := pl.headroom(stock, item)
+				take(stock, item.need, extra)
+				item.batches += extra
+			}
+
+			if pl.value() > pl.bestValue {
+				pl.record()
+				improved = true
+				continue
+			}
+
+			for i, item := range pl.items {
+				restore(stock, item.need, item.batches-snapshot[i])
+				item.batches = snapshot[i]
+			}
+		}
+	}
+}
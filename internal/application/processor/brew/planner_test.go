@@ -0,0 +1,157 @@
+package brew
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_PlanProduction(t *testing.T) {
+	pie := domain.Recipe{ID: 1, Name: "Пирог", Ingredients: []domain.Ingredient{{Name: "мука", Quantity: 6}}}
+	bun := domain.Recipe{ID: 2, Name: "Булка", Ingredients: []domain.Ingredient{{Name: "мука", Quantity: 5}}}
+	tea := domain.Recipe{ID: 3, Name: "Чай", Ingredients: []domain.Ingredient{{Name: "вода", Quantity: 2}}}
+	roll := domain.Recipe{ID: 4, Name: "Рулет", Ingredients: []domain.Ingredient{{Name: "мука", Quantity: 2}}}
+
+	expectRecipes := func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+		mockRepo.EXPECT().GetRecipe(gomock.Any(), int64(1)).Return(&pie, nil)
+		mockRepo.EXPECT().GetRecipe(gomock.Any(), int64(2)).Return(&bun, nil)
+	}
+
+	tests := []struct {
+		name              string
+		config            PlannerConfig
+		query             PlanQuery
+		mockSetup         func(*mock_repository.MockRecipeRepositoryInterface)
+		expected          *Plan
+		expectedErrorType string
+	}{
+		{
+			name:   "точный перебор находит план лучше жадного",
+			config: PlannerConfig{TimeBudget: time.Second, ExactMaxRecipes: 10},
+			query: PlanQuery{
+				Targets:     []PlanTarget{{RecipeID: 1, Value: 5}, {RecipeID: 2, Value: 4}},
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 10}, {Name: "соль", Quantity: 3}},
+			},
+			mockSetup: expectRecipes,
+			expected: &Plan{
+				Brews:      []PlannedBrew{{Recipe: bun, Batches: 2, Value: 8}},
+				TotalValue: 8,
+				Leftovers:  []Ingredient{{Name: "соль", Quantity: 3, Unit: units.Piece}},
+				Optimal:    true,
+			},
+		},
+		{
+			name:   "эвристика для большого каталога улучшает жадный план",
+			config: PlannerConfig{TimeBudget: time.Second, ExactMaxRecipes: 1},
+			query: PlanQuery{
+				Targets:     []PlanTarget{{RecipeID: 1, Value: 5}, {RecipeID: 2, Value: 4}},
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 10}},
+			},
+			mockSetup: expectRecipes,
+			expected: &Plan{
+				Brews:      []PlannedBrew{{Recipe: bun, Batches: 2, Value: 8}},
+				TotalValue: 8,
+				Leftovers:  []Ingredient{},
+			},
+		},
+		{
+			name:   "эвристика оставляет неизрасходованные запасы",
+			config: PlannerConfig{TimeBudget: time.Second},
+			query: PlanQuery{
+				Targets:     []PlanTarget{{RecipeID: 4, MaxBatches: 2}},
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 10}},
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipe(gomock.Any(), int64(4)).Return(&roll, nil)
+			},
+			expected: &Plan{
+				Brews:      []PlannedBrew{{Recipe: roll, Batches: 2, Value: 2}},
+				TotalValue: 2,
+				Leftovers:  []Ingredient{{Name: "мука", Quantity: 6, Unit: units.Piece}},
+			},
+		},
+		{
+			name:   "обязательные и максимальные варки",
+			config: PlannerConfig{TimeBudget: time.Second, ExactMaxRecipes: 10},
+			query: PlanQuery{
+				Targets:     []PlanTarget{{RecipeID: 1, Value: 5, MinBatches: 1}, {RecipeID: 2, Value: 4, MaxBatches: 1}},
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 12}},
+			},
+			mockSetup: expectRecipes,
+			expected: &Plan{
+				Brews:      []PlannedBrew{{Recipe: pie, Batches: 2, Value: 10}},
+				TotalValue: 10,
+				Leftovers:  []Ingredient{},
+				Optimal:    true,
+			},
+		},
+		{
+			name:   "без целей планируются все рецепты",
+			config: PlannerConfig{TimeBudget: time.Second, ExactMaxRecipes: 10},
+			query: PlanQuery{
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 11}, {Name: "вода", Quantity: 5}},
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{pie, bun, tea}, nil)
+			},
+			expected: &Plan{
+				Brews:      []PlannedBrew{{Recipe: bun, Batches: 2, Value: 2}, {Recipe: tea, Batches: 2, Value: 2}},
+				TotalValue: 4,
+				Leftovers:  []Ingredient{{Name: "мука", Quantity: 1, Unit: units.Piece}, {Name: "вода", Quantity: 1, Unit: units.Piece}},
+				Optimal:    true,
+			},
+		},
+		{
+			name: "запасов не хватает на обязательные варки",
+			query: PlanQuery{
+				Targets:     []PlanTarget{{RecipeID: 1, MinBatches: 2}, {RecipeID: 2}},
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 10}},
+			},
+			mockSetup:         expectRecipes,
+			expectedErrorType: domainErrors.ValidationError,
+		},
+		{
+			name: "максимум меньше минимума",
+			query: PlanQuery{
+				Targets: []PlanTarget{{RecipeID: 1, MinBatches: 2, MaxBatches: 1}},
+			},
+			mockSetup:         func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {},
+			expectedErrorType: domainErrors.ValidationError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			tt.mockSetup(mockRepo)
+
+			processor := NewGRPCProcessor(mockRepo, WithPlannerConfig(tt.config))
+
+			// Act
+			plan, err := processor.PlanProduction(context.Background(), tt.query)
+
+			// Assert
+			if tt.expectedErrorType != "" {
+				var appErr *domainErrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedErrorType, appErr.Type)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, plan)
+		})
+	}
+}
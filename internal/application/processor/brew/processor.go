@@ -2,65 +2,352 @@ package brew
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
 	"github.com/vostelmakh/mixturka/internal/infrastructure/repository"
 )
 
-const (
-	successfulBrew = true
-	failedBrew     = false
-)
-
+// Ingredient количество ингредиента, пустая Unit означает штуки. Количества в ответах
+// процессора приведены к базовым единицам: граммам, миллилитрам или штукам.
 type Ingredient struct {
 	Name     string
-	Quantity int
+	Quantity float64
+	Unit     units.Unit
+}
+
+// Result итог варки. Если подходящего рецепта нет, Started = false и остальные поля пустые.
+type Result struct {
+	Started bool
+	// BrewID идентификатор варки.
+	BrewID     string
+	RecipeID   int64
+	RecipeName string
+	// Consumed ингредиенты, израсходованные на рецепт, в порядке рецепта.
+	Consumed []Ingredient
+	// Leftovers остаток переданных ингредиентов после варки, в порядке передачи.
+	Leftovers []Ingredient
+	// Substitutions замены, без которых рецепт не подошёл бы.
+	Substitutions []AppliedSubstitution
+	// Corrections неизвестные ингредиенты, исправленные в режиме автоисправления.
+	Corrections []Correction
+	// State состояние начатой варки, пустое без WithSessions.
+	State domain.BrewState
+	// StartAt время запуска запланированной варки, nil — варка начинается сразу.
+	StartAt *time.Time
+	// QueuePosition позиция варки в очереди к котлам, 0 — варка получила котёл или запланирована.
+	QueuePosition int
 }
 
 type Processor struct {
-	repo repository.RecipeRepositoryInterface
+	repo         repository.RecipeRepositoryInterface
+	defaultMatch Match
+	planner      PlannerConfig
+	// substitutions правила замены ингредиентов, nil — замены не учитываются.
+	substitutions repository.SubstitutionRepositoryInterface
+	units         *units.Registry
+	// names и synonyms приводят названия ингредиентов к каноническим, nil synonyms — без синонимов.
+	names    *names.Normalizer
+	synonyms repository.SynonymRepositoryInterface
+	fuzzy    FuzzyConfig
+	// sessions ведёт начатые варки, nil — варки не отслеживаются.
+	sessions *Sessions
+	// history журнал вызовов BrewPot, nil — вызовы не записываются.
+	history repository.BrewHistoryRepositoryInterface
+}
+
+type Option func(*Processor)
+
+// WithDefaultMatch задаёт правила сопоставления для запросов, в которых они не указаны.
+func WithDefaultMatch(match Match) Option {
+	return func(p *Processor) {
+		p.defaultMatch = match
+	}
+}
+
+// WithNames задаёт нормализацию названий ингредиентов и словарь синонимов.
+func WithNames(normalizer *names.Normalizer, synonyms repository.SynonymRepositoryInterface) Option {
+	return func(p *Processor) {
+		p.names = normalizer
+		p.synonyms = synonyms
+	}
 }
 
-func NewGRPCProcessor(repo repository.RecipeRepositoryInterface) *Processor {
-	return &Processor{
-		repo: repo,
+func NewGRPCProcessor(repo repository.RecipeRepositoryInterface, opts ...Option) *Processor {
+	p := &Processor{
+		repo:         repo,
+		defaultMatch: Match{Mode: MatchSuperset},
+		planner: PlannerConfig{
+			TimeBudget:      2 * time.Second,
+			ExactMaxRecipes: 20,
+		},
+		units: units.NewRegistry(),
+		names: names.NewNormalizer(),
+		fuzzy: FuzzyConfig{
+			Algorithm:            names.AlgorithmEditDistance,
+			Threshold:            0.6,
+			MaxSuggestions:       3,
+			AutoCorrectThreshold: 0.85,
+		},
 	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// BrewPot варит первый рецепт, который подходит к ingredients по правилам match.
+// Если рецепт не подходит как есть, недостающие ингредиенты покрываются правилами замены.
+// Количества котла и рецепта сравниваются в базовых единицах, названия — после нормализации
+// и замены синонимов каноническими. Если рецепт не нашёлся, а среди ingredients есть неизвестные,
+// похожие на известные, возвращается ошибка валидации с подсказками.
+func (p *Processor) BrewPot(ctx context.Context, ingredients []Ingredient, match Match) (*Result, error) {
+	result, err := p.brewPot(ctx, ingredients, match, nil)
+	p.record(ctx, ingredients, match, nil, result, err)
+
+	return result, err
 }
 
-func (p *Processor) BrewPot(ctx context.Context, ingredients []Ingredient) (bool, error) {
+// ScheduleBrew подбирает рецепт к ingredients так же, как BrewPot, и ставит варку в очередь
+// с запуском в startAt. Если рецепт не подходит, возвращается ошибка валидации.
+func (p *Processor) ScheduleBrew(ctx context.Context, ingredients []Ingredient, match Match, startAt time.Time) (*Result, error) {
+	result, err := p.scheduleBrew(ctx, ingredients, match, startAt)
+	p.record(ctx, ingredients, match, &startAt, result, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Started {
+		return nil, domainErrors.NewValidationError(errors.New("no recipe matches the ingredients"), domainErrors.FieldViolation{
+			Field:       "ingredients",
+			Description: "no recipe matches the ingredients",
+		})
+	}
+
+	return result, nil
+}
+
+func (p *Processor) scheduleBrew(ctx context.Context, ingredients []Ingredient, match Match, startAt time.Time) (*Result, error) {
+	if p.sessions == nil {
+		return nil, domainErrors.NewAppError(errors.New("brew scheduling is not enabled"), domainErrors.FailedPrecondition)
+	}
+
+	if err := p.sessions.validateStartAt(startAt); err != nil {
+		return nil, err
+	}
+
+	return p.brewPot(ctx, ingredients, match, &startAt)
+}
+
+func (p *Processor) brewPot(ctx context.Context, ingredients []Ingredient, match Match, startAt *time.Time) (*Result, error) {
+	if err := validatePot(ingredients); err != nil {
+		return nil, err
+	}
+
+	match, err := match.resolve(p.defaultMatch)
+	if err != nil {
+		return nil, err
+	}
+
 	recipesList, err := p.repo.GetRecipes(ctx)
 	if err != nil {
-		return false, fmt.Errorf("failed to get recipes: %w", err)
+		return nil, fmt.Errorf("failed to get recipes: %w", err)
 	}
 
-	brewIngredients := make(map[string]int)
-	for _, ingredient := range ingredients {
-		brewIngredients[ingredient.Name] = ingredient.Quantity
+	normalizer, err := p.normalizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := p.substitutionRules(ctx, normalizer)
+	if err != nil {
+		return nil, err
 	}
 
+	vocabulary := p.vocabulary(normalizer, recipesList, rules)
+	ingredients, corrections := p.autoCorrect(vocabulary, normalizer, ingredients)
+
+	pot, err := normalizer.normalize(ingredients)
+	if err != nil {
+		return nil, err
+	}
+	brewIngredients := amounts(pot)
+
 	for _, recipe := range recipesList {
-		if p.canBrew(brewIngredients, recipe.Ingredients) {
-			fmt.Printf("Successfully brewed %s!\n", recipe.Name)
+		recipeIngredients := normalizer.recipeIngredients(recipe)
+		if p.matches(match, brewIngredients, recipeIngredients) {
+			result := brewResult(pot, recipe, recipeIngredients, nil)
+			result.Corrections = corrections
+			if err := p.begin(ctx, recipe, result, startAt); err != nil {
+				return nil, err
+			}
+
+			return result, nil
+		}
 
-			return successfulBrew, nil
+		if len(rules) == 0 {
+			continue
 		}
+
+		substituted, applied := substitute(brewIngredients, recipe.ID, recipeIngredients, rules)
+		if len(applied) > 0 && p.matches(match, substituted, recipeIngredients) {
+			result := brewResult(pot, recipe, recipeIngredients, applied)
+			result.Corrections = corrections
+			if err := p.begin(ctx, recipe, result, startAt); err != nil {
+				return nil, err
+			}
+
+			return result, nil
+		}
+	}
+
+	if err := p.unknownIngredients(vocabulary, normalizer, ingredients); err != nil {
+		return nil, err
+	}
+
+	return &Result{Corrections: corrections}, nil
+}
+
+// begin сохраняет начатую варку, если варки отслеживаются. Варка со startAt ждёт в очереди до startAt.
+func (p *Processor) begin(ctx context.Context, recipe domain.Recipe, result *Result, startAt *time.Time) error {
+	if p.sessions == nil {
+		return nil
+	}
+
+	brew := &domain.Brew{
+		ID:         result.BrewID,
+		RecipeID:   recipe.ID,
+		RecipeName: recipe.Name,
+		Duration:   p.sessions.duration(recipe),
+		Consumed:   toDomainIngredients(result.Consumed),
+		Leftovers:  toDomainIngredients(result.Leftovers),
+		StartAt:    startAt,
+	}
+	position, err := p.sessions.begin(ctx, brew)
+	if err != nil {
+		return err
+	}
+
+	result.State = brew.State
+	result.StartAt = brew.StartAt
+	result.QueuePosition = position
+
+	return nil
+}
+
+func toDomainIngredients(ingredients []Ingredient) []domain.Ingredient {
+	result := make([]domain.Ingredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		result = append(result, domain.Ingredient{Name: ingredient.Name, Quantity: ingredient.Quantity, Unit: ingredient.Unit})
 	}
 
-	return failedBrew, nil
+	return result
 }
-func (p *Processor) canBrew(brewIngredients map[string]int, recipeIngredients []domain.Ingredient) bool {
-	recipeIngredientsMap := make(map[string]int)
+
+// brewResult списывает на рецепт не больше, чем он требует, и заменители из applied,
+// остальное остаётся в котле. pot и recipeIngredients в базовых единицах.
+func brewResult(pot []Ingredient, recipe domain.Recipe, recipeIngredients []Ingredient, applied []AppliedSubstitution) *Result {
+	remaining := amounts(pot)
+
+	result := &Result{
+		Started:       true,
+		BrewID:        uuid.NewString(),
+		RecipeID:      recipe.ID,
+		RecipeName:    recipe.Name,
+		Consumed:      make([]Ingredient, 0, len(recipeIngredients)),
+		Leftovers:     make([]Ingredient, 0),
+		Substitutions: applied,
+	}
+
 	for _, ingredient := range recipeIngredients {
-		recipeIngredientsMap[ingredient.Name] = ingredient.Quantity
+		quantity := min(remaining[ingredient.key()], ingredient.Quantity)
+		if quantity <= 0 {
+			continue
+		}
+
+		remaining[ingredient.key()] -= quantity
+		result.Consumed = append(result.Consumed, Ingredient{Name: ingredient.Name, Quantity: quantity, Unit: ingredient.Unit})
+	}
+
+	for _, substitution := range applied {
+		used := Ingredient{Name: substitution.Substitute, Quantity: substitution.SubstituteQuantity, Unit: substitution.SubstituteUnit}
+		remaining[used.key()] -= used.Quantity
+		result.Consumed = addIngredient(result.Consumed, used)
+	}
+
+	for _, ingredient := range pot {
+		if quantity := remaining[ingredient.key()]; quantity > epsilon {
+			result.Leftovers = append(result.Leftovers, Ingredient{Name: ingredient.Name, Quantity: quantity, Unit: ingredient.Unit})
+		}
 	}
 
-	for brewIngredientName, brewIngredientQuantity := range brewIngredients {
-		ingredientQuantity, ok := recipeIngredientsMap[brewIngredientName]
-		if !ok || brewIngredientQuantity > ingredientQuantity {
-			return false
+	return result
+}
+
+// addIngredient прибавляет количество ingredient к такому же ингредиенту в ingredients
+// или добавляет его в конец.
+func addIngredient(ingredients []Ingredient, ingredient Ingredient) []Ingredient {
+	for i := range ingredients {
+		if ingredients[i].key() == ingredient.key() {
+			ingredients[i].Quantity += ingredient.Quantity
+			return ingredients
 		}
 	}
 
-	return true
+	return append(ingredients, ingredient)
+}
+
+func validateIngredients(ingredients []Ingredient) error {
+	var violations []domainErrors.FieldViolation
+	for i, ingredient := range ingredients {
+		if ingredient.Name == "" {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("ingredients[%d].name", i),
+				Description: "name must not be empty",
+			})
+		}
+
+		if !(ingredient.Quantity > 0) || math.IsInf(ingredient.Quantity, 1) {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("ingredients[%d].quantity", i),
+				Description: "quantity must be positive",
+			})
+		}
+
+		if _, err := units.Parse(string(ingredient.Unit)); err != nil {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("ingredients[%d].unit", i),
+				Description: err.Error(),
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return domainErrors.NewValidationError(errors.New("invalid brew ingredients"), violations...)
+	}
+
+	return nil
+}
+
+// validatePot проверяет ингредиенты котла: в отличие от запаса для подбора и расчётов,
+// пустой котёл сварить нельзя.
+func validatePot(ingredients []Ingredient) error {
+	if len(ingredients) == 0 {
+		return domainErrors.NewValidationError(errors.New("invalid brew ingredients"), domainErrors.FieldViolation{
+			Field:       "ingredients",
+			Description: "at least one ingredient is required",
+		})
+	}
+
+	return validateIngredients(ingredients)
 }
@@ -7,18 +7,19 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vostelmakh/mixturka/internal/domain"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
 	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
 )
 
 func TestProcessor_BrewPot(t *testing.T) {
 	tests := []struct {
-		name               string
-		ingredients        []Ingredient
-		mockSetup          func(*mock_repository.MockRecipeRepositoryInterface)
-		expectedResult     bool
-		expectedError      string
-		expectedPrintCount int
+		name           string
+		ingredients    []Ingredient
+		mockSetup      func(*mock_repository.MockRecipeRepositoryInterface)
+		expectedResult *Result
+		expectedError  string
 	}{
 		{
 			name: "успешное варение - точное соответствие ингредиентов",
@@ -40,11 +41,17 @@ func TestProcessor_BrewPot(t *testing.T) {
 						},
 					}, nil)
 			},
-			expectedResult: true,
-			expectedError:  "",
+			expectedResult: &Result{
+				Started:    true,
+				RecipeID:   1,
+				RecipeName: "Торт",
+				Consumed:   []Ingredient{{Name: "мука", Quantity: 100, Unit: units.Piece}, {Name: "сахар", Quantity: 50, Unit: units.Piece}},
+				Leftovers:  []Ingredient{},
+			},
+			expectedError: "",
 		},
 		{
-			name: "успешное варение - ингредиентов больше чем нужно",
+			name: "неуспешное варение - ингредиентов меньше, чем требует рецепт",
 			ingredients: []Ingredient{
 				{Name: "мука", Quantity: 80},
 				{Name: "сахар", Quantity: 30},
@@ -63,7 +70,7 @@ func TestProcessor_BrewPot(t *testing.T) {
 						},
 					}, nil)
 			},
-			expectedResult: true,
+			expectedResult: &Result{},
 			expectedError:  "",
 		},
 		{
@@ -86,7 +93,7 @@ func TestProcessor_BrewPot(t *testing.T) {
 						},
 					}, nil)
 			},
-			expectedResult: false,
+			expectedResult: &Result{},
 			expectedError:  "",
 		},
 		{
@@ -109,7 +116,7 @@ func TestProcessor_BrewPot(t *testing.T) {
 						},
 					}, nil)
 			},
-			expectedResult: false,
+			expectedResult: &Result{},
 			expectedError:  "",
 		},
 		{
@@ -123,7 +130,7 @@ func TestProcessor_BrewPot(t *testing.T) {
 					GetRecipes(gomock.Any()).
 					Return([]domain.Recipe{}, nil)
 			},
-			expectedResult: false,
+			expectedResult: &Result{},
 			expectedError:  "",
 		},
 		{
@@ -136,7 +143,7 @@ func TestProcessor_BrewPot(t *testing.T) {
 					GetRecipes(gomock.Any()).
 					Return(nil, errors.New("ошибка базы данных"))
 			},
-			expectedResult: false,
+			expectedResult: nil,
 			expectedError:  "failed to get recipes",
 		},
 		{
@@ -167,25 +174,20 @@ func TestProcessor_BrewPot(t *testing.T) {
 						},
 					}, nil)
 			},
-			expectedResult: true,
-			expectedError:  "",
+			expectedResult: &Result{
+				Started:    true,
+				RecipeID:   1,
+				RecipeName: "Торт",
+				Consumed:   []Ingredient{{Name: "мука", Quantity: 100, Unit: units.Piece}, {Name: "сахар", Quantity: 50, Unit: units.Piece}},
+				Leftovers:  []Ingredient{},
+			},
+			expectedError: "",
 		},
 		{
-			name:        "успешное варение - пустой список ингредиентов для варки",
-			ingredients: []Ingredient{},
-			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
-				mockRepo.EXPECT().
-					GetRecipes(gomock.Any()).
-					Return([]domain.Recipe{
-						{
-							ID:          1,
-							Name:        "Пустой рецепт",
-							Ingredients: []domain.Ingredient{},
-						},
-					}, nil)
-			},
-			expectedResult: true,
-			expectedError:  "",
+			name:          "неуспешное варение - пустой котёл",
+			ingredients:   []Ingredient{},
+			mockSetup:     func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {},
+			expectedError: "invalid brew ingredients",
 		},
 	}
 
@@ -202,9 +204,13 @@ func TestProcessor_BrewPot(t *testing.T) {
 			ctx := context.Background()
 
 			// Act
-			result, err := processor.BrewPot(ctx, tt.ingredients)
+			result, err := processor.BrewPot(ctx, tt.ingredients, Match{})
 
 			// Assert
+			if result != nil && result.Started {
+				assert.NotEmpty(t, result.BrewID)
+				result.BrewID = ""
+			}
 			assert.Equal(t, tt.expectedResult, result)
 
 			if tt.expectedError != "" {
@@ -217,96 +223,35 @@ func TestProcessor_BrewPot(t *testing.T) {
 	}
 }
 
-// TestProcessor_canBrew тестирует внутреннюю логику сравнения ингредиентов
-func TestProcessor_canBrew(t *testing.T) {
-	tests := []struct {
-		name              string
-		brewIngredients   map[string]int
-		recipeIngredients []domain.Ingredient
-		expected          bool
-	}{
-		{
-			name: "точное соответствие",
-			brewIngredients: map[string]int{
-				"мука":  100,
-				"сахар": 50,
-			},
-			recipeIngredients: []domain.Ingredient{
-				{Name: "мука", Quantity: 100},
-				{Name: "сахар", Quantity: 50},
-			},
-			expected: true,
-		},
-		{
-			name: "больше ингредиентов чем нужно",
-			brewIngredients: map[string]int{
-				"мука":  80,
-				"сахар": 30,
-			},
-			recipeIngredients: []domain.Ingredient{
-				{Name: "мука", Quantity: 100},
-				{Name: "сахар", Quantity: 50},
-			},
-			expected: true,
-		},
-		{
-			name: "недостаточно одного ингредиента",
-			brewIngredients: map[string]int{
-				"мука":  150,
-				"сахар": 30,
-			},
-			recipeIngredients: []domain.Ingredient{
-				{Name: "мука", Quantity: 100},
-				{Name: "сахар", Quantity: 50},
-			},
-			expected: false,
-		},
-		{
-			name: "ингредиент отсутствует в рецепте",
-			brewIngredients: map[string]int{
-				"мука":  100,
-				"перец": 10, // этого ингредиента нет в рецепте
-			},
-			recipeIngredients: []domain.Ingredient{
-				{Name: "мука", Quantity: 100},
-				{Name: "сахар", Quantity: 50},
-			},
-			expected: false,
-		},
-		{
-			name:            "пустые ингредиенты для варки - можно сварить любой рецепт",
-			brewIngredients: map[string]int{},
-			recipeIngredients: []domain.Ingredient{
-				{Name: "мука", Quantity: 100},
-			},
-			expected: true, // пустой список ингредиентов означает что ничего не требуется
-		},
-		{
-			name: "пустой рецепт - нельзя использовать ингредиенты",
-			brewIngredients: map[string]int{
-				"мука": 100,
-			},
-			recipeIngredients: []domain.Ingredient{},
-			expected:          false, // в рецепте нет ингредиентов, поэтому мука не подходит
-		},
-		{
-			name:              "пустой рецепт и пустые ингредиенты",
-			brewIngredients:   map[string]int{},
-			recipeIngredients: []domain.Ingredient{},
-			expected:          true, // ничего не нужно для пустого рецепта
+func TestBrewResult(t *testing.T) {
+	// Arrange
+	ingredients := []Ingredient{
+		{Name: "перец", Quantity: 10},
+		{Name: "мука", Quantity: 150},
+		{Name: "сахар", Quantity: 50},
+	}
+	recipe := domain.Recipe{
+		ID:   1,
+		Name: "Торт",
+		Ingredients: []domain.Ingredient{
+			{Name: "мука", Quantity: 100},
+			{Name: "сахар", Quantity: 50},
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Arrange
-			processor := &Processor{}
+	normalizer, err := NewGRPCProcessor(nil).normalizer(context.Background())
+	require.NoError(t, err)
+	pot, err := normalizer.normalize(ingredients)
+	require.NoError(t, err)
 
-			// Act
-			result := processor.canBrew(tt.brewIngredients, tt.recipeIngredients)
+	// Act
+	result := brewResult(pot, recipe, normalizer.recipeIngredients(recipe), nil)
 
-			// Assert
-			assert.Equal(t, tt.expected, result)
-		})
-	}
+	// Assert
+	assert.True(t, result.Started)
+	assert.NotEmpty(t, result.BrewID)
+	assert.Equal(t, int64(1), result.RecipeID)
+	assert.Equal(t, "Торт", result.RecipeName)
+	assert.Equal(t, []Ingredient{{Name: "мука", Quantity: 100, Unit: units.Piece}, {Name: "сахар", Quantity: 50, Unit: units.Piece}}, result.Consumed)
+	assert.Equal(t, []Ingredient{{Name: "перец", Quantity: 10, Unit: units.Piece}, {Name: "мука", Quantity: 50, Unit: units.Piece}}, result.Leftovers)
 }
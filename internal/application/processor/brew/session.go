@@ -0,0 +1,345 @@
+package brew
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/repository"
+)
+
+// CancelPolicy что происходит с израсходованными ингредиентами при отмене варки.
+type CancelPolicy string
+
+const (
+	// CancelReturn возвращает ингредиенты при любой отмене.
+	CancelReturn CancelPolicy = "return"
+	// CancelLose считает ингредиенты потерянными.
+	CancelLose CancelPolicy = "lose"
+	// CancelReturnQueued возвращает ингредиенты, только если варка ещё не началась.
+	CancelReturnQueued CancelPolicy = "return_queued"
+)
+
+// returns сообщает, возвращаются ли ингредиенты варки, отменённой в состоянии state.
+// Неизвестная политика ведёт себя как CancelReturnQueued.
+func (p CancelPolicy) returns(state domain.BrewState) bool {
+	switch p {
+	case CancelReturn:
+		return true
+	case CancelLose:
+		return false
+	default:
+		return state == domain.BrewQueued
+	}
+}
+
+type SessionConfig struct {
+	// DefaultDuration длительность варки рецептов без собственной BrewDuration.
+	DefaultDuration time.Duration
+	CancelPolicy    CancelPolicy
+	// MaxScheduleAhead насколько вперёд можно запланировать варку, 0 — без ограничения.
+	MaxScheduleAhead time.Duration
+	// Cauldrons число одновременных варок, не меньше 1.
+	Cauldrons int
+	// QueueSize сколько варок может ждать свободного котла. Новая варка сверх этого
+	// отклоняется с ResourceExhausted, запланированные варки встают в очередь в свой срок всегда.
+	QueueSize int
+}
+
+// Sessions ведёт начатые варки по состояниям queued → brewing → finished и хранит их
+// в репозитории, чтобы клиент мог следить за котлом после BrewPot.
+type Sessions struct {
+	repo   repository.BrewRepositoryInterface
+	config SessionConfig
+	now    func() time.Time
+
+	mu sync.Mutex
+	// ctx задаётся в Start, до него варки только сохраняются и подхватываются при старте.
+	ctx       context.Context
+	workers   map[string]*worker
+	cauldrons cauldrons
+}
+
+// worker ведёт одну варку. Отмена ctx останавливает её, даже если этап уже выполняется.
+type worker struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	// timer таймер следующего этапа, nil пока этап выполняется.
+	timer *time.Timer
+	// brew и enqueuedAt варка, ждущая котла в очереди, и время постановки в очередь.
+	brew       domain.Brew
+	enqueuedAt time.Time
+	// cauldron варка занимает котёл.
+	cauldron bool
+}
+
+func NewSessions(repo repository.BrewRepositoryInterface, config SessionConfig) *Sessions {
+	config.Cauldrons = max(config.Cauldrons, 1)
+	config.QueueSize = max(config.QueueSize, 0)
+
+	return &Sessions{
+		repo:    repo,
+		config:  config,
+		now:     time.Now,
+		workers: make(map[string]*worker),
+	}
+}
+
+// WithSessions сохраняет начатые варки и ведёт их до завершения.
+func WithSessions(sessions *Sessions) Option {
+	return func(p *Processor) {
+		p.sessions = sessions
+	}
+}
+
+// Start подхватывает варки, не завершённые до перезапуска, и ведёт варки, пока ctx не отменён.
+// Варка, время этапа которой вышло во время простоя, переходит к следующему этапу сразу.
+func (s *Sessions) Start(ctx context.Context) error {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+
+	brews, err := s.repo.FindBrews(ctx, domain.BrewQuery{States: []domain.BrewState{domain.BrewQueued, domain.BrewBrewing}})
+	if err != nil {
+		return fmt.Errorf("failed to recover brews: %w", err)
+	}
+
+	// FindBrews возвращает новые первыми, очередь продолжается со старых.
+	slices.Reverse(brews)
+	for _, brew := range brews {
+		s.schedule(brew)
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.stop()
+	}()
+
+	return nil
+}
+
+// duration длительность варки recipe.
+func (s *Sessions) duration(recipe domain.Recipe) time.Duration {
+	if recipe.BrewDuration > 0 {
+		return recipe.BrewDuration
+	}
+
+	return s.config.DefaultDuration
+}
+
+// validateStartAt проверяет время запуска запланированной варки.
+func (s *Sessions) validateStartAt(startAt time.Time) error {
+	now := s.now()
+
+	var description string
+	switch {
+	case !startAt.After(now):
+		description = "start time must be in the future"
+	case s.config.MaxScheduleAhead > 0 && startAt.Sub(now) > s.config.MaxScheduleAhead:
+		description = fmt.Sprintf("start time must be at most %s ahead", s.config.MaxScheduleAhead)
+	default:
+		return nil
+	}
+
+	return domainErrors.NewValidationError(errors.New("invalid start time"), domainErrors.FieldViolation{
+		Field:       "start_at",
+		Description: description,
+	})
+}
+
+// begin сохраняет варку в состоянии queued и ставит её в очередь к котлам.
+// Возвращает позицию в очереди, 0 — варка получила котёл или запланирована.
+func (s *Sessions) begin(ctx context.Context, brew *domain.Brew) (int, error) {
+	if brew.StartAt == nil {
+		if err := s.reserve(); err != nil {
+			return 0, err
+		}
+		defer s.unreserve()
+	}
+
+	brew.State = domain.BrewQueued
+	brew.CreatedAt = s.now()
+
+	if err := s.repo.SaveBrew(ctx, brew); err != nil {
+		return 0, fmt.Errorf("failed to save brew: %w", err)
+	}
+
+	return s.schedule(*brew), nil
+}
+
+// schedule ведёт варку к следующему этапу: queued встаёт в очередь к котлам сразу
+// или в StartAt, brewing завершается по истечении Duration с начала.
+// Возвращает позицию варки в очереди, 0 — варка не ждёт котла.
+func (s *Sessions) schedule(brew domain.Brew) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx == nil || s.ctx.Err() != nil {
+		return 0
+	}
+
+	w, ok := s.workers[brew.ID]
+	if !ok {
+		w = &worker{}
+		w.ctx, w.cancel = context.WithCancel(s.ctx)
+		s.workers[brew.ID] = w
+	}
+
+	if w.timer != nil {
+		return 0
+	}
+
+	if brew.State == domain.BrewQueued {
+		if brew.StartAt == nil || !brew.StartAt.After(s.now()) {
+			return s.enqueueLocked(w, brew)
+		}
+
+		w.timer = time.AfterFunc(brew.StartAt.Sub(s.now()), func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			w.timer = nil
+			if w.ctx.Err() == nil {
+				s.enqueueLocked(w, brew)
+			}
+		})
+
+		return 0
+	}
+
+	s.occupyLocked(w)
+
+	var delay time.Duration
+	if brew.StartedAt != nil {
+		delay = max(brew.StartedAt.Add(brew.Duration).Sub(s.now()), 0)
+	}
+
+	w.timer = time.AfterFunc(delay, func() { s.advance(w, brew) })
+
+	return 0
+}
+
+// advance переводит варку на следующий этап. Если состояние в репозитории уже изменилось,
+// например варку отменили, переход не выполняется.
+func (s *Sessions) advance(w *worker, brew domain.Brew) {
+	s.mu.Lock()
+	w.timer = nil
+	s.mu.Unlock()
+
+	ctx := w.ctx
+	if ctx.Err() != nil {
+		return
+	}
+
+	from := brew.State
+	next := domain.BrewFinished
+	if from == domain.BrewQueued {
+		next = domain.BrewBrewing
+	}
+
+	current := brew
+	if err := brew.Transition(next, s.now()); err != nil {
+		log.Printf("brew: %v", err)
+		s.release(brew.ID)
+		return
+	}
+
+	updated, err := s.repo.UpdateBrew(ctx, &brew, from)
+	if err != nil {
+		s.fail(ctx, current, err)
+		s.release(brew.ID)
+		return
+	}
+
+	if updated && next == domain.BrewBrewing {
+		s.schedule(brew)
+		return
+	}
+
+	s.release(brew.ID)
+}
+
+// fail отмечает варку неудавшейся с причиной cause.
+func (s *Sessions) fail(ctx context.Context, brew domain.Brew, cause error) {
+	// Отменённую варку не помечаем неудавшейся.
+	if ctx.Err() != nil {
+		return
+	}
+
+	log.Printf("brew %s: %v", brew.ID, cause)
+
+	from := brew.State
+	if err := brew.Transition(domain.BrewFailed, s.now()); err != nil {
+		return
+	}
+	brew.Error = cause.Error()
+
+	if _, err := s.repo.UpdateBrew(ctx, &brew, from); err != nil {
+		log.Printf("brew %s: failed to mark as failed: %v", brew.ID, err)
+	}
+}
+
+// Cancel отменяет незавершённую варку id и останавливает её обработку. Ингредиенты
+// возвращаются или теряются по CancelPolicy.
+func (s *Sessions) Cancel(ctx context.Context, id, caller, by, reason string) (*domain.Brew, error) {
+	// Варка меняет состояние не больше двух раз, поэтому повторы конечны.
+	for {
+		brew, err := s.repo.GetBrew(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if brew.State.Terminal() {
+			return nil, domainErrors.NewAppError(fmt.Errorf("brew is already %s", brew.State), domainErrors.FailedPrecondition)
+		}
+
+		from := brew.State
+		if err := brew.Cancel(caller, by, reason, s.config.CancelPolicy.returns(from), s.now()); err != nil {
+			return nil, err
+		}
+
+		updated, err := s.repo.UpdateBrew(ctx, brew, from)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cancel brew: %w", err)
+		}
+
+		if updated {
+			s.release(id)
+			return brew, nil
+		}
+	}
+}
+
+// release останавливает обработку варки id.
+func (s *Sessions) release(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if w, ok := s.workers[id]; ok {
+		s.stopWorker(id, w)
+	}
+}
+
+func (s *Sessions) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, w := range s.workers {
+		s.stopWorker(id, w)
+	}
+}
+
+func (s *Sessions) stopWorker(id string, w *worker) {
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.cancel()
+	delete(s.workers, id)
+	s.leaveLocked(w)
+}
@@ -0,0 +1,414 @@
+package brew
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+// recordUpdates записывает состояния, в которые переводится варка, и закрывает done после last.
+func recordUpdates(repo *mock_repository.MockBrewRepositoryInterface, last domain.BrewState, done chan<- struct{}) *[]domain.BrewState {
+	states := make([]domain.BrewState, 0)
+	repo.EXPECT().UpdateBrew(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, brew *domain.Brew, _ domain.BrewState) (bool, error) {
+			states = append(states, brew.State)
+			if brew.State == last {
+				close(done)
+			}
+			return true, nil
+		}).AnyTimes()
+
+	return &states
+}
+
+func waitFor(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("brew did not reach the expected state")
+	}
+}
+
+func TestProcessor_BrewPot_Session(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recipe := domain.Recipe{
+		ID:           1,
+		Name:         "Зелье",
+		Ingredients:  []domain.Ingredient{{Name: "вода", Quantity: 1}},
+		BrewDuration: 20 * time.Millisecond,
+	}
+
+	mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{recipe}, nil)
+
+	var saved domain.Brew
+	mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+	mockBrews.EXPECT().FindBrews(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockBrews.EXPECT().SaveBrew(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, brew *domain.Brew) error {
+		saved = *brew
+		return nil
+	})
+	done := make(chan struct{})
+	states := recordUpdates(mockBrews, domain.BrewFinished, done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sessions := NewSessions(mockBrews, SessionConfig{DefaultDuration: time.Hour})
+	require.NoError(t, sessions.Start(ctx))
+
+	processor := NewGRPCProcessor(mockRepo, WithSessions(sessions))
+
+	// Act
+	result, err := processor.BrewPot(ctx, []Ingredient{{Name: "вода", Quantity: 1}}, Match{})
+	require.NoError(t, err)
+	waitFor(t, done)
+
+	// Assert
+	assert.Equal(t, domain.BrewQueued, result.State)
+	assert.Equal(t, result.BrewID, saved.ID)
+	assert.Equal(t, domain.BrewQueued, saved.State)
+	assert.Equal(t, int64(1), saved.RecipeID)
+	assert.Equal(t, 20*time.Millisecond, saved.Duration)
+	assert.Len(t, saved.Consumed, 1)
+	assert.Equal(t, []domain.BrewState{domain.BrewBrewing, domain.BrewFinished}, *states)
+}
+
+func TestSessions_Start_Recovers(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	startedAt := time.Now().Add(-time.Hour)
+	brewing := domain.Brew{ID: "b1", State: domain.BrewBrewing, Duration: time.Minute, StartedAt: &startedAt}
+
+	mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+	mockBrews.EXPECT().FindBrews(gomock.Any(), domain.BrewQuery{States: []domain.BrewState{domain.BrewQueued, domain.BrewBrewing}}).
+		Return([]domain.Brew{brewing}, nil)
+	done := make(chan struct{})
+	states := recordUpdates(mockBrews, domain.BrewFinished, done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sessions := NewSessions(mockBrews, SessionConfig{DefaultDuration: time.Hour})
+
+	// Act
+	err := sessions.Start(ctx)
+	waitFor(t, done)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.BrewState{domain.BrewFinished}, *states)
+}
+
+func TestSessions_Advance(t *testing.T) {
+	tests := []struct {
+		name           string
+		updated        bool
+		updateErr      error
+		expectedStates []domain.BrewState
+	}{
+		{
+			name:           "состояние изменилось, варка дальше не ведётся",
+			updated:        false,
+			expectedStates: []domain.BrewState{domain.BrewBrewing},
+		},
+		{
+			name:           "ошибка сохранения переводит варку в failed",
+			updateErr:      errors.New("database error"),
+			expectedStates: []domain.BrewState{domain.BrewBrewing, domain.BrewFailed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			states := make([]domain.BrewState, 0)
+			mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+			mockBrews.EXPECT().UpdateBrew(gomock.Any(), gomock.Any(), domain.BrewQueued).
+				DoAndReturn(func(_ context.Context, brew *domain.Brew, _ domain.BrewState) (bool, error) {
+					states = append(states, brew.State)
+					return tt.updated, tt.updateErr
+				})
+			if tt.updateErr != nil {
+				mockBrews.EXPECT().UpdateBrew(gomock.Any(), gomock.Any(), domain.BrewQueued).
+					DoAndReturn(func(_ context.Context, brew *domain.Brew, _ domain.BrewState) (bool, error) {
+						states = append(states, brew.State)
+						assert.Equal(t, "database error", brew.Error)
+						return true, nil
+					})
+			}
+
+			sessions := NewSessions(mockBrews, SessionConfig{DefaultDuration: time.Millisecond})
+			sessions.ctx = context.Background()
+			w := &worker{}
+			w.ctx, w.cancel = context.WithCancel(sessions.ctx)
+			sessions.workers["b1"] = w
+
+			// Act
+			sessions.advance(w, domain.Brew{ID: "b1", State: domain.BrewQueued, Duration: time.Millisecond})
+
+			// Assert
+			assert.Equal(t, tt.expectedStates, states)
+			assert.Empty(t, sessions.workers)
+		})
+	}
+}
+
+func TestSessions_Cancel(t *testing.T) {
+	startedAt := time.Now()
+
+	tests := []struct {
+		name             string
+		policy           CancelPolicy
+		brew             domain.Brew
+		expectedReturned bool
+		expectedType     string
+	}{
+		{
+			name:             "в очереди по умолчанию ингредиенты возвращаются",
+			brew:             domain.Brew{ID: "b1", State: domain.BrewQueued},
+			expectedReturned: true,
+		},
+		{
+			name: "в котле по умолчанию ингредиенты теряются",
+			brew: domain.Brew{ID: "b1", State: domain.BrewBrewing, StartedAt: &startedAt},
+		},
+		{
+			name:             "политика return возвращает ингредиенты из котла",
+			policy:           CancelReturn,
+			brew:             domain.Brew{ID: "b1", State: domain.BrewBrewing, StartedAt: &startedAt},
+			expectedReturned: true,
+		},
+		{
+			name:   "политика lose теряет ингредиенты из очереди",
+			policy: CancelLose,
+			brew:   domain.Brew{ID: "b1", State: domain.BrewQueued},
+		},
+		{
+			name:         "завершённую варку не отменить",
+			brew:         domain.Brew{ID: "b1", State: domain.BrewFinished},
+			expectedType: domainErrors.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stored := tt.brew
+			mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+			mockBrews.EXPECT().GetBrew(gomock.Any(), "b1").Return(&stored, nil)
+			if tt.expectedType == "" {
+				mockBrews.EXPECT().UpdateBrew(gomock.Any(), gomock.Any(), tt.brew.State).Return(true, nil)
+			}
+
+			sessions := NewSessions(mockBrews, SessionConfig{CancelPolicy: tt.policy})
+
+			// Act
+			brew, err := sessions.Cancel(context.Background(), "b1", "10.0.0.7", "alchemist", "не тот котёл")
+
+			// Assert
+			if tt.expectedType != "" {
+				var appErr *domainErrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedType, appErr.Type)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, domain.BrewCancelled, brew.State)
+			assert.Equal(t, "alchemist", brew.CancelledBy)
+			assert.Equal(t, "10.0.0.7", brew.CancelledByCaller)
+			assert.Equal(t, "не тот котёл", brew.CancelReason)
+			assert.Equal(t, tt.expectedReturned, brew.IngredientsReturned)
+		})
+	}
+}
+
+func TestSessions_Cancel_StopsWorker(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	startedAt := time.Now()
+	brewing := domain.Brew{ID: "b1", State: domain.BrewBrewing, Duration: time.Hour, StartedAt: &startedAt}
+
+	mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+	mockBrews.EXPECT().FindBrews(gomock.Any(), gomock.Any()).Return([]domain.Brew{brewing}, nil)
+	mockBrews.EXPECT().GetBrew(gomock.Any(), "b1").Return(&domain.Brew{ID: "b1", State: domain.BrewQueued}, nil)
+	// Варка успела начаться между чтением и записью: отмена повторяется с новым состоянием.
+	gomock.InOrder(
+		mockBrews.EXPECT().UpdateBrew(gomock.Any(), gomock.Any(), domain.BrewQueued).Return(false, nil),
+		mockBrews.EXPECT().UpdateBrew(gomock.Any(), gomock.Any(), domain.BrewBrewing).Return(true, nil),
+	)
+	mockBrews.EXPECT().GetBrew(gomock.Any(), "b1").Return(&brewing, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sessions := NewSessions(mockBrews, SessionConfig{})
+	require.NoError(t, sessions.Start(ctx))
+	require.Len(t, sessions.workers, 1)
+	w := sessions.workers["b1"]
+
+	// Act
+	brew, err := sessions.Cancel(ctx, "b1", "", "alchemist", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.BrewCancelled, brew.State)
+	assert.False(t, brew.IngredientsReturned)
+	assert.Empty(t, sessions.workers)
+	assert.ErrorIs(t, w.ctx.Err(), context.Canceled)
+}
+
+func TestProcessor_ScheduleBrew(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	recipe := domain.Recipe{ID: 1, Name: "Зелье", Ingredients: []domain.Ingredient{{Name: "вода", Quantity: 1}}}
+
+	tests := []struct {
+		name         string
+		ingredients  []Ingredient
+		startAt      time.Time
+		withSessions bool
+		mockSetup    func(*mock_repository.MockRecipeRepositoryInterface, *mock_repository.MockBrewRepositoryInterface)
+		expectedType string
+	}{
+		{
+			name:         "варка запланирована",
+			ingredients:  []Ingredient{{Name: "вода", Quantity: 1}},
+			startAt:      now.Add(8 * time.Hour),
+			withSessions: true,
+			mockSetup: func(repo *mock_repository.MockRecipeRepositoryInterface, brews *mock_repository.MockBrewRepositoryInterface) {
+				repo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{recipe}, nil)
+				brews.EXPECT().SaveBrew(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, brew *domain.Brew) error {
+					assert.Equal(t, domain.BrewQueued, brew.State)
+					assert.Equal(t, now.Add(8*time.Hour), *brew.StartAt)
+					return nil
+				})
+			},
+		},
+		{
+			name:         "время запуска в прошлом",
+			ingredients:  []Ingredient{{Name: "вода", Quantity: 1}},
+			startAt:      now.Add(-time.Minute),
+			withSessions: true,
+			expectedType: domainErrors.ValidationError,
+		},
+		{
+			name:         "время запуска дальше допустимого",
+			ingredients:  []Ingredient{{Name: "вода", Quantity: 1}},
+			startAt:      now.Add(48 * time.Hour),
+			withSessions: true,
+			expectedType: domainErrors.ValidationError,
+		},
+		{
+			name:         "рецепт не подходит",
+			ingredients:  []Ingredient{{Name: "вода", Quantity: 1}, {Name: "соль", Quantity: 1}},
+			startAt:      now.Add(time.Hour),
+			withSessions: true,
+			mockSetup: func(repo *mock_repository.MockRecipeRepositoryInterface, brews *mock_repository.MockBrewRepositoryInterface) {
+				repo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{recipe}, nil)
+			},
+			expectedType: domainErrors.ValidationError,
+		},
+		{
+			name:         "варки не отслеживаются",
+			ingredients:  []Ingredient{{Name: "вода", Quantity: 1}},
+			startAt:      now.Add(time.Hour),
+			expectedType: domainErrors.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockRepo, mockBrews)
+			}
+
+			opts := []Option{WithDefaultMatch(Match{Mode: MatchExact})}
+			if tt.withSessions {
+				sessions := NewSessions(mockBrews, SessionConfig{MaxScheduleAhead: 24 * time.Hour})
+				sessions.now = func() time.Time { return now }
+				opts = append(opts, WithSessions(sessions))
+			}
+			processor := NewGRPCProcessor(mockRepo, opts...)
+
+			// Act
+			result, err := processor.ScheduleBrew(context.Background(), tt.ingredients, Match{}, tt.startAt)
+
+			// Assert
+			if tt.expectedType != "" {
+				var appErr *domainErrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedType, appErr.Type)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, result.Started)
+			assert.Equal(t, domain.BrewQueued, result.State)
+			assert.Equal(t, tt.startAt, *result.StartAt)
+		})
+	}
+}
+
+func TestSessions_Start_WaitsForStartAt(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	startAt := time.Now().Add(50 * time.Millisecond)
+	scheduled := domain.Brew{ID: "b1", State: domain.BrewQueued, Duration: time.Hour, StartAt: &startAt}
+
+	mockBrews := mock_repository.NewMockBrewRepositoryInterface(ctrl)
+	mockBrews.EXPECT().FindBrews(gomock.Any(), gomock.Any()).Return([]domain.Brew{scheduled}, nil)
+	done := make(chan struct{})
+	var startedAt time.Time
+	mockBrews.EXPECT().UpdateBrew(gomock.Any(), gomock.Any(), domain.BrewQueued).
+		DoAndReturn(func(_ context.Context, brew *domain.Brew, _ domain.BrewState) (bool, error) {
+			startedAt = *brew.StartedAt
+			close(done)
+			return true, nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sessions := NewSessions(mockBrews, SessionConfig{})
+
+	// Act
+	err := sessions.Start(ctx)
+	waitFor(t, done)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, startedAt.Before(startAt))
+}
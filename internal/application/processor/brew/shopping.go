@@ -0,0 +1,118 @@
+package brew
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+)
+
+// ShoppingTarget рецепт, который нужно сварить Batches раз.
+type ShoppingTarget struct {
+	RecipeID int64
+	// Batches количество варок, 0 означает одну.
+	Batches int
+}
+
+// ShoppingQuery запрос списка покупок для нескольких рецептов с учётом имеющихся ингредиентов.
+type ShoppingQuery struct {
+	Targets     []ShoppingTarget
+	Ingredients []Ingredient
+}
+
+// ShoppingItem сколько ингредиента нужно на все рецепты, сколько есть и сколько докупить,
+// в базовой единице Unit.
+type ShoppingItem struct {
+	Name    string
+	Unit    units.Unit
+	Needed  float64
+	Have    float64
+	Missing float64
+}
+
+// ShoppingList считает сводную потребность в ингредиентах для всех рецептов запроса.
+// Ингредиенты идут в порядке первого упоминания в рецептах.
+func (p *Processor) ShoppingList(ctx context.Context, query ShoppingQuery) ([]ShoppingItem, error) {
+	if err := validateShoppingQuery(query); err != nil {
+		return nil, err
+	}
+
+	normalizer, err := p.normalizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := normalizer.normalize(query.Ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	have := amounts(stock)
+
+	items := make([]ShoppingItem, 0)
+	indexes := make(map[ingredientKey]int)
+	for _, target := range query.Targets {
+		recipe, err := p.repo.GetRecipe(ctx, target.RecipeID)
+		if err != nil {
+			return nil, err
+		}
+
+		batches := float64(max(target.Batches, 1))
+		for _, ingredient := range normalizer.recipeIngredients(*recipe) {
+			idx, ok := indexes[ingredient.key()]
+			if !ok {
+				items = append(items, ShoppingItem{Name: ingredient.Name, Unit: ingredient.Unit, Have: have[ingredient.key()]})
+				idx = len(items) - 1
+				indexes[ingredient.key()] = idx
+			}
+
+			items[idx].Needed += ingredient.Quantity * batches
+		}
+	}
+
+	for i := range items {
+		if missing := items[i].Needed - items[i].Have; missing > epsilon {
+			items[i].Missing = missing
+		}
+	}
+
+	return items, nil
+}
+
+func validateShoppingQuery(query ShoppingQuery) error {
+	if err := validateIngredients(query.Ingredients); err != nil {
+		return err
+	}
+
+	var violations []domainErrors.FieldViolation
+	if len(query.Targets) == 0 {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "targets",
+			Description: "at least one target recipe is required",
+		})
+	}
+
+	for i, target := range query.Targets {
+		if target.RecipeID < 1 {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("targets[%d].recipe_id", i),
+				Description: "recipe id must be positive",
+			})
+		}
+
+		if target.Batches < 0 {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("targets[%d].batches", i),
+				Description: "batches must not be negative",
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return domainErrors.NewValidationError(errors.New("invalid shopping list query"), violations...)
+	}
+
+	return nil
+}
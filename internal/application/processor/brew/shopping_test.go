@@ -0,0 +1,109 @@
+package brew
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_ShoppingList(t *testing.T) {
+	cake := &domain.Recipe{ID: 1, Name: "Торт", Ingredients: []domain.Ingredient{{Name: "мука", Quantity: 100}, {Name: "сахар", Quantity: 50}}}
+	bread := &domain.Recipe{ID: 2, Name: "Хлеб", Ingredients: []domain.Ingredient{{Name: "мука", Quantity: 200}, {Name: "дрожжи", Quantity: 10}}}
+
+	tests := []struct {
+		name              string
+		query             ShoppingQuery
+		mockSetup         func(*mock_repository.MockRecipeRepositoryInterface)
+		expected          []ShoppingItem
+		expectedErrorType string
+	}{
+		{
+			name: "один рецепт на несколько варок",
+			query: ShoppingQuery{
+				Targets:     []ShoppingTarget{{RecipeID: 1, Batches: 3}},
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 120}, {Name: "сахар", Quantity: 200}},
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipe(gomock.Any(), int64(1)).Return(cake, nil)
+			},
+			expected: []ShoppingItem{
+				{Name: "мука", Unit: units.Piece, Needed: 300, Have: 120, Missing: 180},
+				{Name: "сахар", Unit: units.Piece, Needed: 150, Have: 200, Missing: 0},
+			},
+		},
+		{
+			name: "сводный список по нескольким рецептам",
+			query: ShoppingQuery{
+				Targets:     []ShoppingTarget{{RecipeID: 1}, {RecipeID: 2, Batches: 2}},
+				Ingredients: []Ingredient{{Name: "дрожжи", Quantity: 5}},
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipe(gomock.Any(), int64(1)).Return(cake, nil)
+				mockRepo.EXPECT().GetRecipe(gomock.Any(), int64(2)).Return(bread, nil)
+			},
+			expected: []ShoppingItem{
+				{Name: "мука", Unit: units.Piece, Needed: 500, Have: 0, Missing: 500},
+				{Name: "сахар", Unit: units.Piece, Needed: 50, Have: 0, Missing: 50},
+				{Name: "дрожжи", Unit: units.Piece, Needed: 20, Have: 5, Missing: 15},
+			},
+		},
+		{
+			name: "рецепт не найден",
+			query: ShoppingQuery{
+				Targets: []ShoppingTarget{{RecipeID: 42}},
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipe(gomock.Any(), int64(42)).Return(nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound))
+			},
+			expectedErrorType: domainErrors.NotFound,
+		},
+		{
+			name:              "без рецептов",
+			query:             ShoppingQuery{},
+			mockSetup:         func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {},
+			expectedErrorType: domainErrors.ValidationError,
+		},
+		{
+			name: "отрицательное количество варок",
+			query: ShoppingQuery{
+				Targets: []ShoppingTarget{{RecipeID: 1, Batches: -1}},
+			},
+			mockSetup:         func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {},
+			expectedErrorType: domainErrors.ValidationError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			tt.mockSetup(mockRepo)
+
+			processor := NewGRPCProcessor(mockRepo)
+
+			// Act
+			items, err := processor.ShoppingList(context.Background(), tt.query)
+
+			// Assert
+			if tt.expectedErrorType != "" {
+				var appErr *domainErrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedErrorType, appErr.Type)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, items)
+		})
+	}
+}
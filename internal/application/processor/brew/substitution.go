@@ -0,0 +1,109 @@
+package brew
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/repository"
+)
+
+// AppliedSubstitution замена, использованная при варке: Quantity ингредиента Ingredient
+// заменено SubstituteQuantity заменителя Substitute. Количества в базовых единицах.
+type AppliedSubstitution struct {
+	Ingredient         string
+	Substitute         string
+	Quantity           float64
+	SubstituteQuantity float64
+	Unit               units.Unit
+	SubstituteUnit     units.Unit
+}
+
+// WithSubstitutions включает учёт правил замены ингредиентов при варке.
+func WithSubstitutions(repo repository.SubstitutionRepositoryInterface) Option {
+	return func(p *Processor) {
+		p.substitutions = repo
+	}
+}
+
+// substitutionRules возвращает правила замены с каноническими названиями ингредиентов.
+func (p *Processor) substitutionRules(ctx context.Context, normalizer *normalizer) ([]domain.Substitution, error) {
+	if p.substitutions == nil {
+		return nil, nil
+	}
+
+	rules, err := p.substitutions.GetSubstitutions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get substitutions: %w", err)
+	}
+
+	for i := range rules {
+		rules[i].Ingredient = normalizer.names.Canonical(rules[i].Ingredient)
+		rules[i].Substitute = normalizer.names.Canonical(rules[i].Substitute)
+	}
+
+	return rules, nil
+}
+
+// substitute возвращает котёл, в котором недостающие ингредиенты рецепта покрыты заменителями.
+// Заменителем расходуется только то, что не нужно рецепту самому по себе; правила рецепта
+// применяются раньше общих, в порядке rules. Ratio правила — сколько заменителя идёт
+// на единицу заменяемого ингредиента, оба в базовых единицах.
+func substitute(brewIngredients map[ingredientKey]float64, recipeID int64, recipeIngredients []Ingredient, rules []domain.Substitution) (map[ingredientKey]float64, []AppliedSubstitution) {
+	need := amounts(recipeIngredients)
+
+	pot := make(map[ingredientKey]float64, len(brewIngredients))
+	// Одно название может лежать в котле в нескольких несводимых единицах, например штуками
+	// и граммами без плотности, поэтому заменитель ищется по всем его ключам.
+	potKeys := make(map[string][]ingredientKey, len(brewIngredients))
+	for key, quantity := range brewIngredients {
+		pot[key] = quantity
+		potKeys[key.name] = append(potKeys[key.name], key)
+	}
+	for _, keys := range potKeys {
+		sort.Slice(keys, func(i, j int) bool { return keys[i].unit < keys[j].unit })
+	}
+
+	var applied []AppliedSubstitution
+	for _, ingredient := range recipeIngredients {
+	rules:
+		for _, rule := range rules {
+			if rule.Ingredient != ingredient.Name || (rule.RecipeID != nil && *rule.RecipeID != recipeID) {
+				continue
+			}
+
+			for _, substituteKey := range potKeys[rule.Substitute] {
+				shortfall := need[ingredient.key()] - pot[ingredient.key()]
+				if shortfall <= epsilon {
+					break rules
+				}
+
+				spare := pot[substituteKey] - need[substituteKey]
+				if spare <= epsilon {
+					continue
+				}
+
+				covered := min(shortfall, spare/rule.Ratio)
+				used := min(spare, covered*rule.Ratio)
+				pot[substituteKey] -= used
+				pot[ingredient.key()] += covered
+				if pot[substituteKey] <= epsilon {
+					delete(pot, substituteKey)
+				}
+
+				applied = append(applied, AppliedSubstitution{
+					Ingredient:         ingredient.Name,
+					Substitute:         rule.Substitute,
+					Quantity:           covered,
+					SubstituteQuantity: used,
+					Unit:               ingredient.Unit,
+					SubstituteUnit:     substituteKey.unit,
+				})
+			}
+		}
+	}
+
+	return pot, applied
+}
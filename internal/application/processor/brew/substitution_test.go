@@ -0,0 +1,146 @@
+package brew
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_BrewPot_Substitutions(t *testing.T) {
+	cake := domain.Recipe{
+		ID:   1,
+		Name: "Торт",
+		Ingredients: []domain.Ingredient{
+			{Name: "мука", Quantity: 100},
+			{Name: "сахар", Quantity: 50},
+		},
+	}
+	otherRecipe := int64(2)
+	cakeRecipe := int64(1)
+
+	tests := []struct {
+		name                  string
+		rules                 []domain.Substitution
+		ingredients           []Ingredient
+		expectedStarted       bool
+		expectedConsumed      []Ingredient
+		expectedLeftovers     []Ingredient
+		expectedSubstitutions []AppliedSubstitution
+	}{
+		{
+			name:                  "рецепт подходит без замен",
+			rules:                 []domain.Substitution{{Ingredient: "сахар", Substitute: "мёд", Ratio: 0.5}},
+			ingredients:           []Ingredient{{Name: "мука", Quantity: 100}, {Name: "сахар", Quantity: 50}, {Name: "мёд", Quantity: 40}},
+			expectedStarted:       true,
+			expectedConsumed:      []Ingredient{{Name: "мука", Quantity: 100, Unit: units.Piece}, {Name: "сахар", Quantity: 50, Unit: units.Piece}},
+			expectedLeftovers:     []Ingredient{{Name: "мёд", Quantity: 40, Unit: units.Piece}},
+			expectedSubstitutions: nil,
+		},
+		{
+			name:              "недостающий сахар покрывается мёдом",
+			rules:             []domain.Substitution{{Ingredient: "сахар", Substitute: "мёд", Ratio: 0.5}},
+			ingredients:       []Ingredient{{Name: "мука", Quantity: 100}, {Name: "сахар", Quantity: 20}, {Name: "мёд", Quantity: 40}},
+			expectedStarted:   true,
+			expectedConsumed:  []Ingredient{{Name: "мука", Quantity: 100, Unit: units.Piece}, {Name: "сахар", Quantity: 20, Unit: units.Piece}, {Name: "мёд", Quantity: 15, Unit: units.Piece}},
+			expectedLeftovers: []Ingredient{{Name: "мёд", Quantity: 25, Unit: units.Piece}},
+			expectedSubstitutions: []AppliedSubstitution{
+				{Ingredient: "сахар", Substitute: "мёд", Quantity: 30, SubstituteQuantity: 15, Unit: units.Piece, SubstituteUnit: units.Piece},
+			},
+		},
+		{
+			name: "правило рецепта применяется раньше общего",
+			rules: []domain.Substitution{
+				{Ingredient: "сахар", Substitute: "патока", Ratio: 1, RecipeID: &cakeRecipe},
+				{Ingredient: "сахар", Substitute: "мёд", Ratio: 1},
+			},
+			ingredients:       []Ingredient{{Name: "мука", Quantity: 100}, {Name: "патока", Quantity: 30}, {Name: "мёд", Quantity: 30}},
+			expectedStarted:   true,
+			expectedConsumed:  []Ingredient{{Name: "мука", Quantity: 100, Unit: units.Piece}, {Name: "патока", Quantity: 30, Unit: units.Piece}, {Name: "мёд", Quantity: 20, Unit: units.Piece}},
+			expectedLeftovers: []Ingredient{{Name: "мёд", Quantity: 10, Unit: units.Piece}},
+			expectedSubstitutions: []AppliedSubstitution{
+				{Ingredient: "сахар", Substitute: "патока", Quantity: 30, SubstituteQuantity: 30, Unit: units.Piece, SubstituteUnit: units.Piece},
+				{Ingredient: "сахар", Substitute: "мёд", Quantity: 20, SubstituteQuantity: 20, Unit: units.Piece, SubstituteUnit: units.Piece},
+			},
+		},
+		{
+			name:        "правило другого рецепта не применяется",
+			rules:       []domain.Substitution{{Ingredient: "сахар", Substitute: "мёд", Ratio: 1, RecipeID: &otherRecipe}},
+			ingredients: []Ingredient{{Name: "мука", Quantity: 100}, {Name: "мёд", Quantity: 50}},
+		},
+		{
+			name:        "заменителя не хватает",
+			rules:       []domain.Substitution{{Ingredient: "сахар", Substitute: "мёд", Ratio: 2}},
+			ingredients: []Ingredient{{Name: "мука", Quantity: 100}, {Name: "мёд", Quantity: 60}},
+		},
+		{
+			name:              "заменитель из ингредиентов рецепта расходуется только сверх нужного",
+			rules:             []domain.Substitution{{Ingredient: "сахар", Substitute: "мука", Ratio: 2}},
+			ingredients:       []Ingredient{{Name: "мука", Quantity: 205}},
+			expectedStarted:   true,
+			expectedConsumed:  []Ingredient{{Name: "мука", Quantity: 200, Unit: units.Piece}},
+			expectedLeftovers: []Ingredient{{Name: "мука", Quantity: 5, Unit: units.Piece}},
+			expectedSubstitutions: []AppliedSubstitution{
+				{Ingredient: "сахар", Substitute: "мука", Quantity: 50, SubstituteQuantity: 100, Unit: units.Piece, SubstituteUnit: units.Piece},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{cake}, nil)
+
+			mockSubstitutions := mock_repository.NewMockSubstitutionRepositoryInterface(ctrl)
+			mockSubstitutions.EXPECT().GetSubstitutions(gomock.Any()).Return(tt.rules, nil)
+
+			processor := NewGRPCProcessor(mockRepo, WithDefaultMatch(Match{Mode: MatchSuperset}), WithSubstitutions(mockSubstitutions))
+
+			// Act
+			result, err := processor.BrewPot(context.Background(), tt.ingredients, Match{})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStarted, result.Started)
+			if tt.expectedStarted {
+				assert.Equal(t, tt.expectedConsumed, result.Consumed)
+				assert.Equal(t, tt.expectedLeftovers, result.Leftovers)
+				assert.Equal(t, tt.expectedSubstitutions, result.Substitutions)
+			}
+		})
+	}
+}
+
+func TestSubstitute_SeveralUnits(t *testing.T) {
+	// Arrange
+	// Синонимы "мёд" и "honey" в штуках и граммах без плотности дают в котле два ключа одного названия.
+	pot := map[ingredientKey]float64{
+		{name: "мука", unit: units.Piece}: 100,
+		{name: "мёд", unit: units.Piece}:  10,
+		{name: "мёд", unit: units.Gram}:   40,
+	}
+	recipeIngredients := []Ingredient{{Name: "мука", Quantity: 100, Unit: units.Piece}, {Name: "сахар", Quantity: 50, Unit: units.Piece}}
+	rules := []domain.Substitution{{Ingredient: "сахар", Substitute: "мёд", Ratio: 1}}
+
+	// Act
+	result, applied := substitute(pot, 1, recipeIngredients, rules)
+
+	// Assert
+	assert.Equal(t, map[ingredientKey]float64{
+		{name: "мука", unit: units.Piece}:  100,
+		{name: "сахар", unit: units.Piece}: 50,
+	}, result)
+	assert.Equal(t, []AppliedSubstitution{
+		{Ingredient: "сахар", Substitute: "мёд", Quantity: 40, SubstituteQuantity: 40, Unit: units.Piece, SubstituteUnit: units.Gram},
+		{Ingredient: "сахар", Substitute: "мёд", Quantity: 10, SubstituteQuantity: 10, Unit: units.Piece, SubstituteUnit: units.Piece},
+	}, applied)
+}
@@ -0,0 +1,146 @@
+package brew
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+// SuggestQuery запрос подсказок: что можно сварить из ингредиентов на столе.
+type SuggestQuery struct {
+	Ingredients []Ingredient
+	// Match правила, по которым рецепт считается готовым к варке.
+	Match Match
+	// Limit максимальное количество подсказок, 0 без ограничения.
+	Limit int
+}
+
+// Suggestion оценка рецепта относительно имеющихся ингредиентов.
+type Suggestion struct {
+	Recipe domain.Recipe
+	// Brewable рецепт можно сварить по правилам SuggestQuery.Match.
+	Brewable bool
+	// Coverage доля требуемого рецептом количества ингредиентов, которая уже есть, от 0 до 1.
+	Coverage float64
+	// Missing сколько каждого ингредиента не хватает до рецепта.
+	Missing []Ingredient
+	// Excess сколько каждого имеющегося ингредиента сверх рецепта.
+	Excess []Ingredient
+}
+
+// SuggestRecipes оценивает все рецепты и возвращает готовые к варке и близкие к ним,
+// упорядоченные по убыванию покрытия. Рецепты без единого имеющегося ингредиента не возвращаются.
+func (p *Processor) SuggestRecipes(ctx context.Context, query SuggestQuery) ([]Suggestion, error) {
+	if err := validateIngredients(query.Ingredients); err != nil {
+		return nil, err
+	}
+
+	if query.Limit < 0 {
+		return nil, domainErrors.NewValidationError(errors.New("invalid suggest limit"), domainErrors.FieldViolation{
+			Field:       "limit",
+			Description: "limit must not be negative",
+		})
+	}
+
+	match, err := query.Match.resolve(p.defaultMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	recipesList, err := p.repo.GetRecipes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipes: %w", err)
+	}
+
+	normalizer, err := p.normalizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pot, err := normalizer.normalize(query.Ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	brewIngredients := amounts(pot)
+
+	suggestions := make([]Suggestion, 0)
+	for _, recipe := range recipesList {
+		recipeIngredients := normalizer.recipeIngredients(recipe)
+		suggestion := evaluate(brewIngredients, pot, recipe, recipeIngredients)
+		suggestion.Brewable = p.matches(match, brewIngredients, recipeIngredients)
+
+		if suggestion.Brewable || suggestion.Coverage > 0 {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	slices.SortFunc(suggestions, compareSuggestions)
+
+	if query.Limit > 0 && len(suggestions) > query.Limit {
+		suggestions = suggestions[:query.Limit]
+	}
+
+	return suggestions, nil
+}
+
+// evaluate считает покрытие рецепта, недостающие и лишние количества.
+// available содержит те же ингредиенты, что и ingredients; все количества в базовых единицах.
+func evaluate(available map[ingredientKey]float64, ingredients []Ingredient, recipe domain.Recipe, recipeIngredients []Ingredient) Suggestion {
+	suggestion := Suggestion{
+		Recipe:  recipe,
+		Missing: make([]Ingredient, 0),
+		Excess:  make([]Ingredient, 0),
+	}
+
+	// Покрытие — среднее по ингредиентам рецепта доли имеющегося количества, поэтому
+	// 500 г муки не перевешивают недостающие штуки или миллилитры. У рецепта без
+	// ингредиентов покрытие 0: котёл ему ничем не помогает.
+	required := amounts(recipeIngredients)
+	var covered float64
+	for _, ingredient := range recipeIngredients {
+		if ingredient.Quantity > 0 {
+			covered += min(available[ingredient.key()]/ingredient.Quantity, 1)
+		} else {
+			covered++
+		}
+
+		if missing := ingredient.Quantity - available[ingredient.key()]; missing > epsilon {
+			suggestion.Missing = append(suggestion.Missing, Ingredient{Name: ingredient.Name, Quantity: missing, Unit: ingredient.Unit})
+		}
+	}
+
+	if len(recipeIngredients) > 0 {
+		suggestion.Coverage = covered / float64(len(recipeIngredients))
+	}
+
+	for _, ingredient := range ingredients {
+		if excess := ingredient.Quantity - required[ingredient.key()]; excess > epsilon {
+			suggestion.Excess = append(suggestion.Excess, Ingredient{Name: ingredient.Name, Quantity: excess, Unit: ingredient.Unit})
+		}
+	}
+
+	return suggestion
+}
+
+// compareSuggestions сначала готовые к варке, затем по убыванию покрытия,
+// меньшему числу недостающих ингредиентов и идентификатору рецепта.
+func compareSuggestions(a, b Suggestion) int {
+	if a.Brewable != b.Brewable {
+		if a.Brewable {
+			return -1
+		}
+		return 1
+	}
+
+	return cmp.Or(
+		cmp.Compare(b.Coverage, a.Coverage),
+		cmp.Compare(len(a.Missing), len(b.Missing)),
+		cmp.Compare(a.Recipe.ID, b.Recipe.ID),
+	)
+}
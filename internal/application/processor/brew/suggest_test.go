@@ -0,0 +1,194 @@
+package brew
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_SuggestRecipes(t *testing.T) {
+	recipes := []domain.Recipe{
+		{ID: 1, Name: "Торт", Ingredients: []domain.Ingredient{{Name: "мука", Quantity: 100}, {Name: "сахар", Quantity: 50}}},
+		{ID: 2, Name: "Хлеб", Ingredients: []domain.Ingredient{{Name: "мука", Quantity: 200}, {Name: "дрожжи", Quantity: 10}}},
+		{ID: 3, Name: "Суп", Ingredients: []domain.Ingredient{{Name: "вода", Quantity: 500}}},
+	}
+	flatbread := domain.Recipe{ID: 4, Name: "Лепёшка", Ingredients: []domain.Ingredient{{Name: "мука", Quantity: 180}}}
+	// Граммы муки не должны перевешивать недостающие штуки.
+	pie := domain.Recipe{ID: 5, Name: "Пирог", Ingredients: []domain.Ingredient{
+		{Name: "мука", Quantity: 500, Unit: units.Gram},
+		{Name: "яйца", Quantity: 4},
+		{Name: "сахар", Quantity: 2},
+	}}
+	omelette := domain.Recipe{ID: 6, Name: "Глазунья", Ingredients: []domain.Ingredient{{Name: "яйца", Quantity: 2}}}
+
+	tests := []struct {
+		name          string
+		query         SuggestQuery
+		mockSetup     func(*mock_repository.MockRecipeRepositoryInterface)
+		expected      []Suggestion
+		expectedError string
+	}{
+		{
+			name: "готовые к варке впереди, рецепты без совпадений не возвращаются",
+			query: SuggestQuery{
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 150}, {Name: "сахар", Quantity: 50}},
+				Match:       Match{Mode: MatchSuperset},
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return(recipes, nil)
+			},
+			expected: []Suggestion{
+				{
+					Recipe:   recipes[0],
+					Brewable: true,
+					Coverage: 1,
+					Missing:  []Ingredient{},
+					Excess:   []Ingredient{{Name: "мука", Quantity: 50, Unit: units.Piece}},
+				},
+				{
+					Recipe:   recipes[1],
+					Coverage: (150.0/200.0 + 0) / 2,
+					Missing:  []Ingredient{{Name: "мука", Quantity: 50, Unit: units.Piece}, {Name: "дрожжи", Quantity: 10, Unit: units.Piece}},
+					Excess:   []Ingredient{{Name: "сахар", Quantity: 50, Unit: units.Piece}},
+				},
+			},
+		},
+		{
+			name: "готовность важнее покрытия",
+			query: SuggestQuery{
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 180}, {Name: "дрожжи", Quantity: 9}},
+				Match:       Match{Mode: MatchTolerance, TolerancePercent: percent(10)},
+				Limit:       2,
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return(append(recipes, flatbread), nil)
+			},
+			expected: []Suggestion{
+				{
+					Recipe:   recipes[1],
+					Brewable: true,
+					Coverage: (180.0/200.0 + 9.0/10.0) / 2,
+					Missing:  []Ingredient{{Name: "мука", Quantity: 20, Unit: units.Piece}, {Name: "дрожжи", Quantity: 1, Unit: units.Piece}},
+					Excess:   []Ingredient{},
+				},
+				{
+					Recipe:   flatbread,
+					Coverage: 1,
+					Missing:  []Ingredient{},
+					Excess:   []Ingredient{{Name: "дрожжи", Quantity: 9, Unit: units.Piece}},
+				},
+			},
+		},
+		{
+			name: "покрытие не зависит от единиц ингредиентов",
+			query: SuggestQuery{
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 0.5, Unit: units.Kilogram}, {Name: "яйца", Quantity: 1}},
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{pie, omelette}, nil)
+			},
+			expected: []Suggestion{
+				{
+					Recipe:   omelette,
+					Coverage: 1.0 / 2.0,
+					Missing:  []Ingredient{{Name: "яйца", Quantity: 1, Unit: units.Piece}},
+					Excess:   []Ingredient{{Name: "мука", Quantity: 500, Unit: units.Gram}},
+				},
+				{
+					Recipe:   pie,
+					Coverage: (1 + 1.0/4.0 + 0) / 3,
+					Missing:  []Ingredient{{Name: "яйца", Quantity: 3, Unit: units.Piece}, {Name: "сахар", Quantity: 2, Unit: units.Piece}},
+					Excess:   []Ingredient{},
+				},
+			},
+		},
+		{
+			name: "рецепт без ингредиентов не подсказывается",
+			query: SuggestQuery{
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 100}, {Name: "сахар", Quantity: 50}},
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{{ID: 7, Name: "Пустышка"}, recipes[0]}, nil)
+			},
+			expected: []Suggestion{
+				{
+					Recipe:   recipes[0],
+					Brewable: true,
+					Coverage: 1,
+					Missing:  []Ingredient{},
+					Excess:   []Ingredient{},
+				},
+			},
+		},
+		{
+			name: "ограничение количества подсказок",
+			query: SuggestQuery{
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 100}},
+				Match:       Match{Mode: MatchExact},
+				Limit:       1,
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return(recipes, nil)
+			},
+			expected: []Suggestion{
+				{
+					Recipe:   recipes[0],
+					Coverage: (1 + 0) / 2.0,
+					Missing:  []Ingredient{{Name: "сахар", Quantity: 50, Unit: units.Piece}},
+					Excess:   []Ingredient{},
+				},
+			},
+		},
+		{
+			name: "отрицательный лимит",
+			query: SuggestQuery{
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 100}},
+				Limit:       -1,
+			},
+			mockSetup:     func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {},
+			expectedError: "invalid suggest limit",
+		},
+		{
+			name: "ошибка при получении рецептов",
+			query: SuggestQuery{
+				Ingredients: []Ingredient{{Name: "мука", Quantity: 100}},
+			},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return(nil, errors.New("ошибка базы данных"))
+			},
+			expectedError: "failed to get recipes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			tt.mockSetup(mockRepo)
+
+			processor := NewGRPCProcessor(mockRepo)
+
+			// Act
+			suggestions, err := processor.SuggestRecipes(context.Background(), tt.query)
+
+			// Assert
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, suggestions)
+		})
+	}
+}
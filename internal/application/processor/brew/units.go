@@ -0,0 +1,137 @@
+package brew
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+)
+
+// epsilon допуск сравнения количеств после перевода единиц, в базовых единицах.
+const epsilon = 1e-9
+
+// WithUnits задаёт реестр единиц, по которому количества переводятся в базовые единицы.
+func WithUnits(registry *units.Registry) Option {
+	return func(p *Processor) {
+		p.units = registry
+	}
+}
+
+// ingredientKey ингредиент в базовой единице. Количества одного ингредиента в несводимых
+// друг к другу единицах, например в штуках и граммах, считаются разными ингредиентами.
+type ingredientKey struct {
+	name string
+	unit units.Unit
+}
+
+func (i Ingredient) key() ingredientKey {
+	return ingredientKey{name: i.Name, unit: i.Unit}
+}
+
+// normalizer приводит ингредиенты одного запроса к каноническим названиям и базовым единицам.
+type normalizer struct {
+	units *units.Registry
+	names *names.Dictionary
+}
+
+// normalizer загружает словарь синонимов для обработки запроса.
+func (p *Processor) normalizer(ctx context.Context) (*normalizer, error) {
+	dictionary, err := names.LoadDictionary(ctx, p.names, p.synonyms)
+	if err != nil {
+		return nil, err
+	}
+
+	return &normalizer{units: p.units.WithNames(dictionary.Canonical), names: dictionary}, nil
+}
+
+// normalize переводит проверенные validateIngredients ингредиенты в базовые единицы.
+// Ингредиенты, совпавшие после нормализации по каноническому названию и единице, например
+// "Крапива" и "крапива " или килограммы и граммы одного ингредиента, отклоняются как повтор.
+func (n *normalizer) normalize(ingredients []Ingredient) ([]Ingredient, error) {
+	result := make([]Ingredient, 0, len(ingredients))
+	seen := make(map[ingredientKey]struct{}, len(ingredients))
+	var violations []domainErrors.FieldViolation
+	for i, ingredient := range ingredients {
+		unit, _ := units.Parse(string(ingredient.Unit))
+		normalized := n.normalizeIngredient(n.names.Canonical(ingredient.Name), ingredient.Quantity, unit)
+		if _, ok := seen[normalized.key()]; ok {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       fmt.Sprintf("ingredients[%d].name", i),
+				Description: fmt.Sprintf("ingredient %q is listed twice", ingredient.Name),
+			})
+			continue
+		}
+		seen[normalized.key()] = struct{}{}
+
+		result = append(result, normalized)
+	}
+
+	if len(violations) > 0 {
+		return nil, domainErrors.NewValidationError(errors.New("invalid brew ingredients"), violations...)
+	}
+
+	return result, nil
+}
+
+// recipeIngredients переводит ингредиенты рецепта в базовые единицы, сводя повторы.
+func (n *normalizer) recipeIngredients(recipe domain.Recipe) []Ingredient {
+	result := make([]Ingredient, 0, len(recipe.Ingredients))
+	indexes := make(map[ingredientKey]int, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		unit, err := units.Parse(string(ingredient.Unit))
+		if err != nil {
+			unit = ingredient.Unit
+		}
+
+		result, indexes = n.add(result, indexes, ingredient.Name, ingredient.Quantity, unit)
+	}
+
+	return result
+}
+
+func (n *normalizer) add(result []Ingredient, indexes map[ingredientKey]int, name string, quantity float64, unit units.Unit) ([]Ingredient, map[ingredientKey]int) {
+	normalized := n.normalizeIngredient(n.names.Canonical(name), quantity, unit)
+	if idx, ok := indexes[normalized.key()]; ok {
+		result[idx].Quantity += normalized.Quantity
+		return result, indexes
+	}
+
+	indexes[normalized.key()] = len(result)
+
+	return append(result, normalized), indexes
+}
+
+// normalizeIngredient оставляет количество как есть, если единицу не удаётся перевести:
+// такой ингредиент совпадёт только с записанным в той же единице.
+func (n *normalizer) normalizeIngredient(name string, quantity float64, unit units.Unit) Ingredient {
+	amount, base, err := n.units.Normalize(name, quantity, unit)
+	if err != nil {
+		return Ingredient{Name: name, Quantity: quantity, Unit: unit}
+	}
+
+	return Ingredient{Name: name, Quantity: amount, Unit: base}
+}
+
+func amounts(ingredients []Ingredient) map[ingredientKey]float64 {
+	result := make(map[ingredientKey]float64, len(ingredients))
+	for _, ingredient := range ingredients {
+		result[ingredient.key()] += ingredient.Quantity
+	}
+
+	return result
+}
+
+// less сообщает, что a меньше b с учётом погрешности перевода единиц.
+func less(a, b float64) bool {
+	return a < b-epsilon*math.Max(1, math.Abs(b))
+}
+
+// batchesOf сколько целых раз quantity помещается в stock.
+func batchesOf(stock, quantity float64) int {
+	return int(math.Floor(stock/quantity + epsilon))
+}
@@ -0,0 +1,124 @@
+package brew
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_BrewPot_Units(t *testing.T) {
+	mead := domain.Recipe{
+		ID:   1,
+		Name: "Медовуха",
+		Ingredients: []domain.Ingredient{
+			{Name: "мёд", Quantity: 140, Unit: units.Gram},
+			{Name: "вода", Quantity: 0.5, Unit: units.Litre},
+			{Name: "хмель", Quantity: 2.5, Unit: units.Gram},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		ingredients       []Ingredient
+		expectedStarted   bool
+		expectedConsumed  []Ingredient
+		expectedLeftovers []Ingredient
+		expectedErrorType string
+	}{
+		{
+			name: "количества сравниваются после перевода единиц",
+			ingredients: []Ingredient{
+				{Name: "мёд", Quantity: 0.2, Unit: units.Kilogram},
+				{Name: "вода", Quantity: 500, Unit: units.Millilitre},
+				{Name: "хмель", Quantity: 2500, Unit: units.Milligram},
+			},
+			expectedStarted: true,
+			expectedConsumed: []Ingredient{
+				{Name: "мёд", Quantity: 140, Unit: units.Gram},
+				{Name: "вода", Quantity: 500, Unit: units.Millilitre},
+				{Name: "хмель", Quantity: 2.5, Unit: units.Gram},
+			},
+			expectedLeftovers: []Ingredient{{Name: "мёд", Quantity: 60, Unit: units.Gram}},
+		},
+		{
+			name: "объём переводится в массу по плотности",
+			ingredients: []Ingredient{
+				{Name: "мёд", Quantity: 100, Unit: units.Millilitre},
+				{Name: "вода", Quantity: 0.5, Unit: units.Litre},
+				{Name: "хмель", Quantity: 2.5, Unit: units.Gram},
+			},
+			expectedStarted: true,
+			expectedConsumed: []Ingredient{
+				{Name: "мёд", Quantity: 140, Unit: units.Gram},
+				{Name: "вода", Quantity: 500, Unit: units.Millilitre},
+				{Name: "хмель", Quantity: 2.5, Unit: units.Gram},
+			},
+			expectedLeftovers: []Ingredient{},
+		},
+		{
+			name: "штуки не сравниваются с граммами",
+			ingredients: []Ingredient{
+				{Name: "мёд", Quantity: 200, Unit: units.Gram},
+				{Name: "вода", Quantity: 1, Unit: units.Litre},
+				{Name: "хмель", Quantity: 3},
+			},
+		},
+		{
+			name:              "неизвестная единица",
+			ingredients:       []Ingredient{{Name: "мёд", Quantity: 1, Unit: "ложка"}},
+			expectedErrorType: domainErrors.ValidationError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			if tt.expectedErrorType == "" {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{mead}, nil)
+			}
+
+			processor := NewGRPCProcessor(mockRepo,
+				WithDefaultMatch(Match{Mode: MatchSuperset}),
+				WithUnits(units.NewRegistry(units.WithDensities(map[string]float64{"мёд": 1.4}))),
+			)
+
+			// Act
+			result, err := processor.BrewPot(context.Background(), tt.ingredients, Match{})
+
+			// Assert
+			if tt.expectedErrorType != "" {
+				var appErr *domainErrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedErrorType, appErr.Type)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStarted, result.Started)
+			if tt.expectedStarted {
+				assert.Equal(t, len(tt.expectedConsumed), len(result.Consumed))
+				for i, ingredient := range tt.expectedConsumed {
+					assert.Equal(t, ingredient.Name, result.Consumed[i].Name)
+					assert.Equal(t, ingredient.Unit, result.Consumed[i].Unit)
+					assert.InDelta(t, ingredient.Quantity, result.Consumed[i].Quantity, 1e-9)
+				}
+				assert.Equal(t, len(tt.expectedLeftovers), len(result.Leftovers))
+				for i, ingredient := range tt.expectedLeftovers {
+					assert.Equal(t, ingredient.Name, result.Leftovers[i].Name)
+					assert.InDelta(t, ingredient.Quantity, result.Leftovers[i].Quantity, 1e-9)
+				}
+			}
+		})
+	}
+}
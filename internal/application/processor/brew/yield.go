@@ -0,0 +1,95 @@
+package brew
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+// YieldQuery запрос выхода рецепта из имеющихся запасов.
+type YieldQuery struct {
+	// RecipeID рецепт для расчёта, 0 для всех рецептов каталога.
+	RecipeID    int64
+	Ingredients []Ingredient
+}
+
+// Yield сколько целых варок рецепта можно сделать из запасов.
+type Yield struct {
+	Recipe  domain.Recipe
+	Batches int
+	// LimitingIngredient ингредиент, которого хватает на меньшее число варок.
+	// Пустой для рецептов без ингредиентов, у которых Batches = 0.
+	LimitingIngredient string
+}
+
+// CalculateYield считает максимальное число варок для одного или всех рецептов.
+func (p *Processor) CalculateYield(ctx context.Context, query YieldQuery) ([]Yield, error) {
+	if err := validateIngredients(query.Ingredients); err != nil {
+		return nil, err
+	}
+
+	if query.RecipeID < 0 {
+		return nil, domainErrors.NewValidationError(errors.New("invalid yield query"), domainErrors.FieldViolation{
+			Field:       "recipe_id",
+			Description: "recipe id must not be negative",
+		})
+	}
+
+	var recipesList []domain.Recipe
+	if query.RecipeID > 0 {
+		recipe, err := p.repo.GetRecipe(ctx, query.RecipeID)
+		if err != nil {
+			return nil, err
+		}
+
+		recipesList = []domain.Recipe{*recipe}
+	} else {
+		var err error
+		recipesList, err = p.repo.GetRecipes(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recipes: %w", err)
+		}
+	}
+
+	normalizer, err := p.normalizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredients, err := normalizer.normalize(query.Ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	stock := amounts(ingredients)
+
+	yields := make([]Yield, 0, len(recipesList))
+	for _, recipe := range recipesList {
+		yields = append(yields, recipeYield(stock, recipe, normalizer.recipeIngredients(recipe)))
+	}
+
+	return yields, nil
+}
+
+// recipeYield stock и recipeIngredients в базовых единицах.
+func recipeYield(stock map[ingredientKey]float64, recipe domain.Recipe, recipeIngredients []Ingredient) Yield {
+	yield := Yield{Recipe: recipe}
+	for _, ingredient := range recipeIngredients {
+		// Рецепты из Kafka, сохранённые до появления проверки, могут содержать нулевое
+		// количество; оно ничего не ограничивает.
+		if ingredient.Quantity <= 0 {
+			continue
+		}
+
+		batches := batchesOf(stock[ingredient.key()], ingredient.Quantity)
+		if yield.LimitingIngredient == "" || batches < yield.Batches {
+			yield.Batches = batches
+			yield.LimitingIngredient = ingredient.Name
+		}
+	}
+
+	return yield
+}
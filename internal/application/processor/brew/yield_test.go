@@ -0,0 +1,89 @@
+package brew
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_CalculateYield(t *testing.T) {
+	schnapps := domain.Recipe{ID: 1, Name: "Saviour Schnapps", Ingredients: []domain.Ingredient{{Name: "nettle", Quantity: 1}, {Name: "belladonna", Quantity: 2}}}
+	tea := domain.Recipe{ID: 2, Name: "Чай", Ingredients: []domain.Ingredient{{Name: "вода", Quantity: 3}}}
+	empty := domain.Recipe{ID: 3, Name: "Пустой рецепт", Ingredients: []domain.Ingredient{}}
+
+	stock := []Ingredient{{Name: "nettle", Quantity: 10}, {Name: "belladonna", Quantity: 7}}
+
+	tests := []struct {
+		name          string
+		query         YieldQuery
+		mockSetup     func(*mock_repository.MockRecipeRepositoryInterface)
+		expected      []Yield
+		expectedError string
+	}{
+		{
+			name:  "один рецепт - ограничивает белладонна",
+			query: YieldQuery{RecipeID: 1, Ingredients: stock},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipe(gomock.Any(), int64(1)).Return(&schnapps, nil)
+			},
+			expected: []Yield{{Recipe: schnapps, Batches: 3, LimitingIngredient: "belladonna"}},
+		},
+		{
+			name:  "все рецепты",
+			query: YieldQuery{Ingredients: stock},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{schnapps, tea, empty}, nil)
+			},
+			expected: []Yield{
+				{Recipe: schnapps, Batches: 3, LimitingIngredient: "belladonna"},
+				{Recipe: tea, Batches: 0, LimitingIngredient: "вода"},
+				{Recipe: empty},
+			},
+		},
+		{
+			name:  "ошибка при получении рецептов",
+			query: YieldQuery{Ingredients: stock},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().GetRecipes(gomock.Any()).Return(nil, errors.New("ошибка базы данных"))
+			},
+			expectedError: "failed to get recipes",
+		},
+		{
+			name:          "отрицательный идентификатор рецепта",
+			query:         YieldQuery{RecipeID: -1},
+			mockSetup:     func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {},
+			expectedError: "invalid yield query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			tt.mockSetup(mockRepo)
+
+			processor := NewGRPCProcessor(mockRepo)
+
+			// Act
+			yields, err := processor.CalculateYield(context.Background(), tt.query)
+
+			// Assert
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, yields)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package recipe
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// ListQuery запрос страницы рецептов.
+type ListQuery struct {
+	Filter    domain.RecipeFilter
+	OrderBy   domain.RecipeOrder
+	PageSize  int
+	PageToken string
+}
+
+type Page struct {
+	Recipes []domain.Recipe
+	// NextPageToken пустой, если страница последняя.
+	NextPageToken string
+}
+
+// pageToken непрозрачный для клиента курсор: порядок сортировки и ключ последнего рецепта.
+type pageToken struct {
+	OrderBy domain.RecipeOrder `json:"o"`
+	ID      int64              `json:"id"`
+	Name    string             `json:"n,omitempty"`
+}
+
+func encodePageToken(orderBy domain.RecipeOrder, last domain.Recipe) string {
+	token := pageToken{
+		OrderBy: orderBy,
+		ID:      last.ID,
+	}
+	if orderBy == domain.RecipeOrderName {
+		token.Name = last.Name
+	}
+
+	data, _ := json.Marshal(token)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(raw string, orderBy domain.RecipeOrder) (*domain.RecipeCursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("invalid page token"), domainErrors.ValidationError)
+	}
+
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, domainErrors.NewAppError(errors.New("invalid page token"), domainErrors.ValidationError)
+	}
+
+	if token.OrderBy != orderBy {
+		return nil, domainErrors.NewAppError(errors.New("page token was issued for a different order"), domainErrors.ValidationError)
+	}
+
+	return &domain.RecipeCursor{
+		ID:   token.ID,
+		Name: token.Name,
+	}, nil
+}
+
+func normalizeListQuery(query ListQuery) (ListQuery, error) {
+	switch query.OrderBy {
+	case "":
+		query.OrderBy = domain.RecipeOrderID
+	case domain.RecipeOrderID, domain.RecipeOrderName:
+	default:
+		return query, domainErrors.NewAppError(errors.New(`order_by must be "id" or "name"`), domainErrors.ValidationError)
+	}
+
+	switch {
+	case query.PageSize < 0:
+		return query, domainErrors.NewAppError(errors.New("page_size must not be negative"), domainErrors.ValidationError)
+	case query.PageSize == 0:
+		query.PageSize = defaultPageSize
+	case query.PageSize > maxPageSize:
+		query.PageSize = maxPageSize
+	}
+
+	return query, nil
+}
@@ -3,35 +3,281 @@ package recipe
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
 
 	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
 	"github.com/vostelmakh/mixturka/internal/infrastructure/repository"
 )
 
 type Processor struct {
-	repo *repository.RecipeRepository
+	repo repository.RecipeRepositoryInterface
+	hub  *hub
+	// normalizer и synonyms приводят названия ингредиентов к каноническим для поиска по составу.
+	normalizer *names.Normalizer
+	synonyms   repository.SynonymRepositoryInterface
 }
 
-func NewRecipeProcessor(repo *repository.RecipeRepository) *Processor {
-	return &Processor{
-		repo: repo,
+type Option func(*Processor)
+
+// WithWatchConfig задаёт размеры буферов подписок на изменения каталога.
+func WithWatchConfig(config WatchConfig) Option {
+	return func(p *Processor) {
+		p.hub = newHub(config)
+	}
+}
+
+// WithNames задаёт нормализацию названий ингредиентов и словарь синонимов, nil — без синонимов.
+func WithNames(normalizer *names.Normalizer, synonyms repository.SynonymRepositoryInterface) Option {
+	return func(p *Processor) {
+		p.normalizer = normalizer
+		p.synonyms = synonyms
+	}
+}
+
+func NewRecipeProcessor(repo repository.RecipeRepositoryInterface, opts ...Option) *Processor {
+	p := &Processor{
+		repo:       repo,
+		hub:        newHub(WatchConfig{}),
+		normalizer: names.NewNormalizer(),
 	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
 }
 
+// ProcessRecipe сохраняет рецепт из сообщения Kafka. Рецепт, который уже есть в каталоге,
+// пропускается, поэтому повторная доставка сообщения безопасна. Рецепт, не прошедший ту же
+// проверку, что и в CreateRecipe, тоже пропускается: повторная доставка его не исправит.
 func (p *Processor) ProcessRecipe(ctx context.Context, message []byte) error {
 	var recipe domain.Recipe
 	if err := json.Unmarshal(message, &recipe); err != nil {
 		return err
 	}
 
-	return p.repo.SaveRecipe(ctx, &recipe)
+	if err := p.canonicalizeIngredients(ctx, &recipe); err != nil {
+		return err
+	}
+
+	if err := validateRecipe(&recipe); err != nil {
+		log.Printf("invalid recipe %q, message skipped: %v", recipe.Name, err)
+		return nil
+	}
+
+	if err := p.repo.SaveRecipe(ctx, &recipe); err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) && appErr.Type == domainErrors.ResourceAlreadyExists {
+			log.Printf("recipe %q already exists, message skipped", recipe.Name)
+			return nil
+		}
+
+		return err
+	}
+
+	p.hub.publish(EventCreated, recipe)
+
+	return nil
 }
 
-func (p *Processor) GetRecipes(ctx context.Context) ([]domain.Recipe, error) {
-	recipes, err := p.repo.GetRecipes(ctx)
+// GetRecipes возвращает страницу рецептов в стабильном порядке.
+func (p *Processor) GetRecipes(ctx context.Context, query ListQuery) (*Page, error) {
+	query, err := normalizeListQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodePageToken(query.PageToken, query.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := p.canonicalFilter(ctx, query.Filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return recipes, nil
+	// Запрашиваем на один рецепт больше, чтобы понять, есть ли следующая страница.
+	recipes, err := p.repo.FindRecipes(ctx, domain.RecipeQuery{
+		Filter:  filter,
+		OrderBy: query.OrderBy,
+		After:   after,
+		Limit:   query.PageSize + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Recipes: recipes}
+	if len(recipes) > query.PageSize {
+		page.Recipes = recipes[:query.PageSize]
+		page.NextPageToken = encodePageToken(query.OrderBy, page.Recipes[query.PageSize-1])
+	}
+
+	return page, nil
+}
+
+func (p *Processor) GetRecipe(ctx context.Context, id int64) (*domain.Recipe, error) {
+	return p.repo.GetRecipe(ctx, id)
+}
+
+func (p *Processor) CreateRecipe(ctx context.Context, recipe *domain.Recipe) error {
+	if err := p.canonicalizeIngredients(ctx, recipe); err != nil {
+		return err
+	}
+
+	if err := validateRecipe(recipe); err != nil {
+		return err
+	}
+
+	if err := p.repo.SaveRecipe(ctx, recipe); err != nil {
+		return err
+	}
+
+	p.hub.publish(EventCreated, *recipe)
+
+	return nil
+}
+
+func (p *Processor) UpdateRecipe(ctx context.Context, recipe *domain.Recipe) error {
+	if err := p.canonicalizeIngredients(ctx, recipe); err != nil {
+		return err
+	}
+
+	if err := validateRecipe(recipe); err != nil {
+		return err
+	}
+
+	if err := p.repo.UpdateRecipe(ctx, recipe); err != nil {
+		return err
+	}
+
+	p.hub.publish(EventUpdated, *recipe)
+
+	return nil
+}
+
+func (p *Processor) DeleteRecipe(ctx context.Context, id int64) error {
+	if err := p.repo.DeleteRecipe(ctx, id); err != nil {
+		return err
+	}
+
+	p.hub.publish(EventDeleted, domain.Recipe{ID: id})
+
+	return nil
+}
+
+// canonicalizeIngredients записывает ингредиентам канонические названия, чтобы "Крапива",
+// "крапива " и её синонимы находились фильтром одинаково. Название для показа не меняется.
+func (p *Processor) canonicalizeIngredients(ctx context.Context, recipe *domain.Recipe) error {
+	dictionary, err := names.LoadDictionary(ctx, p.normalizer, p.synonyms)
+	if err != nil {
+		return err
+	}
+
+	for i := range recipe.Ingredients {
+		recipe.Ingredients[i].CanonicalName = dictionary.Canonical(recipe.Ingredients[i].Name)
+	}
+
+	return nil
+}
+
+// RefreshCanonicalNames пересчитывает канонические названия ингредиентов всего каталога по текущему
+// словарю синонимов. Вызывается при запуске и после изменения словаря.
+func (p *Processor) RefreshCanonicalNames(ctx context.Context) error {
+	dictionary, err := names.LoadDictionary(ctx, p.normalizer, p.synonyms)
+	if err != nil {
+		return err
+	}
+
+	return p.repo.UpdateCanonicalNames(ctx, dictionary.Canonical)
+}
+
+// canonicalFilter приводит названия фильтра по составу к каноническим так же, как при сохранении рецепта.
+func (p *Processor) canonicalFilter(ctx context.Context, filter domain.RecipeFilter) (domain.RecipeFilter, error) {
+	if filter.IsEmpty() {
+		return filter, nil
+	}
+
+	dictionary, err := names.LoadDictionary(ctx, p.normalizer, p.synonyms)
+	if err != nil {
+		return filter, err
+	}
+
+	canonical := func(list []string) []string {
+		if list == nil {
+			return nil
+		}
+
+		result := make([]string, 0, len(list))
+		for _, name := range list {
+			result = append(result, dictionary.Canonical(name))
+		}
+
+		return result
+	}
+
+	return domain.RecipeFilter{
+		AllOf:  canonical(filter.AllOf),
+		AnyOf:  canonical(filter.AnyOf),
+		NoneOf: canonical(filter.NoneOf),
+	}, nil
+}
+
+func validateRecipe(recipe *domain.Recipe) error {
+	recipe.Name = strings.TrimSpace(recipe.Name)
+	if recipe.Name == "" {
+		return domainErrors.NewAppError(errors.New("recipe name is required"), domainErrors.ValidationError)
+	}
+
+	if recipe.BrewDuration < 0 {
+		return domainErrors.NewAppError(errors.New("brew duration must not be negative"), domainErrors.ValidationError)
+	}
+
+	// Рецепт без ингредиентов подходил бы к любому котлу.
+	if len(recipe.Ingredients) == 0 {
+		return domainErrors.NewAppError(errors.New("recipe must have at least one ingredient"), domainErrors.ValidationError)
+	}
+
+	seen := make(map[string]struct{}, len(recipe.Ingredients))
+	for i := range recipe.Ingredients {
+		ingredient := &recipe.Ingredients[i]
+		ingredient.Name = strings.TrimSpace(ingredient.Name)
+
+		if ingredient.Name == "" {
+			return domainErrors.NewAppError(fmt.Errorf("ingredient #%d: name is required", i+1), domainErrors.ValidationError)
+		}
+
+		if !(ingredient.Quantity > 0) {
+			return domainErrors.NewAppError(fmt.Errorf("ingredient %q: quantity must be positive", ingredient.Name), domainErrors.ValidationError)
+		}
+
+		if _, ok := seen[ingredient.CanonicalName]; ok {
+			return domainErrors.NewAppError(fmt.Errorf("ingredient %q is listed twice", ingredient.Name), domainErrors.ValidationError)
+		}
+		seen[ingredient.CanonicalName] = struct{}{}
+	}
+
+	return normalizeUnits(recipe)
+}
+
+// normalizeUnits приводит единицы ингредиентов к каноническому виду, пустая единица означает штуки.
+func normalizeUnits(recipe *domain.Recipe) error {
+	for i := range recipe.Ingredients {
+		unit, err := units.Parse(string(recipe.Ingredients[i].Unit))
+		if err != nil {
+			return domainErrors.NewAppError(fmt.Errorf("ingredient %q: %w", recipe.Ingredients[i].Name, err), domainErrors.ValidationError)
+		}
+
+		recipe.Ingredients[i].Unit = unit
+	}
+
+	return nil
 }
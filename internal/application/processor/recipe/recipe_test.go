@@ -0,0 +1,290 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_GetRecipes(t *testing.T) {
+	recipes := []domain.Recipe{
+		{ID: 1, Name: "Борщ"},
+		{ID: 2, Name: "Ахмед"},
+		{ID: 3, Name: "Вино"},
+	}
+
+	tests := []struct {
+		name              string
+		query             ListQuery
+		mockSetup         func(*mock_repository.MockRecipeRepositoryInterface)
+		expectedIDs       []int64
+		expectedNextToken bool
+		expectedError     string
+	}{
+		{
+			name:  "первая страница с токеном следующей",
+			query: ListQuery{PageSize: 2},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().
+					FindRecipes(gomock.Any(), domain.RecipeQuery{OrderBy: domain.RecipeOrderID, Limit: 3}).
+					Return(recipes, nil)
+			},
+			expectedIDs:       []int64{1, 2},
+			expectedNextToken: true,
+		},
+		{
+			name:  "последняя страница без токена",
+			query: ListQuery{PageSize: 3},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().
+					FindRecipes(gomock.Any(), domain.RecipeQuery{OrderBy: domain.RecipeOrderID, Limit: 4}).
+					Return(recipes, nil)
+			},
+			expectedIDs: []int64{1, 2, 3},
+		},
+		{
+			name:  "токен продолжает выборку по имени",
+			query: ListQuery{OrderBy: domain.RecipeOrderName, PageSize: 1, PageToken: encodePageToken(domain.RecipeOrderName, recipes[1])},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().
+					FindRecipes(gomock.Any(), domain.RecipeQuery{
+						OrderBy: domain.RecipeOrderName,
+						After:   &domain.RecipeCursor{ID: 2, Name: "Ахмед"},
+						Limit:   2,
+					}).
+					Return(recipes[:1], nil)
+			},
+			expectedIDs: []int64{1},
+		},
+		{
+			name:  "названия фильтра приводятся к каноническим",
+			query: ListQuery{PageSize: 3, Filter: domain.RecipeFilter{AllOf: []string{" Свекла "}, NoneOf: []string{"КАПУСТА"}}},
+			mockSetup: func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {
+				mockRepo.EXPECT().
+					FindRecipes(gomock.Any(), domain.RecipeQuery{
+						Filter:  domain.RecipeFilter{AllOf: []string{"свекла"}, NoneOf: []string{"капуста"}},
+						OrderBy: domain.RecipeOrderID,
+						Limit:   4,
+					}).
+					Return(recipes[:1], nil)
+			},
+			expectedIDs: []int64{1},
+		},
+		{
+			name:          "токен другой сортировки",
+			query:         ListQuery{OrderBy: domain.RecipeOrderName, PageToken: encodePageToken(domain.RecipeOrderID, recipes[0])},
+			mockSetup:     func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {},
+			expectedError: "page token was issued for a different order",
+		},
+		{
+			name:          "повреждённый токен",
+			query:         ListQuery{PageToken: "not a token"},
+			mockSetup:     func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {},
+			expectedError: "invalid page token",
+		},
+		{
+			name:          "неизвестная сортировка",
+			query:         ListQuery{OrderBy: "quantity"},
+			mockSetup:     func(mockRepo *mock_repository.MockRecipeRepositoryInterface) {},
+			expectedError: "order_by must be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			tt.mockSetup(mockRepo)
+
+			processor := NewRecipeProcessor(mockRepo)
+
+			// Act
+			page, err := processor.GetRecipes(context.Background(), tt.query)
+
+			// Assert
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			ids := make([]int64, 0, len(page.Recipes))
+			for _, recipe := range page.Recipes {
+				ids = append(ids, recipe.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedNextToken, page.NextPageToken != "")
+		})
+	}
+}
+
+func TestProcessor_ProcessRecipe(t *testing.T) {
+	const potion = `{"name":"Зелье","ingredients":[{"name":"вода","quantity":1}]}`
+
+	tests := []struct {
+		name          string
+		message       string
+		skipSave      bool
+		saveErr       error
+		expectedError bool
+		expectedEvent bool
+	}{
+		{
+			name:          "новый рецепт сохраняется",
+			message:       potion,
+			expectedEvent: true,
+		},
+		{
+			name:    "повторная доставка пропускается",
+			message: potion,
+			saveErr: domainErrors.NewAppErrorWithType(domainErrors.ResourceAlreadyExists),
+		},
+		{
+			name:          "ошибка базы данных",
+			message:       potion,
+			saveErr:       errors.New("connection refused"),
+			expectedError: true,
+		},
+		{
+			name:     "рецепт без ингредиентов пропускается",
+			message:  `{"name":"Зелье","ingredients":[]}`,
+			skipSave: true,
+		},
+		{
+			name:     "нулевое количество пропускается",
+			message:  `{"name":"Зелье","ingredients":[{"name":"вода","quantity":0}]}`,
+			skipSave: true,
+		},
+		{
+			name:     "неизвестная единица пропускается",
+			message:  `{"name":"Зелье","ingredients":[{"name":"вода","quantity":1,"unit":"bucket"}]}`,
+			skipSave: true,
+		},
+		{
+			name:     "повтор ингредиента пропускается",
+			message:  `{"name":"Зелье","ingredients":[{"name":"Вода","quantity":1},{"name":"вода ","quantity":2}]}`,
+			skipSave: true,
+		},
+		{
+			name:     "рецепт без названия пропускается",
+			message:  `{"name":" ","ingredients":[{"name":"вода","quantity":1}]}`,
+			skipSave: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+			if !tt.skipSave {
+				mockRepo.EXPECT().SaveRecipe(gomock.Any(), gomock.Any()).Return(tt.saveErr)
+			}
+
+			processor := NewRecipeProcessor(mockRepo)
+
+			// Act
+			err := processor.ProcessRecipe(context.Background(), []byte(tt.message))
+
+			// Assert
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedEvent, len(processor.hub.history) == 1)
+		})
+	}
+}
+
+func TestProcessor_CreateRecipe_Names(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var saved domain.Recipe
+	mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+	mockRepo.EXPECT().SaveRecipe(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, recipe *domain.Recipe) error {
+			saved = *recipe
+			return nil
+		})
+
+	mockSynonyms := mock_repository.NewMockSynonymRepositoryInterface(ctrl)
+	mockSynonyms.EXPECT().GetSynonyms(gomock.Any()).Return([]domain.Synonym{{Name: "крапива", Canonical: "nettle"}}, nil)
+
+	processor := NewRecipeProcessor(mockRepo, WithNames(names.NewNormalizer(names.WithTransliteration(true)), mockSynonyms))
+	recipe := &domain.Recipe{Name: "Зелье", Ingredients: []domain.Ingredient{
+		{Name: " Крапива", Quantity: 2},
+		{Name: "Вода", Quantity: 1},
+	}}
+
+	// Act
+	err := processor.CreateRecipe(context.Background(), recipe)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Ingredient{
+		{Name: "Крапива", Quantity: 2, Unit: units.Piece, CanonicalName: "nettle"},
+		{Name: "Вода", Quantity: 1, Unit: units.Piece, CanonicalName: "voda"},
+	}, saved.Ingredients)
+}
+
+func TestProcessor_RefreshCanonicalNames(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var canonical func(name string) string
+	mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+	mockRepo.EXPECT().UpdateCanonicalNames(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(name string) string) error {
+			canonical = fn
+			return nil
+		})
+
+	mockSynonyms := mock_repository.NewMockSynonymRepositoryInterface(ctrl)
+	mockSynonyms.EXPECT().GetSynonyms(gomock.Any()).Return([]domain.Synonym{{Name: "крапива", Canonical: "nettle"}}, nil)
+
+	processor := NewRecipeProcessor(mockRepo, WithNames(names.NewNormalizer(names.WithTransliteration(true)), mockSynonyms))
+
+	// Act
+	err := processor.RefreshCanonicalNames(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "nettle", canonical(" Крапива"))
+	assert.Equal(t, "voda", canonical("Вода"))
+}
+
+func TestProcessor_CreateRecipe_NoIngredients(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processor := NewRecipeProcessor(mock_repository.NewMockRecipeRepositoryInterface(ctrl))
+
+	// Act
+	err := processor.CreateRecipe(context.Background(), &domain.Recipe{Name: "Пустышка", Ingredients: []domain.Ingredient{}})
+
+	// Assert
+	var appErr *domainErrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, domainErrors.ValidationError, appErr.Type)
+}
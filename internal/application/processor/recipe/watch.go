@@ -0,0 +1,181 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+)
+
+// ErrSlowConsumer подписчик не успевает вычитывать события и был отключён.
+var ErrSlowConsumer = errors.New("recipe watcher is too slow to keep up with catalog updates")
+
+type EventType string
+
+const (
+	EventSnapshot    EventType = "snapshot"
+	EventSnapshotEnd EventType = "snapshot_end"
+	EventCreated     EventType = "created"
+	EventUpdated     EventType = "updated"
+	EventDeleted     EventType = "deleted"
+)
+
+// Event изменение каталога рецептов. Для удалённых рецептов заполнен только Recipe.ID.
+type Event struct {
+	Type     EventType
+	Revision int64
+	Recipe   domain.Recipe
+}
+
+type WatchConfig struct {
+	// BufferSize количество событий, которое копится для подписчика до его отключения.
+	BufferSize int
+	// HistorySize количество последних событий, хранимых для возобновления подписки.
+	HistorySize int
+}
+
+// hub рассылает изменения каталога подписчикам и хранит историю для возобновления.
+// Ревизии начинаются со времени запуска, поэтому ревизия из прошлого запуска сервиса
+// всегда старше истории и приводит к повторной отправке каталога.
+type hub struct {
+	mu          sync.Mutex
+	revision    int64
+	history     []Event
+	subscribers map[*subscription]struct{}
+	config      WatchConfig
+}
+
+type subscription struct {
+	events chan Event
+	done   chan struct{}
+	err    error
+}
+
+func newHub(config WatchConfig) *hub {
+	if config.BufferSize <= 0 {
+		config.BufferSize = 64
+	}
+	if config.HistorySize <= 0 {
+		config.HistorySize = 1024
+	}
+
+	return &hub{
+		revision:    time.Now().UnixMicro(),
+		subscribers: make(map[*subscription]struct{}),
+		config:      config,
+	}
+}
+
+func (h *hub) publish(eventType EventType, recipe domain.Recipe) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.revision++
+	event := Event{
+		Type:     eventType,
+		Revision: h.revision,
+		Recipe:   recipe,
+	}
+
+	h.history = append(h.history, event)
+	if len(h.history) > h.config.HistorySize {
+		h.history = h.history[len(h.history)-h.config.HistorySize:]
+	}
+
+	for sub := range h.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			h.drop(sub, ErrSlowConsumer)
+		}
+	}
+}
+
+// subscribe регистрирует подписчика и возвращает текущую ревизию. Если fromRevision
+// покрывается историей, возвращает пропущенные события, иначе resumed = false.
+func (h *hub) subscribe(fromRevision int64) (sub *subscription, revision int64, backlog []Event, resumed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub = &subscription{
+		events: make(chan Event, h.config.BufferSize),
+		done:   make(chan struct{}),
+	}
+	h.subscribers[sub] = struct{}{}
+
+	if fromRevision > 0 && fromRevision <= h.revision && fromRevision >= h.revision-int64(len(h.history)) {
+		missed := h.history[len(h.history)-int(h.revision-fromRevision):]
+		backlog = append(backlog, missed...)
+		resumed = true
+	}
+
+	return sub, h.revision, backlog, resumed
+}
+
+func (h *hub) unsubscribe(sub *subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.drop(sub, nil)
+}
+
+func (h *hub) drop(sub *subscription, err error) {
+	if _, ok := h.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(h.subscribers, sub)
+	sub.err = err
+	close(sub.done)
+}
+
+// Watch отправляет в send текущий каталог (или события после fromRevision, если их
+// ещё можно восстановить), а затем изменения каталога, пока не отменён ctx.
+func (p *Processor) Watch(ctx context.Context, fromRevision int64, send func(Event) error) error {
+	sub, revision, backlog, resumed := p.hub.subscribe(fromRevision)
+	defer p.hub.unsubscribe(sub)
+
+	if resumed {
+		for _, event := range backlog {
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	} else {
+		// Подписка оформлена до чтения каталога, поэтому изменения, пришедшие во время
+		// отправки снимка, не теряются, а в худшем случае приходят повторно.
+		recipes, err := p.repo.GetRecipes(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, recipe := range recipes {
+			if err := send(Event{Type: EventSnapshot, Revision: revision, Recipe: recipe}); err != nil {
+				return err
+			}
+		}
+
+		if err := send(Event{Type: EventSnapshotEnd, Revision: revision}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case event := <-sub.events:
+			if event.Revision <= revision {
+				continue
+			}
+
+			if err := send(event); err != nil {
+				return err
+			}
+		case <-sub.done:
+			return sub.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
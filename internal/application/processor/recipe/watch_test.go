@@ -0,0 +1,123 @@
+package recipe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_Watch(t *testing.T) {
+	t.Run("снимок каталога, затем изменения", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetRecipes(gomock.Any()).Return([]domain.Recipe{{ID: 1, Name: "Борщ"}}, nil)
+		mockRepo.EXPECT().DeleteRecipe(gomock.Any(), int64(1)).Return(nil)
+
+		processor := NewRecipeProcessor(mockRepo)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := make(chan Event, 10)
+		done := make(chan error, 1)
+
+		// Act
+		go func() {
+			done <- processor.Watch(ctx, 0, func(event Event) error {
+				events <- event
+				return nil
+			})
+		}()
+
+		snapshot := <-events
+		snapshotEnd := <-events
+		assert.NoError(t, processor.DeleteRecipe(ctx, 1))
+		deleted := <-events
+		cancel()
+
+		// Assert
+		assert.Equal(t, EventSnapshot, snapshot.Type)
+		assert.Equal(t, "Борщ", snapshot.Recipe.Name)
+		assert.Equal(t, EventSnapshotEnd, snapshotEnd.Type)
+		assert.Equal(t, EventDeleted, deleted.Type)
+		assert.Equal(t, int64(1), deleted.Recipe.ID)
+		assert.Greater(t, deleted.Revision, snapshotEnd.Revision)
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+
+	t.Run("возобновление с известной ревизии без снимка", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+		processor := NewRecipeProcessor(mockRepo)
+
+		processor.hub.publish(EventCreated, domain.Recipe{ID: 1})
+		resumeFrom := processor.hub.revision
+		processor.hub.publish(EventCreated, domain.Recipe{ID: 2})
+		processor.hub.publish(EventUpdated, domain.Recipe{ID: 2})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		var received []Event
+
+		// Act
+		err := processor.Watch(ctx, resumeFrom, func(event Event) error {
+			received = append(received, event)
+			return nil
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		if assert.Len(t, received, 2) {
+			assert.Equal(t, EventCreated, received[0].Type)
+			assert.Equal(t, EventUpdated, received[1].Type)
+			assert.Equal(t, resumeFrom+2, received[1].Revision)
+		}
+	})
+
+	t.Run("медленный подписчик отключается", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock_repository.NewMockRecipeRepositoryInterface(ctrl)
+		processor := NewRecipeProcessor(mockRepo, WithWatchConfig(WatchConfig{BufferSize: 1}))
+
+		ctx := context.Background()
+		blocked := make(chan struct{})
+		done := make(chan error, 1)
+
+		// Act
+		go func() {
+			done <- processor.Watch(ctx, processor.hub.revision, func(event Event) error {
+				<-blocked
+				return nil
+			})
+		}()
+
+		assert.Eventually(t, func() bool {
+			processor.hub.mu.Lock()
+			defer processor.hub.mu.Unlock()
+			return len(processor.hub.subscribers) == 1
+		}, time.Second, time.Millisecond)
+
+		for i := int64(1); i <= 3; i++ {
+			processor.hub.publish(EventCreated, domain.Recipe{ID: i})
+		}
+		close(blocked)
+
+		// Assert
+		assert.ErrorIs(t, <-done, ErrSlowConsumer)
+	})
+}
@@ -0,0 +1,95 @@
+package substitution
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/repository"
+)
+
+// Processor управляет правилами замены ингредиентов.
+type Processor struct {
+	repo repository.SubstitutionRepositoryInterface
+}
+
+func NewSubstitutionProcessor(repo repository.SubstitutionRepositoryInterface) *Processor {
+	return &Processor{
+		repo: repo,
+	}
+}
+
+func (p *Processor) GetSubstitutions(ctx context.Context) ([]domain.Substitution, error) {
+	return p.repo.GetSubstitutions(ctx)
+}
+
+func (p *Processor) GetSubstitution(ctx context.Context, id int64) (*domain.Substitution, error) {
+	return p.repo.GetSubstitution(ctx, id)
+}
+
+func (p *Processor) CreateSubstitution(ctx context.Context, substitution *domain.Substitution) error {
+	if err := validateSubstitution(substitution); err != nil {
+		return err
+	}
+
+	return p.repo.SaveSubstitution(ctx, substitution)
+}
+
+func (p *Processor) UpdateSubstitution(ctx context.Context, substitution *domain.Substitution) error {
+	if err := validateSubstitution(substitution); err != nil {
+		return err
+	}
+
+	return p.repo.UpdateSubstitution(ctx, substitution)
+}
+
+func (p *Processor) DeleteSubstitution(ctx context.Context, id int64) error {
+	return p.repo.DeleteSubstitution(ctx, id)
+}
+
+func validateSubstitution(substitution *domain.Substitution) error {
+	substitution.Ingredient = strings.TrimSpace(substitution.Ingredient)
+	substitution.Substitute = strings.TrimSpace(substitution.Substitute)
+
+	var violations []domainErrors.FieldViolation
+	if substitution.Ingredient == "" {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "ingredient",
+			Description: "ingredient must not be empty",
+		})
+	}
+
+	if substitution.Substitute == "" {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "substitute",
+			Description: "substitute must not be empty",
+		})
+	} else if substitution.Substitute == substitution.Ingredient {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "substitute",
+			Description: "ingredient cannot substitute itself",
+		})
+	}
+
+	if !(substitution.Ratio > 0) {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "ratio",
+			Description: "ratio must be positive",
+		})
+	}
+
+	if substitution.RecipeID != nil && *substitution.RecipeID < 1 {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "recipe_id",
+			Description: "recipe id must be positive",
+		})
+	}
+
+	if len(violations) > 0 {
+		return domainErrors.NewValidationError(errors.New("invalid substitution"), violations...)
+	}
+
+	return nil
+}
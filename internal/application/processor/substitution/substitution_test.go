@@ -0,0 +1,85 @@
+package substitution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_CreateSubstitution(t *testing.T) {
+	recipeID := int64(1)
+	invalidRecipeID := int64(0)
+
+	tests := []struct {
+		name               string
+		substitution       domain.Substitution
+		expectedSaved      *domain.Substitution
+		expectedViolations []string
+	}{
+		{
+			name:          "общее правило, названия обрезаются",
+			substitution:  domain.Substitution{Ingredient: " сахар ", Substitute: "мёд ", Ratio: 0.5},
+			expectedSaved: &domain.Substitution{Ingredient: "сахар", Substitute: "мёд", Ratio: 0.5},
+		},
+		{
+			name:          "правило рецепта",
+			substitution:  domain.Substitution{Ingredient: "сахар", Substitute: "мёд", Ratio: 1, RecipeID: &recipeID},
+			expectedSaved: &domain.Substitution{Ingredient: "сахар", Substitute: "мёд", Ratio: 1, RecipeID: &recipeID},
+		},
+		{
+			name:               "пустые названия и нулевая пропорция",
+			substitution:       domain.Substitution{Ingredient: " ", Ratio: 0},
+			expectedViolations: []string{"ingredient", "substitute", "ratio"},
+		},
+		{
+			name:               "ингредиент заменяет сам себя",
+			substitution:       domain.Substitution{Ingredient: "сахар", Substitute: "сахар", Ratio: 1},
+			expectedViolations: []string{"substitute"},
+		},
+		{
+			name:               "некорректный рецепт",
+			substitution:       domain.Substitution{Ingredient: "сахар", Substitute: "мёд", Ratio: 1, RecipeID: &invalidRecipeID},
+			expectedViolations: []string{"recipe_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockSubstitutionRepositoryInterface(ctrl)
+			if tt.expectedSaved != nil {
+				mockRepo.EXPECT().SaveSubstitution(gomock.Any(), tt.expectedSaved).Return(nil)
+			}
+
+			processor := NewSubstitutionProcessor(mockRepo)
+
+			// Act
+			err := processor.CreateSubstitution(context.Background(), &tt.substitution)
+
+			// Assert
+			if tt.expectedViolations == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var appErr *domainErrors.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, domainErrors.ValidationError, appErr.Type)
+
+			fields := make([]string, 0, len(appErr.Violations))
+			for _, violation := range appErr.Violations {
+				fields = append(fields, violation.Field)
+			}
+			assert.Equal(t, tt.expectedViolations, fields)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package synonym
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/repository"
+)
+
+// Processor управляет словарём синонимов ингредиентов. Названия хранятся нормализованными.
+type Processor struct {
+	repo       repository.SynonymRepositoryInterface
+	normalizer *names.Normalizer
+	onChange   func(ctx context.Context) error
+}
+
+type Option func(*Processor)
+
+// WithOnChange задаёт действие после изменения словаря, например пересчёт канонических названий
+// ингредиентов в каталоге рецептов.
+func WithOnChange(onChange func(ctx context.Context) error) Option {
+	return func(p *Processor) {
+		p.onChange = onChange
+	}
+}
+
+func NewSynonymProcessor(repo repository.SynonymRepositoryInterface, normalizer *names.Normalizer, opts ...Option) *Processor {
+	p := &Processor{
+		repo:       repo,
+		normalizer: normalizer,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *Processor) GetSynonyms(ctx context.Context) ([]domain.Synonym, error) {
+	return p.repo.GetSynonyms(ctx)
+}
+
+func (p *Processor) GetSynonym(ctx context.Context, id int64) (*domain.Synonym, error) {
+	return p.repo.GetSynonym(ctx, id)
+}
+
+func (p *Processor) CreateSynonym(ctx context.Context, synonym *domain.Synonym) error {
+	if err := p.validateSynonym(synonym); err != nil {
+		return err
+	}
+
+	if err := p.repo.SaveSynonym(ctx, synonym); err != nil {
+		return err
+	}
+
+	p.changed(ctx)
+
+	return nil
+}
+
+func (p *Processor) UpdateSynonym(ctx context.Context, synonym *domain.Synonym) error {
+	if err := p.validateSynonym(synonym); err != nil {
+		return err
+	}
+
+	if err := p.repo.UpdateSynonym(ctx, synonym); err != nil {
+		return err
+	}
+
+	p.changed(ctx)
+
+	return nil
+}
+
+func (p *Processor) DeleteSynonym(ctx context.Context, id int64) error {
+	if err := p.repo.DeleteSynonym(ctx, id); err != nil {
+		return err
+	}
+
+	p.changed(ctx)
+
+	return nil
+}
+
+// changed вызывает onChange после записи в словарь. Изменение словаря уже сохранено, поэтому
+// ошибка только логируется: устаревшие названия пересчитаются при следующем изменении или запуске.
+func (p *Processor) changed(ctx context.Context) {
+	if p.onChange == nil {
+		return
+	}
+
+	if err := p.onChange(ctx); err != nil {
+		log.Printf("failed to apply synonym change: %v", err)
+	}
+}
+
+func (p *Processor) validateSynonym(synonym *domain.Synonym) error {
+	synonym.Name = p.normalizer.Normalize(synonym.Name)
+	synonym.Canonical = p.normalizer.Normalize(synonym.Canonical)
+
+	var violations []domainErrors.FieldViolation
+	if synonym.Name == "" {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "name",
+			Description: "name must not be empty",
+		})
+	}
+
+	if synonym.Canonical == "" {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "canonical",
+			Description: "canonical name must not be empty",
+		})
+	} else if synonym.Canonical == synonym.Name {
+		violations = append(violations, domainErrors.FieldViolation{
+			Field:       "canonical",
+			Description: "name and canonical name are the same after normalization",
+		})
+	}
+
+	if len(violations) > 0 {
+		return domainErrors.NewValidationError(errors.New("invalid synonym"), violations...)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package synonym
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+	mock_repository "github.com/vostelmakh/mixturka/internal/infrastructure/repository/mocks"
+)
+
+func TestProcessor_CreateSynonym(t *testing.T) {
+	tests := []struct {
+		name               string
+		synonym            domain.Synonym
+		expectedSaved      *domain.Synonym
+		expectedViolations []string
+	}{
+		{
+			name:          "названия нормализуются",
+			synonym:       domain.Synonym{Name: " Крапива ", Canonical: "Nettle"},
+			expectedSaved: &domain.Synonym{Name: "крапива", Canonical: "nettle"},
+		},
+		{
+			name:               "пустые названия",
+			synonym:            domain.Synonym{Name: " ", Canonical: ""},
+			expectedViolations: []string{"name", "canonical"},
+		},
+		{
+			name:               "синоним совпадает с каноническим названием после нормализации",
+			synonym:            domain.Synonym{Name: "NETTLE", Canonical: "nettle "},
+			expectedViolations: []string{"canonical"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockSynonymRepositoryInterface(ctrl)
+			if tt.expectedSaved != nil {
+				mockRepo.EXPECT().SaveSynonym(gomock.Any(), tt.expectedSaved).Return(nil)
+			}
+
+			processor := NewSynonymProcessor(mockRepo, names.NewNormalizer())
+
+			// Act
+			err := processor.CreateSynonym(context.Background(), &tt.synonym)
+
+			// Assert
+			if tt.expectedViolations == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var appErr *domainErrors.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, domainErrors.ValidationError, appErr.Type)
+
+			fields := make([]string, 0, len(appErr.Violations))
+			for _, violation := range appErr.Violations {
+				fields = append(fields, violation.Field)
+			}
+			assert.Equal(t, tt.expectedViolations, fields)
+		})
+	}
+}
+
+func TestProcessor_OnChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		act      func(processor *Processor) error
+		arrange  func(mockRepo *mock_repository.MockSynonymRepositoryInterface)
+		expected int
+	}{
+		{
+			name: "после создания",
+			arrange: func(mockRepo *mock_repository.MockSynonymRepositoryInterface) {
+				mockRepo.EXPECT().SaveSynonym(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			act: func(processor *Processor) error {
+				return processor.CreateSynonym(context.Background(), &domain.Synonym{Name: "крапива", Canonical: "nettle"})
+			},
+			expected: 1,
+		},
+		{
+			name: "после изменения",
+			arrange: func(mockRepo *mock_repository.MockSynonymRepositoryInterface) {
+				mockRepo.EXPECT().UpdateSynonym(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			act: func(processor *Processor) error {
+				return processor.UpdateSynonym(context.Background(), &domain.Synonym{ID: 1, Name: "крапива", Canonical: "nettle"})
+			},
+			expected: 1,
+		},
+		{
+			name: "после удаления",
+			arrange: func(mockRepo *mock_repository.MockSynonymRepositoryInterface) {
+				mockRepo.EXPECT().DeleteSynonym(gomock.Any(), int64(1)).Return(nil)
+			},
+			act: func(processor *Processor) error {
+				return processor.DeleteSynonym(context.Background(), 1)
+			},
+			expected: 1,
+		},
+		{
+			name: "словарь не изменился",
+			arrange: func(mockRepo *mock_repository.MockSynonymRepositoryInterface) {
+				mockRepo.EXPECT().DeleteSynonym(gomock.Any(), int64(1)).Return(domainErrors.NewAppErrorWithType(domainErrors.NotFound))
+			},
+			act: func(processor *Processor) error {
+				return processor.DeleteSynonym(context.Background(), 1)
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockSynonymRepositoryInterface(ctrl)
+			tt.arrange(mockRepo)
+
+			calls := 0
+			processor := NewSynonymProcessor(mockRepo, names.NewNormalizer(), WithOnChange(func(context.Context) error {
+				calls++
+				return errors.New("refresh failed")
+			}))
+
+			// Act
+			err := tt.act(processor)
+
+			// Assert
+			if tt.expected > 0 {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, calls)
+		})
+	}
+}
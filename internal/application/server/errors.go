@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	mixturkaGrpc "github.com/vostelmakh/mixturka/internal/infrastructure/grpc"
+)
+
+// errorDomain домен ошибок в google.rpc.ErrorInfo.
+const errorDomain = "mixturka"
+
+var appErrorCodes = map[string]codes.Code{
+	domainErrors.NotFound:              codes.NotFound,
+	domainErrors.ValidationError:       codes.InvalidArgument,
+	domainErrors.ResourceAlreadyExists: codes.AlreadyExists,
+	domainErrors.FailedPrecondition:    codes.FailedPrecondition,
+	domainErrors.ResourceExhausted:     codes.ResourceExhausted,
+	domainErrors.NotAuthenticated:      codes.Unauthenticated,
+	domainErrors.NotAuthorized:         codes.PermissionDenied,
+}
+
+// legacyErrorCodes коды поля Error.code, на которые рассчитаны старые клиенты BrewPot.
+var legacyErrorCodes = map[codes.Code]int32{
+	codes.NotFound:           http.StatusNotFound,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.FailedPrecondition: http.StatusConflict,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+}
+
+// toStatusError переводит ошибку прикладного слоя в gRPC-статус с деталями google.rpc.
+// Неизвестные ошибки логируются и отдаются клиенту как Internal без подробностей.
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var appErr *domainErrors.AppError
+	if !errors.As(err, &appErr) {
+		log.Printf("grpc: internal error: %v", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	code, ok := appErrorCodes[appErr.Type]
+	if !ok {
+		log.Printf("grpc: internal error: %v", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason: appErr.Type,
+		Domain: errorDomain,
+	}}
+
+	if len(appErr.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range appErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	if len(appErr.Suggestions) > 0 {
+		suggestions := &mixturkaGrpc.FieldSuggestions{}
+		for _, suggestion := range appErr.Suggestions {
+			fieldSuggestion := &mixturkaGrpc.FieldSuggestion{
+				Field: suggestion.Field,
+				Value: suggestion.Value,
+			}
+			for _, candidate := range suggestion.Candidates {
+				fieldSuggestion.Candidates = append(fieldSuggestion.Candidates, &mixturkaGrpc.SuggestedValue{
+					Value: candidate.Value,
+					Score: candidate.Score,
+				})
+			}
+			suggestions.Suggestions = append(suggestions.Suggestions, fieldSuggestion)
+		}
+		details = append(details, protoadapt.MessageV1Of(suggestions))
+	}
+
+	st := status.New(code, appErr.Error())
+	if withDetails, detailsErr := st.WithDetails(details...); detailsErr == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// toLegacyError заполняет Error ответа BrewPot для клиентов, не разбирающих gRPC-статусы.
+func toLegacyError(err error) *mixturkaGrpc.Error {
+	st := status.Convert(toStatusError(err))
+
+	code, ok := legacyErrorCodes[st.Code()]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+
+	legacyErr := &mixturkaGrpc.Error{
+		Code:    code,
+		Message: st.Message(),
+		Data:    map[string]string{"grpc_code": st.Code().String()},
+	}
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			legacyErr.Data["reason"] = d.GetReason()
+		case *errdetails.BadRequest:
+			for _, violation := range d.GetFieldViolations() {
+				legacyErr.Data[violation.GetField()] = violation.GetDescription()
+			}
+		}
+	}
+
+	return legacyErr
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	mixturkaGrpc "github.com/vostelmakh/mixturka/internal/infrastructure/grpc"
+)
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		name                string
+		err                 error
+		expectedCode        codes.Code
+		expectedMessage     string
+		expectedReason      string
+		expectedViolations  int
+		expectedSuggestions int
+	}{
+		{
+			name:            "NotFound",
+			err:             domainErrors.NewAppErrorWithType(domainErrors.NotFound),
+			expectedCode:    codes.NotFound,
+			expectedMessage: "record not found",
+			expectedReason:  domainErrors.NotFound,
+		},
+		{
+			name: "ValidationError с нарушениями полей",
+			err: domainErrors.NewValidationError(errors.New("invalid brew ingredients"),
+				domainErrors.FieldViolation{Field: "ingredients[0].name", Description: "name must not be empty"},
+			),
+			expectedCode:       codes.InvalidArgument,
+			expectedMessage:    "invalid brew ingredients",
+			expectedReason:     domainErrors.ValidationError,
+			expectedViolations: 1,
+		},
+		{
+			name: "ValidationError с подсказками",
+			err: domainErrors.NewValidationError(errors.New("unknown brew ingredients"),
+				domainErrors.FieldViolation{Field: "ingredients[0].name", Description: `unknown ingredient "крапва", did you mean "крапива"?`},
+			).WithSuggestions(domainErrors.Suggestion{
+				Field:      "ingredients[0].name",
+				Value:      "крапва",
+				Candidates: []domainErrors.Candidate{{Value: "крапива", Score: 0.86}},
+			}),
+			expectedCode:        codes.InvalidArgument,
+			expectedMessage:     "unknown brew ingredients",
+			expectedReason:      domainErrors.ValidationError,
+			expectedViolations:  1,
+			expectedSuggestions: 1,
+		},
+		{
+			name:            "обёрнутая ResourceAlreadyExists",
+			err:             fmt.Errorf("save: %w", domainErrors.NewAppErrorWithType(domainErrors.ResourceAlreadyExists)),
+			expectedCode:    codes.AlreadyExists,
+			expectedMessage: "resource already exists",
+			expectedReason:  domainErrors.ResourceAlreadyExists,
+		},
+		{
+			name:            "NotAuthorized",
+			err:             domainErrors.NewAppErrorWithType(domainErrors.NotAuthorized),
+			expectedCode:    codes.PermissionDenied,
+			expectedMessage: "not authorized",
+			expectedReason:  domainErrors.NotAuthorized,
+		},
+		{
+			name:            "FailedPrecondition",
+			err:             domainErrors.NewAppError(errors.New("brew is already finished"), domainErrors.FailedPrecondition),
+			expectedCode:    codes.FailedPrecondition,
+			expectedMessage: "brew is already finished",
+			expectedReason:  domainErrors.FailedPrecondition,
+		},
+		{
+			name:            "ResourceExhausted",
+			err:             domainErrors.NewAppErrorWithType(domainErrors.ResourceExhausted),
+			expectedCode:    codes.ResourceExhausted,
+			expectedMessage: "resource exhausted",
+			expectedReason:  domainErrors.ResourceExhausted,
+		},
+		{
+			name:            "RepositoryError скрывается за Internal",
+			err:             domainErrors.NewAppErrorWithType(domainErrors.RepositoryError),
+			expectedCode:    codes.Internal,
+			expectedMessage: "internal error",
+		},
+		{
+			name:            "ошибка базы данных скрывается за Internal",
+			err:             errors.New("pq: connection refused"),
+			expectedCode:    codes.Internal,
+			expectedMessage: "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			st := status.Convert(toStatusError(tt.err))
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, st.Code())
+			assert.Equal(t, tt.expectedMessage, st.Message())
+
+			var reason string
+			var violations, suggestions int
+			for _, detail := range st.Details() {
+				switch d := detail.(type) {
+				case *errdetails.ErrorInfo:
+					reason = d.GetReason()
+				case *errdetails.BadRequest:
+					violations = len(d.GetFieldViolations())
+				case *mixturkaGrpc.FieldSuggestions:
+					suggestions = len(d.GetSuggestions())
+				}
+			}
+			assert.Equal(t, tt.expectedReason, reason)
+			assert.Equal(t, tt.expectedViolations, violations)
+			assert.Equal(t, tt.expectedSuggestions, suggestions)
+		})
+	}
+}
@@ -0,0 +1,670 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/vostelmakh/mixturka/internal/application/processor/brew"
+	"github.com/vostelmakh/mixturka/internal/application/processor/recipe"
+	"github.com/vostelmakh/mixturka/internal/domain"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/jsonrpc"
+)
+
+const (
+	MethodRecipesList    = "recipes.list"
+	MethodRecipesSuggest = "recipes.suggest"
+	MethodRecipesYield   = "recipes.yield"
+	MethodPotBrew        = "pot.brew"
+	MethodShoppingList   = "shopping.list"
+	MethodProductionPlan = "production.plan"
+	MethodBrewsGet       = "brews.get"
+	MethodBrewsList      = "brews.list"
+	MethodBrewsCancel    = "brews.cancel"
+	MethodBrewsHistory   = "brews.history"
+)
+
+type JSONRPCServer struct {
+	recipeProcessor *recipe.Processor
+	brewProcessor   *brew.Processor
+}
+
+func NewJSONRPCServer(recipeProcessor *recipe.Processor, brewProcessor *brew.Processor) *JSONRPCServer {
+	return &JSONRPCServer{
+		recipeProcessor: recipeProcessor,
+		brewProcessor:   brewProcessor,
+	}
+}
+
+// Register регистрирует методы из specs/server.yaml в JSON-RPC сервере.
+func (s *JSONRPCServer) Register(rpc *jsonrpc.Server) {
+	rpc.Register(MethodRecipesList, s.RecipesList)
+	rpc.Register(MethodRecipesSuggest, s.RecipesSuggest)
+	rpc.Register(MethodRecipesYield, s.RecipesYield)
+	rpc.Register(MethodPotBrew, s.PotBrew)
+	rpc.Register(MethodShoppingList, s.ShoppingList)
+	rpc.Register(MethodProductionPlan, s.ProductionPlan)
+	rpc.Register(MethodBrewsGet, s.BrewsGet)
+	rpc.Register(MethodBrewsList, s.BrewsList)
+	rpc.Register(MethodBrewsCancel, s.BrewsCancel)
+	rpc.Register(MethodBrewsHistory, s.BrewsHistory)
+}
+
+type rpcIngredient struct {
+	ID       int64      `json:"id,omitempty"`
+	Name     string     `json:"name"`
+	Quantity float64    `json:"quantity"`
+	Unit     units.Unit `json:"unit,omitempty"`
+}
+
+type rpcRecipe struct {
+	ID          int64           `json:"id"`
+	Name        string          `json:"name"`
+	Ingredients []rpcIngredient `json:"ingredients"`
+	// BrewDuration длительность варки в формате Go, например "1m30s".
+	BrewDuration string `json:"brew_duration,omitempty"`
+}
+
+type recipesListParams struct {
+	IngredientsFilter rpcIngredientsFilter `json:"ingredients_filter"`
+	PageSize          int                  `json:"page_size"`
+	PageToken         string               `json:"page_token"`
+	OrderBy           domain.RecipeOrder   `json:"order_by"`
+}
+
+// rpcIngredientsFilter принимает как объект с all_of/any_of/none_of,
+// так и массив названий, который трактуется как all_of.
+type rpcIngredientsFilter struct {
+	AllOf  []string `json:"all_of"`
+	AnyOf  []string `json:"any_of"`
+	NoneOf []string `json:"none_of"`
+}
+
+func (f *rpcIngredientsFilter) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		f.AllOf = names
+		return nil
+	}
+
+	type filter rpcIngredientsFilter
+
+	return json.Unmarshal(data, (*filter)(f))
+}
+
+type recipesListResult struct {
+	Recipes       []rpcRecipe `json:"recipes"`
+	NextPageToken string      `json:"next_page_token,omitempty"`
+}
+
+type recipesSuggestParams struct {
+	Ingredients      []rpcIngredient `json:"ingredients"`
+	MatchMode        brew.MatchMode  `json:"match_mode"`
+	TolerancePercent *int            `json:"tolerance_percent"`
+	Limit            int             `json:"limit"`
+}
+
+type recipesSuggestResult struct {
+	Suggestions []rpcSuggestion `json:"suggestions"`
+}
+
+type rpcSuggestion struct {
+	Recipe   rpcRecipe       `json:"recipe"`
+	Brewable bool            `json:"brewable"`
+	Coverage float64         `json:"coverage"`
+	Missing  []rpcIngredient `json:"missing"`
+	Excess   []rpcIngredient `json:"excess"`
+}
+
+type potBrewParams struct {
+	Ingredients      []rpcIngredient `json:"ingredients"`
+	MatchMode        brew.MatchMode  `json:"match_mode"`
+	TolerancePercent *int            `json:"tolerance_percent"`
+	// StartAt время запуска запланированной варки, без него варка начинается сразу.
+	StartAt *time.Time `json:"start_at"`
+}
+
+type potBrewResult struct {
+	Started       bool              `json:"started"`
+	BrewID        string            `json:"brew_id,omitempty"`
+	Recipe        *rpcBrewedRecipe  `json:"recipe,omitempty"`
+	Consumed      []rpcIngredient   `json:"consumed"`
+	Leftovers     []rpcIngredient   `json:"leftovers"`
+	Substitutions []rpcSubstitution `json:"substitutions"`
+	Corrections   []rpcCorrection   `json:"corrections"`
+	State         domain.BrewState  `json:"state,omitempty"`
+	StartAt       *time.Time        `json:"start_at,omitempty"`
+	QueuePosition int               `json:"queue_position"`
+}
+
+type rpcCorrection struct {
+	Name      string  `json:"name"`
+	Corrected string  `json:"corrected"`
+	Score     float64 `json:"score"`
+}
+
+type rpcSubstitution struct {
+	Ingredient         string     `json:"ingredient"`
+	Substitute         string     `json:"substitute"`
+	Quantity           float64    `json:"quantity"`
+	SubstituteQuantity float64    `json:"substitute_quantity"`
+	Unit               units.Unit `json:"unit"`
+	SubstituteUnit     units.Unit `json:"substitute_unit"`
+}
+
+type rpcBrewedRecipe struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (s *JSONRPCServer) RecipesList(ctx context.Context, params json.RawMessage) (any, error) {
+	var p recipesListParams
+	if err := jsonrpc.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	page, err := s.recipeProcessor.GetRecipes(ctx, recipe.ListQuery{
+		Filter: domain.RecipeFilter{
+			AllOf:  p.IngredientsFilter.AllOf,
+			AnyOf:  p.IngredientsFilter.AnyOf,
+			NoneOf: p.IngredientsFilter.NoneOf,
+		},
+		OrderBy:   p.OrderBy,
+		PageSize:  p.PageSize,
+		PageToken: p.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := recipesListResult{
+		Recipes:       make([]rpcRecipe, 0, len(page.Recipes)),
+		NextPageToken: page.NextPageToken,
+	}
+
+	for _, recipe := range page.Recipes {
+		result.Recipes = append(result.Recipes, toRPCRecipe(recipe))
+	}
+
+	return result, nil
+}
+
+func (s *JSONRPCServer) RecipesSuggest(ctx context.Context, params json.RawMessage) (any, error) {
+	var p recipesSuggestParams
+	if err := jsonrpc.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.Ingredients == nil {
+		return nil, jsonrpc.NewError(jsonrpc.CodeInvalidParams, "invalid params").
+			WithData("reason", "ingredients are required")
+	}
+
+	suggestions, err := s.brewProcessor.SuggestRecipes(ctx, brew.SuggestQuery{
+		Ingredients: fromRPCIngredients(p.Ingredients),
+		Match: brew.Match{
+			Mode:             p.MatchMode,
+			TolerancePercent: p.TolerancePercent,
+		},
+		Limit: p.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := recipesSuggestResult{
+		Suggestions: make([]rpcSuggestion, 0, len(suggestions)),
+	}
+
+	for _, suggestion := range suggestions {
+		result.Suggestions = append(result.Suggestions, rpcSuggestion{
+			Recipe:   toRPCRecipe(suggestion.Recipe),
+			Brewable: suggestion.Brewable,
+			Coverage: suggestion.Coverage,
+			Missing:  toRPCIngredients(suggestion.Missing),
+			Excess:   toRPCIngredients(suggestion.Excess),
+		})
+	}
+
+	return result, nil
+}
+
+func (s *JSONRPCServer) PotBrew(ctx context.Context, params json.RawMessage) (any, error) {
+	var p potBrewParams
+	if err := jsonrpc.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.Ingredients == nil {
+		return nil, jsonrpc.NewError(jsonrpc.CodeInvalidParams, "invalid params").
+			WithData("reason", "ingredients are required")
+	}
+
+	ingredients := fromRPCIngredients(p.Ingredients)
+	match := brew.Match{
+		Mode:             p.MatchMode,
+		TolerancePercent: p.TolerancePercent,
+	}
+
+	var brewed *brew.Result
+	var err error
+	if p.StartAt != nil {
+		brewed, err = s.brewProcessor.ScheduleBrew(ctx, ingredients, match, *p.StartAt)
+	} else {
+		brewed, err = s.brewProcessor.BrewPot(ctx, ingredients, match)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := potBrewResult{
+		Started:       brewed.Started,
+		BrewID:        brewed.BrewID,
+		Consumed:      toRPCIngredients(brewed.Consumed),
+		Leftovers:     toRPCIngredients(brewed.Leftovers),
+		Substitutions: make([]rpcSubstitution, 0, len(brewed.Substitutions)),
+		Corrections:   make([]rpcCorrection, 0, len(brewed.Corrections)),
+		State:         brewed.State,
+		StartAt:       brewed.StartAt,
+		QueuePosition: brewed.QueuePosition,
+	}
+	for _, substitution := range brewed.Substitutions {
+		result.Substitutions = append(result.Substitutions, rpcSubstitution(substitution))
+	}
+	for _, correction := range brewed.Corrections {
+		result.Corrections = append(result.Corrections, rpcCorrection(correction))
+	}
+	if brewed.Started {
+		result.Recipe = &rpcBrewedRecipe{ID: brewed.RecipeID, Name: brewed.RecipeName}
+	}
+
+	return result, nil
+}
+
+type recipesYieldParams struct {
+	RecipeID    int64           `json:"recipe_id"`
+	Ingredients []rpcIngredient `json:"ingredients"`
+}
+
+type recipesYieldResult struct {
+	Yields []rpcYield `json:"yields"`
+}
+
+type rpcYield struct {
+	Recipe             rpcRecipe `json:"recipe"`
+	Batches            int       `json:"batches"`
+	LimitingIngredient string    `json:"limiting_ingredient,omitempty"`
+}
+
+func (s *JSONRPCServer) RecipesYield(ctx context.Context, params json.RawMessage) (any, error) {
+	var p recipesYieldParams
+	if err := jsonrpc.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	yields, err := s.brewProcessor.CalculateYield(ctx, brew.YieldQuery{
+		RecipeID:    p.RecipeID,
+		Ingredients: fromRPCIngredients(p.Ingredients),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := recipesYieldResult{
+		Yields: make([]rpcYield, 0, len(yields)),
+	}
+	for _, yield := range yields {
+		result.Yields = append(result.Yields, rpcYield{
+			Recipe:             toRPCRecipe(yield.Recipe),
+			Batches:            yield.Batches,
+			LimitingIngredient: yield.LimitingIngredient,
+		})
+	}
+
+	return result, nil
+}
+
+type shoppingListParams struct {
+	Targets     []rpcShoppingTarget `json:"targets"`
+	Ingredients []rpcIngredient     `json:"ingredients"`
+}
+
+type rpcShoppingTarget struct {
+	RecipeID int64 `json:"recipe_id"`
+	Batches  int   `json:"batches"`
+}
+
+type shoppingListResult struct {
+	Items []rpcShoppingItem `json:"items"`
+}
+
+type rpcShoppingItem struct {
+	Name    string     `json:"name"`
+	Unit    units.Unit `json:"unit"`
+	Needed  float64    `json:"needed"`
+	Have    float64    `json:"have"`
+	Missing float64    `json:"missing"`
+}
+
+func (s *JSONRPCServer) ShoppingList(ctx context.Context, params json.RawMessage) (any, error) {
+	var p shoppingListParams
+	if err := jsonrpc.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	query := brew.ShoppingQuery{
+		Targets:     make([]brew.ShoppingTarget, 0, len(p.Targets)),
+		Ingredients: fromRPCIngredients(p.Ingredients),
+	}
+	for _, target := range p.Targets {
+		query.Targets = append(query.Targets, brew.ShoppingTarget{
+			RecipeID: target.RecipeID,
+			Batches:  target.Batches,
+		})
+	}
+
+	items, err := s.brewProcessor.ShoppingList(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := shoppingListResult{
+		Items: make([]rpcShoppingItem, 0, len(items)),
+	}
+	for _, item := range items {
+		result.Items = append(result.Items, rpcShoppingItem(item))
+	}
+
+	return result, nil
+}
+
+type productionPlanParams struct {
+	Targets     []rpcPlanTarget `json:"targets"`
+	Ingredients []rpcIngredient `json:"ingredients"`
+}
+
+type rpcPlanTarget struct {
+	RecipeID   int64   `json:"recipe_id"`
+	Value      float64 `json:"value"`
+	MinBatches int     `json:"min_batches"`
+	MaxBatches int     `json:"max_batches"`
+}
+
+type productionPlanResult struct {
+	Brews      []rpcPlannedBrew `json:"brews"`
+	TotalValue float64          `json:"total_value"`
+	Leftovers  []rpcIngredient  `json:"leftovers"`
+	Optimal    bool             `json:"optimal"`
+}
+
+type rpcPlannedBrew struct {
+	Recipe  rpcRecipe `json:"recipe"`
+	Batches int       `json:"batches"`
+	Value   float64   `json:"value"`
+}
+
+func (s *JSONRPCServer) ProductionPlan(ctx context.Context, params json.RawMessage) (any, error) {
+	var p productionPlanParams
+	if err := jsonrpc.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	query := brew.PlanQuery{
+		Targets:     make([]brew.PlanTarget, 0, len(p.Targets)),
+		Ingredients: fromRPCIngredients(p.Ingredients),
+	}
+	for _, target := range p.Targets {
+		query.Targets = append(query.Targets, brew.PlanTarget(target))
+	}
+
+	plan, err := s.brewProcessor.PlanProduction(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := productionPlanResult{
+		Brews:      make([]rpcPlannedBrew, 0, len(plan.Brews)),
+		TotalValue: plan.TotalValue,
+		Leftovers:  toRPCIngredients(plan.Leftovers),
+		Optimal:    plan.Optimal,
+	}
+	for _, planned := range plan.Brews {
+		result.Brews = append(result.Brews, rpcPlannedBrew{
+			Recipe:  toRPCRecipe(planned.Recipe),
+			Batches: planned.Batches,
+			Value:   planned.Value,
+		})
+	}
+
+	return result, nil
+}
+
+type brewsGetParams struct {
+	BrewID string `json:"brew_id"`
+}
+
+type brewsCancelParams struct {
+	BrewID      string `json:"brew_id"`
+	CancelledBy string `json:"cancelled_by"`
+	Reason      string `json:"reason"`
+}
+
+type brewsListParams struct {
+	States    []domain.BrewState `json:"states"`
+	RecipeID  int64              `json:"recipe_id"`
+	PageSize  int                `json:"page_size"`
+	PageToken string             `json:"page_token"`
+}
+
+type brewsListResult struct {
+	Brews         []rpcBrew `json:"brews"`
+	NextPageToken string    `json:"next_page_token,omitempty"`
+}
+
+type rpcBrew struct {
+	ID                  string           `json:"id"`
+	State               domain.BrewState `json:"state"`
+	RecipeID            int64            `json:"recipe_id,omitempty"`
+	RecipeName          string           `json:"recipe_name"`
+	Consumed            []rpcIngredient  `json:"consumed"`
+	Leftovers           []rpcIngredient  `json:"leftovers"`
+	Duration            string           `json:"duration"`
+	Error               string           `json:"error,omitempty"`
+	CreatedAt           time.Time        `json:"created_at"`
+	StartAt             *time.Time       `json:"start_at,omitempty"`
+	StartedAt           *time.Time       `json:"started_at,omitempty"`
+	FinishedAt          *time.Time       `json:"finished_at,omitempty"`
+	CancelledBy         string           `json:"cancelled_by,omitempty"`
+	CancelledByCaller   string           `json:"cancelled_by_caller,omitempty"`
+	CancelReason        string           `json:"cancel_reason,omitempty"`
+	IngredientsReturned bool             `json:"ingredients_returned"`
+}
+
+func (s *JSONRPCServer) BrewsGet(ctx context.Context, params json.RawMessage) (any, error) {
+	var p brewsGetParams
+	if err := jsonrpc.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	brew, err := s.brewProcessor.GetBrew(ctx, p.BrewID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRPCBrew(*brew), nil
+}
+
+func (s *JSONRPCServer) BrewsCancel(ctx context.Context, params json.RawMessage) (any, error) {
+	var p brewsCancelParams
+	if err := jsonrpc.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	brew, err := s.brewProcessor.CancelBrew(ctx, brew.BrewCancellation(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return toRPCBrew(*brew), nil
+}
+
+func (s *JSONRPCServer) BrewsList(ctx context.Context, params json.RawMessage) (any, error) {
+	var p brewsListParams
+	if err := jsonrpc.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	page, err := s.brewProcessor.ListBrews(ctx, brew.BrewListQuery(p))
+	if err != nil {
+		return nil, err
+	}
+
+	result := brewsListResult{
+		Brews:         make([]rpcBrew, 0, len(page.Brews)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, brew := range page.Brews {
+		result.Brews = append(result.Brews, toRPCBrew(brew))
+	}
+
+	return result, nil
+}
+
+type brewsHistoryParams struct {
+	RecipeID      int64                `json:"recipe_id"`
+	Outcomes      []domain.BrewOutcome `json:"outcomes"`
+	RequestedFrom *time.Time           `json:"requested_from"`
+	RequestedTo   *time.Time           `json:"requested_to"`
+	PageSize      int                  `json:"page_size"`
+	PageToken     string               `json:"page_token"`
+}
+
+type brewsHistoryResult struct {
+	Records       []rpcBrewRecord `json:"records"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+}
+
+type rpcBrewRecord struct {
+	ID          string             `json:"id"`
+	Caller      string             `json:"caller,omitempty"`
+	Ingredients []rpcIngredient    `json:"ingredients"`
+	MatchMode   string             `json:"match_mode,omitempty"`
+	RecipeID    int64              `json:"recipe_id,omitempty"`
+	RecipeName  string             `json:"recipe_name,omitempty"`
+	BrewID      string             `json:"brew_id,omitempty"`
+	Outcome     domain.BrewOutcome `json:"outcome"`
+	Error       string             `json:"error,omitempty"`
+	BrewState   domain.BrewState   `json:"brew_state,omitempty"`
+	StartAt     *time.Time         `json:"start_at,omitempty"`
+	RequestedAt time.Time          `json:"requested_at"`
+}
+
+func (s *JSONRPCServer) BrewsHistory(ctx context.Context, params json.RawMessage) (any, error) {
+	var p brewsHistoryParams
+	if err := jsonrpc.DecodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	page, err := s.brewProcessor.ListBrewHistory(ctx, brew.BrewHistoryQuery(p))
+	if err != nil {
+		return nil, err
+	}
+
+	result := brewsHistoryResult{
+		Records:       make([]rpcBrewRecord, 0, len(page.Records)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, record := range page.Records {
+		result.Records = append(result.Records, rpcBrewRecord{
+			ID:          record.ID,
+			Caller:      record.Caller,
+			Ingredients: toRPCRecipeIngredients(record.Ingredients),
+			MatchMode:   record.MatchMode,
+			RecipeID:    record.RecipeID,
+			RecipeName:  record.RecipeName,
+			BrewID:      record.BrewID,
+			Outcome:     record.Outcome,
+			Error:       record.Error,
+			BrewState:   record.BrewState,
+			StartAt:     record.StartAt,
+			RequestedAt: record.RequestedAt,
+		})
+	}
+
+	return result, nil
+}
+
+func toRPCBrew(brew domain.Brew) rpcBrew {
+	return rpcBrew{
+		ID:                  brew.ID,
+		State:               brew.State,
+		RecipeID:            brew.RecipeID,
+		RecipeName:          brew.RecipeName,
+		Consumed:            toRPCRecipeIngredients(brew.Consumed),
+		Leftovers:           toRPCRecipeIngredients(brew.Leftovers),
+		Duration:            brew.Duration.String(),
+		Error:               brew.Error,
+		CreatedAt:           brew.CreatedAt,
+		StartAt:             brew.StartAt,
+		StartedAt:           brew.StartedAt,
+		FinishedAt:          brew.FinishedAt,
+		CancelledBy:         brew.CancelledBy,
+		CancelledByCaller:   brew.CancelledByCaller,
+		CancelReason:        brew.CancelReason,
+		IngredientsReturned: brew.IngredientsReturned,
+	}
+}
+
+func toRPCRecipe(recipe domain.Recipe) rpcRecipe {
+	result := rpcRecipe{
+		ID:          recipe.ID,
+		Name:        recipe.Name,
+		Ingredients: toRPCRecipeIngredients(recipe.Ingredients),
+	}
+	if recipe.BrewDuration > 0 {
+		result.BrewDuration = recipe.BrewDuration.String()
+	}
+
+	return result
+}
+
+func toRPCRecipeIngredients(ingredients []domain.Ingredient) []rpcIngredient {
+	result := make([]rpcIngredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		result = append(result, rpcIngredient{
+			ID:       ingredient.ID,
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+		})
+	}
+
+	return result
+}
+
+func fromRPCIngredients(ingredients []rpcIngredient) []brew.Ingredient {
+	result := make([]brew.Ingredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		result = append(result, brew.Ingredient{
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+		})
+	}
+
+	return result
+}
+
+func toRPCIngredients(ingredients []brew.Ingredient) []rpcIngredient {
+	result := make([]rpcIngredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		result = append(result, rpcIngredient{
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+		})
+	}
+
+	return result
+}
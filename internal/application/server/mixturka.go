@@ -2,79 +2,637 @@ package server
 
 import (
 	"context"
+	"errors"
+	"math"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/vostelmakh/mixturka/internal/application/processor/brew"
 	"github.com/vostelmakh/mixturka/internal/application/processor/recipe"
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
 	mixturkaGrpc "github.com/vostelmakh/mixturka/internal/infrastructure/grpc"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/grpc/interceptors"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/proxy"
 )
 
+type Config struct {
+	// LegacyBrewErrors возвращает ошибки BrewPot в поле Error ответа с успешным gRPC-статусом,
+	// как это делали прежние версии сервиса, вместо gRPC-статуса с деталями.
+	LegacyBrewErrors bool
+	// TrustedProxies прокси, от которых принимаются x-caller и x-forwarded-for.
+	TrustedProxies proxy.Trusted
+}
+
 type MixturkaServer struct {
 	mixturkaGrpc.UnimplementedMixturkaServer
 	recipeProcessor *recipe.Processor
 	brewProcessor   *brew.Processor
+	config          Config
 }
 
-func NewMixturkaServer(recipeProcessor *recipe.Processor, brewProcessor *brew.Processor) *MixturkaServer {
+func NewMixturkaServer(recipeProcessor *recipe.Processor, brewProcessor *brew.Processor, config Config) *MixturkaServer {
 	return &MixturkaServer{
 		recipeProcessor: recipeProcessor,
 		brewProcessor:   brewProcessor,
+		config:          config,
 	}
 }
 
 func (s *MixturkaServer) GetRecipes(ctx context.Context, req *mixturkaGrpc.GetRecipesRequest) (*mixturkaGrpc.GetRecipesResponse, error) {
-	recipes, err := s.recipeProcessor.GetRecipes(ctx)
+	orderBy := domain.RecipeOrderID
+	if req.GetOrderBy() == mixturkaGrpc.RecipeOrder_RECIPE_ORDER_NAME {
+		orderBy = domain.RecipeOrderName
+	}
+
+	page, err := s.recipeProcessor.GetRecipes(ctx, recipe.ListQuery{
+		Filter: domain.RecipeFilter{
+			AllOf:  req.GetIngredientsFilter().GetAllOf(),
+			AnyOf:  req.GetIngredientsFilter().GetAnyOf(),
+			NoneOf: req.GetIngredientsFilter().GetNoneOf(),
+		},
+		OrderBy:   orderBy,
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	})
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	response := &mixturkaGrpc.GetRecipesResponse{
-		Recipes: make([]*mixturkaGrpc.Recipe, 0, len(recipes)),
+		Recipes:       make([]*mixturkaGrpc.Recipe, 0, len(page.Recipes)),
+		NextPageToken: page.NextPageToken,
+	}
+
+	for _, recipe := range page.Recipes {
+		response.Recipes = append(response.Recipes, toGRPCRecipe(recipe))
 	}
 
-	for _, recipe := range recipes {
-		grpcRecipe := &mixturkaGrpc.Recipe{
-			Id:          recipe.ID,
-			Name:        recipe.Name,
-			Ingredients: make([]*mixturkaGrpc.Ingredient, 0, len(recipe.Ingredients)),
+	return response, nil
+}
+
+func (s *MixturkaServer) BrewPot(ctx context.Context, req *mixturkaGrpc.PotBrewRequest) (*mixturkaGrpc.PotBrewResponse, error) {
+	ingredients := fromGRPCIngredients(req.GetIngredients())
+	match := toBrewMatch(req.GetMatchMode(), req.TolerancePercent)
+	ctx = brew.WithCaller(ctx, caller(ctx, s.config.TrustedProxies))
+
+	// Запускаем процесс варки сразу или планируем на start_at
+	var result *brew.Result
+	var err error
+	if req.StartAt != nil {
+		result, err = s.scheduleBrew(ctx, ingredients, match, req.GetStartAt())
+	} else {
+		result, err = s.brewProcessor.BrewPot(ctx, ingredients, match)
+	}
+	if err != nil {
+		if s.config.LegacyBrewErrors {
+			return &mixturkaGrpc.PotBrewResponse{
+				Started: false,
+				Error:   toLegacyError(err),
+			}, nil
 		}
 
-		for _, ingredient := range recipe.Ingredients {
-			grpcRecipe.Ingredients = append(grpcRecipe.Ingredients, &mixturkaGrpc.Ingredient{
-				Id:       ingredient.ID,
-				Name:     ingredient.Name,
-				Quantity: int32(ingredient.Quantity),
+		return nil, toStatusError(err)
+	}
+
+	return &mixturkaGrpc.PotBrewResponse{
+		Started:       result.Started,
+		BrewId:        result.BrewID,
+		RecipeId:      result.RecipeID,
+		RecipeName:    result.RecipeName,
+		Consumed:      toGRPCIngredients(result.Consumed),
+		Leftovers:     toGRPCIngredients(result.Leftovers),
+		Substitutions: toGRPCSubstitutions(result.Substitutions),
+		Corrections:   toGRPCCorrections(result.Corrections),
+		State:         toGRPCBrewState(result.State),
+		StartAt:       toTimestamp(result.StartAt),
+		QueuePosition: uint32(result.QueuePosition),
+	}, nil
+}
+
+func (s *MixturkaServer) scheduleBrew(ctx context.Context, ingredients []brew.Ingredient, match brew.Match, startAt *timestamppb.Timestamp) (*brew.Result, error) {
+	if err := startAt.CheckValid(); err != nil {
+		return nil, domainErrors.NewValidationError(err, domainErrors.FieldViolation{
+			Field:       "start_at",
+			Description: "start time is not a valid timestamp",
+		})
+	}
+
+	return s.brewProcessor.ScheduleBrew(ctx, ingredients, match, startAt.AsTime())
+}
+
+func (s *MixturkaServer) GetBrewStatus(ctx context.Context, req *mixturkaGrpc.GetBrewStatusRequest) (*mixturkaGrpc.BrewSession, error) {
+	brew, err := s.brewProcessor.GetBrew(ctx, req.GetBrewId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toGRPCBrewSession(*brew), nil
+}
+
+func (s *MixturkaServer) CancelBrew(ctx context.Context, req *mixturkaGrpc.CancelBrewRequest) (*mixturkaGrpc.BrewSession, error) {
+	ctx = brew.WithCaller(ctx, caller(ctx, s.config.TrustedProxies))
+	brew, err := s.brewProcessor.CancelBrew(ctx, brew.BrewCancellation{
+		BrewID:      req.GetBrewId(),
+		CancelledBy: req.GetCancelledBy(),
+		Reason:      req.GetReason(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toGRPCBrewSession(*brew), nil
+}
+
+func (s *MixturkaServer) ListBrews(ctx context.Context, req *mixturkaGrpc.ListBrewsRequest) (*mixturkaGrpc.ListBrewsResponse, error) {
+	query := brew.BrewListQuery{
+		States:    make([]domain.BrewState, 0, len(req.GetStates())),
+		RecipeID:  req.GetRecipeId(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	for _, state := range req.GetStates() {
+		query.States = append(query.States, fromGRPCBrewState(state))
+	}
+
+	page, err := s.brewProcessor.ListBrews(ctx, query)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response := &mixturkaGrpc.ListBrewsResponse{
+		Brews:         make([]*mixturkaGrpc.BrewSession, 0, len(page.Brews)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, brew := range page.Brews {
+		response.Brews = append(response.Brews, toGRPCBrewSession(brew))
+	}
+
+	return response, nil
+}
+
+func (s *MixturkaServer) ListBrewHistory(ctx context.Context, req *mixturkaGrpc.ListBrewHistoryRequest) (*mixturkaGrpc.ListBrewHistoryResponse, error) {
+	query := brew.BrewHistoryQuery{
+		RecipeID:  req.GetRecipeId(),
+		Outcomes:  make([]domain.BrewOutcome, 0, len(req.GetOutcomes())),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	for _, outcome := range req.GetOutcomes() {
+		query.Outcomes = append(query.Outcomes, fromGRPCBrewOutcome(outcome))
+	}
+
+	var violations []domainErrors.FieldViolation
+	for _, timestamp := range []struct {
+		field string
+		value *timestamppb.Timestamp
+	}{
+		{field: "requested_from", value: req.RequestedFrom},
+		{field: "requested_to", value: req.RequestedTo},
+	} {
+		if timestamp.value != nil && timestamp.value.CheckValid() != nil {
+			violations = append(violations, domainErrors.FieldViolation{
+				Field:       timestamp.field,
+				Description: "time is not a valid timestamp",
 			})
 		}
+	}
+	if len(violations) > 0 {
+		return nil, toStatusError(domainErrors.NewValidationError(errors.New("invalid brew history query"), violations...))
+	}
 
-		response.Recipes = append(response.Recipes, grpcRecipe)
+	query.RequestedFrom = fromTimestamp(req.RequestedFrom)
+	query.RequestedTo = fromTimestamp(req.RequestedTo)
+
+	page, err := s.brewProcessor.ListBrewHistory(ctx, query)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response := &mixturkaGrpc.ListBrewHistoryResponse{
+		Records:       make([]*mixturkaGrpc.BrewRecord, 0, len(page.Records)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, record := range page.Records {
+		response.Records = append(response.Records, toGRPCBrewRecord(record))
 	}
 
 	return response, nil
 }
 
-func (s *MixturkaServer) BrewPot(ctx context.Context, req *mixturkaGrpc.PotBrewRequest) (*mixturkaGrpc.PotBrewResponse, error) {
-	// Преобразуем ингредиенты из gRPC в доменные модели
-	ingredients := make([]brew.Ingredient, 0, len(req.Ingredients))
-	for _, ing := range req.Ingredients {
-		ingredients = append(ingredients, brew.Ingredient{
-			Name:     ing.Name,
-			Quantity: int(ing.Quantity),
+func (s *MixturkaServer) SuggestRecipes(ctx context.Context, req *mixturkaGrpc.SuggestRecipesRequest) (*mixturkaGrpc.SuggestRecipesResponse, error) {
+	suggestions, err := s.brewProcessor.SuggestRecipes(ctx, brew.SuggestQuery{
+		Ingredients: fromGRPCIngredients(req.GetIngredients()),
+		Match:       toBrewMatch(req.GetMatchMode(), req.TolerancePercent),
+		Limit:       int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response := &mixturkaGrpc.SuggestRecipesResponse{
+		Suggestions: make([]*mixturkaGrpc.RecipeSuggestion, 0, len(suggestions)),
+	}
+
+	for _, suggestion := range suggestions {
+		response.Suggestions = append(response.Suggestions, &mixturkaGrpc.RecipeSuggestion{
+			Recipe:   toGRPCRecipe(suggestion.Recipe),
+			Brewable: suggestion.Brewable,
+			Coverage: suggestion.Coverage,
+			Missing:  toGRPCIngredients(suggestion.Missing),
+			Excess:   toGRPCIngredients(suggestion.Excess),
 		})
 	}
 
-	// Запускаем процесс варки
-	started, err := s.brewProcessor.BrewPot(ctx, ingredients)
+	return response, nil
+}
+
+func (s *MixturkaServer) GetShoppingList(ctx context.Context, req *mixturkaGrpc.ShoppingListRequest) (*mixturkaGrpc.ShoppingListResponse, error) {
+	query := brew.ShoppingQuery{
+		Targets:     make([]brew.ShoppingTarget, 0, len(req.GetTargets())),
+		Ingredients: fromGRPCIngredients(req.GetIngredients()),
+	}
+	for _, target := range req.GetTargets() {
+		query.Targets = append(query.Targets, brew.ShoppingTarget{
+			RecipeID: target.GetRecipeId(),
+			Batches:  int(target.GetBatches()),
+		})
+	}
+
+	items, err := s.brewProcessor.ShoppingList(ctx, query)
 	if err != nil {
-		return &mixturkaGrpc.PotBrewResponse{
-			Started: false,
-			Error: &mixturkaGrpc.Error{
-				Code:    500,
-				Message: err.Error(),
-			},
-		}, nil
+		return nil, toStatusError(err)
 	}
 
-	return &mixturkaGrpc.PotBrewResponse{
-		Started: started,
-	}, nil
+	response := &mixturkaGrpc.ShoppingListResponse{
+		Items: make([]*mixturkaGrpc.ShoppingItem, 0, len(items)),
+	}
+	for _, item := range items {
+		response.Items = append(response.Items, &mixturkaGrpc.ShoppingItem{
+			Name:          item.Name,
+			Needed:        roundQuantity(item.Needed),
+			Have:          roundQuantity(item.Have),
+			Missing:       roundQuantity(item.Missing),
+			NeededAmount:  item.Needed,
+			HaveAmount:    item.Have,
+			MissingAmount: item.Missing,
+			Unit:          string(item.Unit),
+		})
+	}
+
+	return response, nil
+}
+
+func (s *MixturkaServer) CalculateYield(ctx context.Context, req *mixturkaGrpc.YieldRequest) (*mixturkaGrpc.YieldResponse, error) {
+	yields, err := s.brewProcessor.CalculateYield(ctx, brew.YieldQuery{
+		RecipeID:    req.GetRecipeId(),
+		Ingredients: fromGRPCIngredients(req.GetIngredients()),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response := &mixturkaGrpc.YieldResponse{
+		Yields: make([]*mixturkaGrpc.RecipeYield, 0, len(yields)),
+	}
+	for _, yield := range yields {
+		response.Yields = append(response.Yields, &mixturkaGrpc.RecipeYield{
+			Recipe:             toGRPCRecipe(yield.Recipe),
+			Batches:            int32(yield.Batches),
+			LimitingIngredient: yield.LimitingIngredient,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *MixturkaServer) PlanProduction(ctx context.Context, req *mixturkaGrpc.PlanProductionRequest) (*mixturkaGrpc.PlanProductionResponse, error) {
+	query := brew.PlanQuery{
+		Targets:     make([]brew.PlanTarget, 0, len(req.GetTargets())),
+		Ingredients: fromGRPCIngredients(req.GetIngredients()),
+	}
+	for _, target := range req.GetTargets() {
+		query.Targets = append(query.Targets, brew.PlanTarget{
+			RecipeID:   target.GetRecipeId(),
+			Value:      target.GetValue(),
+			MinBatches: int(target.GetMinBatches()),
+			MaxBatches: int(target.GetMaxBatches()),
+		})
+	}
+
+	plan, err := s.brewProcessor.PlanProduction(ctx, query)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response := &mixturkaGrpc.PlanProductionResponse{
+		Brews:      make([]*mixturkaGrpc.PlannedBrew, 0, len(plan.Brews)),
+		TotalValue: plan.TotalValue,
+		Leftovers:  toGRPCIngredients(plan.Leftovers),
+		Optimal:    plan.Optimal,
+	}
+	for _, planned := range plan.Brews {
+		response.Brews = append(response.Brews, &mixturkaGrpc.PlannedBrew{
+			Recipe:  toGRPCRecipe(planned.Recipe),
+			Batches: int32(planned.Batches),
+			Value:   planned.Value,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *MixturkaServer) WatchRecipes(req *mixturkaGrpc.WatchRecipesRequest, stream grpc.ServerStreamingServer[mixturkaGrpc.RecipeEvent]) error {
+	err := s.recipeProcessor.Watch(stream.Context(), req.GetResumeRevision(), func(event recipe.Event) error {
+		grpcEvent := &mixturkaGrpc.RecipeEvent{
+			Type:     recipeEventTypes[event.Type],
+			Revision: event.Revision,
+		}
+		if event.Type != recipe.EventSnapshotEnd {
+			grpcEvent.Recipe = toGRPCRecipe(event.Recipe)
+		}
+
+		return stream.Send(grpcEvent)
+	})
+
+	if errors.Is(err, recipe.ErrSlowConsumer) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return toStatusError(err)
+}
+
+var brewMatchModes = map[mixturkaGrpc.BrewMatchMode]brew.MatchMode{
+	mixturkaGrpc.BrewMatchMode_BREW_MATCH_MODE_UNSPECIFIED: "",
+	mixturkaGrpc.BrewMatchMode_BREW_MATCH_MODE_EXACT:       brew.MatchExact,
+	mixturkaGrpc.BrewMatchMode_BREW_MATCH_MODE_SUPERSET:    brew.MatchSuperset,
+	mixturkaGrpc.BrewMatchMode_BREW_MATCH_MODE_TOLERANCE:   brew.MatchTolerance,
+	mixturkaGrpc.BrewMatchMode_BREW_MATCH_MODE_SUBSET:      brew.MatchSubset,
+}
+
+func toBrewMatch(mode mixturkaGrpc.BrewMatchMode, tolerancePercent *uint32) brew.Match {
+	var match brew.Match
+	if tolerancePercent != nil {
+		percent := int(*tolerancePercent)
+		match.TolerancePercent = &percent
+	}
+
+	var ok bool
+	if match.Mode, ok = brewMatchModes[mode]; !ok {
+		// Неизвестное значение отклоняется валидацией процессора.
+		match.Mode = brew.MatchMode(mode.String())
+	}
+
+	return match
+}
+
+var brewStates = map[domain.BrewState]mixturkaGrpc.BrewState{
+	domain.BrewQueued:    mixturkaGrpc.BrewState_BREW_STATE_QUEUED,
+	domain.BrewBrewing:   mixturkaGrpc.BrewState_BREW_STATE_BREWING,
+	domain.BrewFinished:  mixturkaGrpc.BrewState_BREW_STATE_FINISHED,
+	domain.BrewFailed:    mixturkaGrpc.BrewState_BREW_STATE_FAILED,
+	domain.BrewCancelled: mixturkaGrpc.BrewState_BREW_STATE_CANCELLED,
+}
+
+func toGRPCBrewState(state domain.BrewState) mixturkaGrpc.BrewState {
+	return brewStates[state]
+}
+
+func fromGRPCBrewState(state mixturkaGrpc.BrewState) domain.BrewState {
+	for domainState, grpcState := range brewStates {
+		if grpcState == state {
+			return domainState
+		}
+	}
+
+	// Неизвестное значение отклоняется валидацией процессора.
+	return domain.BrewState(state.String())
+}
+
+func toGRPCBrewSession(brew domain.Brew) *mixturkaGrpc.BrewSession {
+	return &mixturkaGrpc.BrewSession{
+		Id:                  brew.ID,
+		State:               toGRPCBrewState(brew.State),
+		RecipeId:            brew.RecipeID,
+		RecipeName:          brew.RecipeName,
+		Consumed:            toGRPCRecipeIngredients(brew.Consumed),
+		Leftovers:           toGRPCRecipeIngredients(brew.Leftovers),
+		Duration:            durationpb.New(brew.Duration),
+		Error:               brew.Error,
+		CreatedAt:           timestamppb.New(brew.CreatedAt),
+		StartAt:             toTimestamp(brew.StartAt),
+		StartedAt:           toTimestamp(brew.StartedAt),
+		FinishedAt:          toTimestamp(brew.FinishedAt),
+		CancelledBy:         brew.CancelledBy,
+		CancelledByCaller:   brew.CancelledByCaller,
+		CancelReason:        brew.CancelReason,
+		IngredientsReturned: brew.IngredientsReturned,
+	}
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	t := timestamp.AsTime()
+
+	return &t
+}
+
+var brewOutcomes = map[domain.BrewOutcome]mixturkaGrpc.BrewOutcome{
+	domain.BrewOutcomeStarted:   mixturkaGrpc.BrewOutcome_BREW_OUTCOME_STARTED,
+	domain.BrewOutcomeScheduled: mixturkaGrpc.BrewOutcome_BREW_OUTCOME_SCHEDULED,
+	domain.BrewOutcomeNoMatch:   mixturkaGrpc.BrewOutcome_BREW_OUTCOME_NO_MATCH,
+	domain.BrewOutcomeRejected:  mixturkaGrpc.BrewOutcome_BREW_OUTCOME_REJECTED,
+	domain.BrewOutcomeError:     mixturkaGrpc.BrewOutcome_BREW_OUTCOME_ERROR,
+}
+
+func fromGRPCBrewOutcome(outcome mixturkaGrpc.BrewOutcome) domain.BrewOutcome {
+	for domainOutcome, grpcOutcome := range brewOutcomes {
+		if grpcOutcome == outcome {
+			return domainOutcome
+		}
+	}
+
+	// Неизвестное значение отклоняется валидацией процессора.
+	return domain.BrewOutcome(outcome.String())
+}
+
+func toGRPCBrewMatchMode(mode string) mixturkaGrpc.BrewMatchMode {
+	for grpcMode, brewMode := range brewMatchModes {
+		if string(brewMode) == mode {
+			return grpcMode
+		}
+	}
+
+	return mixturkaGrpc.BrewMatchMode_BREW_MATCH_MODE_UNSPECIFIED
+}
+
+func toGRPCBrewRecord(record domain.BrewRecord) *mixturkaGrpc.BrewRecord {
+	return &mixturkaGrpc.BrewRecord{
+		Id:          record.ID,
+		Caller:      record.Caller,
+		Ingredients: toGRPCRecipeIngredients(record.Ingredients),
+		MatchMode:   toGRPCBrewMatchMode(record.MatchMode),
+		RecipeId:    record.RecipeID,
+		RecipeName:  record.RecipeName,
+		BrewId:      record.BrewID,
+		Outcome:     brewOutcomes[record.Outcome],
+		Error:       record.Error,
+		BrewState:   toGRPCBrewState(record.BrewState),
+		StartAt:     toTimestamp(record.StartAt),
+		RequestedAt: timestamppb.New(record.RequestedAt),
+	}
+}
+
+// caller определяет автора вызова для истории и отмены варок: вызывающего, которого
+// установила аутентификация, иначе адрес клиента. Метаданные x-caller и x-forwarded-for
+// учитываются, только если запрос пришёл от доверенного прокси. У вызовов через HTTP-шлюз
+// нет gRPC-клиента: шлюз дописывает адрес HTTP-клиента последним в x-forwarded-for.
+func caller(ctx context.Context, trusted proxy.Trusted) string {
+	if identity := interceptors.Identity(ctx); identity != "" {
+		return identity
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	forwarded := proxy.Hops(md.Get("x-forwarded-for"))
+
+	var remote string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remote = p.Addr.String()
+	} else if len(forwarded) > 0 {
+		remote, forwarded = forwarded[len(forwarded)-1], forwarded[:len(forwarded)-1]
+	}
+
+	var claimed string
+	if values := md.Get("x-caller"); len(values) > 0 {
+		claimed = values[0]
+	}
+
+	caller, _ := trusted.Caller(remote, forwarded, claimed)
+
+	return caller
+}
+
+var recipeEventTypes = map[recipe.EventType]mixturkaGrpc.RecipeEventType{
+	recipe.EventSnapshot:    mixturkaGrpc.RecipeEventType_RECIPE_EVENT_TYPE_SNAPSHOT,
+	recipe.EventSnapshotEnd: mixturkaGrpc.RecipeEventType_RECIPE_EVENT_TYPE_SNAPSHOT_END,
+	recipe.EventCreated:     mixturkaGrpc.RecipeEventType_RECIPE_EVENT_TYPE_CREATED,
+	recipe.EventUpdated:     mixturkaGrpc.RecipeEventType_RECIPE_EVENT_TYPE_UPDATED,
+	recipe.EventDeleted:     mixturkaGrpc.RecipeEventType_RECIPE_EVENT_TYPE_DELETED,
+}
+
+func toGRPCRecipe(recipe domain.Recipe) *mixturkaGrpc.Recipe {
+	grpcRecipe := &mixturkaGrpc.Recipe{
+		Id:          recipe.ID,
+		Name:        recipe.Name,
+		Ingredients: toGRPCRecipeIngredients(recipe.Ingredients),
+	}
+	if recipe.BrewDuration > 0 {
+		grpcRecipe.BrewDuration = durationpb.New(recipe.BrewDuration)
+	}
+
+	return grpcRecipe
+}
+
+func toGRPCRecipeIngredients(ingredients []domain.Ingredient) []*mixturkaGrpc.Ingredient {
+	result := make([]*mixturkaGrpc.Ingredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		result = append(result, &mixturkaGrpc.Ingredient{
+			Id:       ingredient.ID,
+			Name:     ingredient.Name,
+			Quantity: roundQuantity(ingredient.Quantity),
+			Amount:   ingredient.Quantity,
+			Unit:     string(ingredient.Unit),
+		})
+	}
+
+	return result
+}
+
+// fromGRPCIngredients преобразует ингредиенты из gRPC в ингредиенты котла.
+// Клиенты без единиц передают только целое quantity.
+func fromGRPCIngredients(ingredients []*mixturkaGrpc.Ingredient) []brew.Ingredient {
+	result := make([]brew.Ingredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		quantity := ingredient.GetAmount()
+		if quantity == 0 {
+			quantity = float64(ingredient.GetQuantity())
+		}
+
+		result = append(result, brew.Ingredient{
+			Name:     ingredient.GetName(),
+			Quantity: quantity,
+			Unit:     units.Unit(ingredient.GetUnit()),
+		})
+	}
+
+	return result
+}
+
+func toGRPCSubstitutions(substitutions []brew.AppliedSubstitution) []*mixturkaGrpc.AppliedSubstitution {
+	result := make([]*mixturkaGrpc.AppliedSubstitution, 0, len(substitutions))
+	for _, substitution := range substitutions {
+		result = append(result, &mixturkaGrpc.AppliedSubstitution{
+			Ingredient:         substitution.Ingredient,
+			Substitute:         substitution.Substitute,
+			Quantity:           roundQuantity(substitution.Quantity),
+			SubstituteQuantity: roundQuantity(substitution.SubstituteQuantity),
+			Amount:             substitution.Quantity,
+			SubstituteAmount:   substitution.SubstituteQuantity,
+			Unit:               string(substitution.Unit),
+			SubstituteUnit:     string(substitution.SubstituteUnit),
+		})
+	}
+
+	return result
+}
+
+func toGRPCCorrections(corrections []brew.Correction) []*mixturkaGrpc.IngredientCorrection {
+	result := make([]*mixturkaGrpc.IngredientCorrection, 0, len(corrections))
+	for _, correction := range corrections {
+		result = append(result, &mixturkaGrpc.IngredientCorrection{
+			Name:      correction.Name,
+			Corrected: correction.Corrected,
+			Score:     correction.Score,
+		})
+	}
+
+	return result
+}
+
+func toGRPCIngredients(ingredients []brew.Ingredient) []*mixturkaGrpc.Ingredient {
+	result := make([]*mixturkaGrpc.Ingredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		result = append(result, &mixturkaGrpc.Ingredient{
+			Name:     ingredient.Name,
+			Quantity: roundQuantity(ingredient.Quantity),
+			Amount:   ingredient.Quantity,
+			Unit:     string(ingredient.Unit),
+		})
+	}
+
+	return result
+}
+
+// roundQuantity целое количество для клиентов, не знающих о дробных amount.
+func roundQuantity(quantity float64) int32 {
+	return int32(math.Round(quantity))
 }
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/vostelmakh/mixturka/internal/infrastructure/grpc/interceptors"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/proxy"
+)
+
+func TestCaller(t *testing.T) {
+	trusted, err := proxy.Parse([]string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	client := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.5"), Port: 51234}}
+	proxyPeer := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 443}}
+
+	tests := []struct {
+		name     string
+		peer     *peer.Peer
+		md       metadata.MD
+		identity string
+		expected string
+	}{
+		{
+			name:     "поддельный x-caller от клиента не учитывается",
+			peer:     client,
+			md:       metadata.Pairs("x-caller", "admin", "x-forwarded-for", "198.51.100.1"),
+			expected: "203.0.113.5",
+		},
+		{
+			name:     "x-caller от доверенного прокси",
+			peer:     proxyPeer,
+			md:       metadata.Pairs("x-caller", "alchemist"),
+			expected: "alchemist",
+		},
+		{
+			name:     "поддельный X-Caller через HTTP-шлюз не учитывается",
+			md:       metadata.Pairs("x-caller", "admin", "x-forwarded-for", "198.51.100.1, 203.0.113.5"),
+			expected: "203.0.113.5",
+		},
+		{
+			name:     "HTTP-шлюз за доверенным прокси",
+			md:       metadata.Pairs("x-forwarded-for", "203.0.113.5, 10.0.0.1"),
+			expected: "203.0.113.5",
+		},
+		{
+			name:     "аутентифицированный вызывающий важнее метаданных",
+			peer:     proxyPeer,
+			md:       metadata.Pairs("x-caller", "alchemist"),
+			identity: "service-a",
+			expected: "service-a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			if tt.peer != nil {
+				ctx = peer.NewContext(ctx, tt.peer)
+			}
+			if tt.identity != "" {
+				ctx = interceptors.WithIdentity(ctx, tt.identity)
+			}
+
+			// Act
+			result := caller(ctx, trusted)
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
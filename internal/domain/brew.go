@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// BrewState этап жизненного цикла варки.
+type BrewState string
+
+const (
+	BrewQueued    BrewState = "queued"
+	BrewBrewing   BrewState = "brewing"
+	BrewFinished  BrewState = "finished"
+	BrewFailed    BrewState = "failed"
+	BrewCancelled BrewState = "cancelled"
+)
+
+// brewTransitions допустимые переходы: queued → brewing → finished, из незавершённых
+// состояний варка может провалиться или быть отменена.
+var brewTransitions = map[BrewState][]BrewState{
+	BrewQueued:  {BrewBrewing, BrewFailed, BrewCancelled},
+	BrewBrewing: {BrewFinished, BrewFailed, BrewCancelled},
+}
+
+// Terminal сообщает, что варка завершена и больше не меняется.
+func (s BrewState) Terminal() bool {
+	_, ok := brewTransitions[s]
+	return !ok
+}
+
+func (s BrewState) CanTransitionTo(next BrewState) bool {
+	for _, state := range brewTransitions[s] {
+		if state == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Valid сообщает, что s одно из известных состояний.
+func (s BrewState) Valid() bool {
+	switch s {
+	case BrewQueued, BrewBrewing, BrewFinished, BrewFailed, BrewCancelled:
+		return true
+	}
+
+	return false
+}
+
+// Brew варка рецепта от постановки в очередь до завершения.
+type Brew struct {
+	ID         string
+	RecipeID   int64
+	RecipeName string
+	State      BrewState
+	// Duration сколько длится варка после начала.
+	Duration time.Duration
+	// Consumed ингредиенты, израсходованные на рецепт, Leftovers — оставшиеся в котле.
+	Consumed  []Ingredient
+	Leftovers []Ingredient
+	// Error причина неудачи для BrewFailed.
+	Error string
+	// CancelledBy и CancelReason кто и почему отменил варку BrewCancelled со слов клиента.
+	CancelledBy  string
+	CancelReason string
+	// CancelledByCaller кто отменил варку по данным транспорта: результат аутентификации,
+	// автор от доверенного прокси или адрес клиента.
+	CancelledByCaller string
+	// IngredientsReturned ингредиенты Consumed вернулись при отмене, иначе они потеряны.
+	IngredientsReturned bool
+	CreatedAt           time.Time
+	// StartAt время запуска запланированной варки: до него варка ждёт в очереди.
+	StartAt    *time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// Transition переводит варку в состояние next, отмечая время начала и завершения.
+func (b *Brew) Transition(next BrewState, at time.Time) error {
+	if !b.State.CanTransitionTo(next) {
+		return fmt.Errorf("brew %s cannot move from %s to %s", b.ID, b.State, next)
+	}
+
+	b.State = next
+	if next == BrewBrewing {
+		b.StartedAt = &at
+	}
+	if next.Terminal() {
+		b.FinishedAt = &at
+	}
+
+	return nil
+}
+
+// Cancel отменяет незавершённую варку по запросу caller.
+func (b *Brew) Cancel(caller, by, reason string, returned bool, at time.Time) error {
+	if err := b.Transition(BrewCancelled, at); err != nil {
+		return err
+	}
+
+	b.CancelledByCaller = caller
+	b.CancelledBy = by
+	b.CancelReason = reason
+	b.IngredientsReturned = returned
+
+	return nil
+}
+
+// BrewCursor ключ последней записи предыдущей страницы: время и идентификатор.
+type BrewCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// BrewQuery выборка варок от новых к старым. Пустые States и RecipeID = 0 не ограничивают
+// выборку, Limit = 0 означает выборку без ограничения.
+type BrewQuery struct {
+	States   []BrewState
+	RecipeID int64
+	After    *BrewCursor
+	Limit    int
+}
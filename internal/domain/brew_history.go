@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// BrewOutcome итог вызова BrewPot.
+type BrewOutcome string
+
+const (
+	BrewOutcomeStarted   BrewOutcome = "started"
+	BrewOutcomeScheduled BrewOutcome = "scheduled"
+	// BrewOutcomeNoMatch ни один рецепт не подошёл к ингредиентам.
+	BrewOutcomeNoMatch BrewOutcome = "no_match"
+	// BrewOutcomeRejected запрос отклонён: ошибка валидации или нет места в очереди.
+	BrewOutcomeRejected BrewOutcome = "rejected"
+	BrewOutcomeError    BrewOutcome = "error"
+)
+
+// Valid сообщает, что o один из известных итогов.
+func (o BrewOutcome) Valid() bool {
+	switch o {
+	case BrewOutcomeStarted, BrewOutcomeScheduled, BrewOutcomeNoMatch, BrewOutcomeRejected, BrewOutcomeError:
+		return true
+	}
+
+	return false
+}
+
+// BrewRecord запись истории о вызове BrewPot.
+type BrewRecord struct {
+	ID string
+	// Caller кто вызвал BrewPot, если транспорт это знает.
+	Caller string
+	// Ingredients ингредиенты из запроса как они были переданы.
+	Ingredients []Ingredient
+	// MatchMode режим сопоставления из запроса, пустой — режим по умолчанию.
+	MatchMode  string
+	RecipeID   int64
+	RecipeName string
+	// BrewID идентификатор начатой варки, пустой, если варка не начата.
+	BrewID  string
+	Outcome BrewOutcome
+	Error   string
+	// BrewState текущее состояние начатой варки, пустое, если варки нет.
+	BrewState   BrewState
+	StartAt     *time.Time
+	RequestedAt time.Time
+}
+
+// BrewHistoryQuery выборка истории от новых записей к старым. Пустые поля не ограничивают
+// выборку, RequestedFrom включается в выборку, RequestedTo — нет.
+type BrewHistoryQuery struct {
+	RecipeID      int64
+	Outcomes      []BrewOutcome
+	RequestedFrom *time.Time
+	RequestedTo   *time.Time
+	// After ключ (RequestedAt, ID) последней записи предыдущей страницы.
+	After *BrewCursor
+	Limit int
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrew_Transition(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		from          BrewState
+		to            BrewState
+		expectedError bool
+		expectStarted bool
+		expectEnded   bool
+	}{
+		{name: "очередь → варка", from: BrewQueued, to: BrewBrewing, expectStarted: true},
+		{name: "варка → готово", from: BrewBrewing, to: BrewFinished, expectEnded: true},
+		{name: "отмена в очереди", from: BrewQueued, to: BrewCancelled, expectEnded: true},
+		{name: "ошибка во время варки", from: BrewBrewing, to: BrewFailed, expectEnded: true},
+		{name: "из очереди сразу готово", from: BrewQueued, to: BrewFinished, expectedError: true},
+		{name: "завершённая варка не меняется", from: BrewFinished, to: BrewCancelled, expectedError: true},
+		{name: "повторный старт", from: BrewBrewing, to: BrewBrewing, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			brew := Brew{ID: "b1", State: tt.from}
+
+			// Act
+			err := brew.Transition(tt.to, at)
+
+			// Assert
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Equal(t, tt.from, brew.State)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, brew.State)
+			assert.Equal(t, tt.expectStarted, brew.StartedAt != nil)
+			assert.Equal(t, tt.expectEnded, brew.FinishedAt != nil)
+		})
+	}
+}
+
+func TestBrew_Cancel(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		from          BrewState
+		expectedError bool
+	}{
+		{name: "отмена в очереди", from: BrewQueued},
+		{name: "отмена во время варки", from: BrewBrewing},
+		{name: "готовую варку не отменить", from: BrewFinished, expectedError: true},
+		{name: "повторная отмена", from: BrewCancelled, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			brew := Brew{ID: "b1", State: tt.from}
+
+			// Act
+			err := brew.Cancel("10.0.0.7", "alchemist", "не тот котёл", true, at)
+
+			// Assert
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Equal(t, tt.from, brew.State)
+				assert.Empty(t, brew.CancelledBy)
+				assert.Empty(t, brew.CancelledByCaller)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, BrewCancelled, brew.State)
+			assert.Equal(t, "alchemist", brew.CancelledBy)
+			assert.Equal(t, "10.0.0.7", brew.CancelledByCaller)
+			assert.Equal(t, "не тот котёл", brew.CancelReason)
+			assert.True(t, brew.IngredientsReturned)
+			assert.Equal(t, &at, brew.FinishedAt)
+		})
+	}
+}
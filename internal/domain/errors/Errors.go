@@ -12,6 +12,12 @@ const (
 	ResourceAlreadyExists     = "ResourceAlreadyExists"
 	alreadyExistsErrorMessage = "resource already exists"
 
+	FailedPrecondition             = "FailedPrecondition"
+	failedPreconditionErrorMessage = "operation is not allowed in the current state"
+
+	ResourceExhausted             = "ResourceExhausted"
+	resourceExhaustedErrorMessage = "resource exhausted"
+
 	RepositoryError        = "RepositoryError"
 	repositoryErrorMessage = "error in repository operation"
 
@@ -31,6 +37,28 @@ const (
 type AppError struct {
 	Err  error
 	Type string
+	// Violations поля запроса, не прошедшие валидацию.
+	Violations []FieldViolation
+	// Suggestions варианты исправления нераспознанных значений полей.
+	Suggestions []Suggestion
+}
+
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Suggestion варианты, которые, вероятно, имелись в виду вместо Value поля Field.
+type Suggestion struct {
+	Field      string
+	Value      string
+	Candidates []Candidate
+}
+
+// Candidate вариант исправления с похожестью Score от 0 до 1.
+type Candidate struct {
+	Value string
+	Score float64
 }
 
 func NewAppError(err error, errType string) *AppError {
@@ -50,6 +78,10 @@ func NewAppErrorWithType(errType string) *AppError {
 		err = errors.New(validationErrorMessage)
 	case ResourceAlreadyExists:
 		err = errors.New(alreadyExistsErrorMessage)
+	case FailedPrecondition:
+		err = errors.New(failedPreconditionErrorMessage)
+	case ResourceExhausted:
+		err = errors.New(resourceExhaustedErrorMessage)
 	case RepositoryError:
 		err = errors.New(repositoryErrorMessage)
 	case NotAuthenticated:
@@ -68,6 +100,20 @@ func NewAppErrorWithType(errType string) *AppError {
 	}
 }
 
+func NewValidationError(err error, violations ...FieldViolation) *AppError {
+	return &AppError{
+		Err:        err,
+		Type:       ValidationError,
+		Violations: violations,
+	}
+}
+
+// WithSuggestions добавляет к ошибке варианты исправления значений.
+func (appErr *AppError) WithSuggestions(suggestions ...Suggestion) *AppError {
+	appErr.Suggestions = append(appErr.Suggestions, suggestions...)
+	return appErr
+}
+
 func (appErr *AppError) Error() string {
 	return appErr.Err.Error()
 }
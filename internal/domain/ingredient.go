@@ -1,8 +1,13 @@
 package domain
 
+import "github.com/vostelmakh/mixturka/internal/domain/units"
+
 type Ingredient struct {
-	ID       int64  `db:"id"`
-	RecipeID int64  `db:"recipe_id"`
-	Name     string `db:"name"`
-	Quantity int    `db:"quantity"`
+	ID       int64      `db:"id"`
+	RecipeID int64      `db:"recipe_id"`
+	Name     string     `db:"name"`
+	Quantity float64    `db:"quantity"`
+	Unit     units.Unit `db:"unit"`
+	// CanonicalName нормализованное название с учётом синонимов, по нему рецепты ищутся по составу.
+	CanonicalName string `db:"canonical_name"`
 }
@@ -0,0 +1,162 @@
+package names
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Algorithm способ оценки похожести названий.
+type Algorithm string
+
+const (
+	// AlgorithmEditDistance доля совпадающих символов по расстоянию Дамерау — Левенштейна,
+	// хорошо ловит опечатки и переставленные буквы.
+	AlgorithmEditDistance Algorithm = "edit_distance"
+	// AlgorithmTrigram коэффициент Жаккара по триграммам, устойчив к пропущенным
+	// и лишним словам в длинных названиях.
+	AlgorithmTrigram Algorithm = "trigram"
+)
+
+// Similarity похожесть нормализованных названий a и b от 0 до 1.
+// Неизвестный алгоритм считается AlgorithmEditDistance.
+func Similarity(algorithm Algorithm, a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	if algorithm == AlgorithmTrigram {
+		return trigramSimilarity(a, b)
+	}
+
+	return editSimilarity(a, b)
+}
+
+func editSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// editDistance расстояние Дамерау — Левенштейна в варианте optimal string alignment:
+// вставка, удаление, замена и перестановка соседних символов стоят по единице.
+func editDistance(a, b []rune) int {
+	// Хранятся три последние строки матрицы: перестановка смотрит на две строки назад.
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(b)]
+}
+
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+
+	var common int
+	for trigram := range ta {
+		if _, ok := tb[trigram]; ok {
+			common++
+		}
+	}
+
+	total := len(ta) + len(tb) - common
+	if total == 0 {
+		return 1
+	}
+
+	return float64(common) / float64(total)
+}
+
+// trigrams триграммы названия, дополненного пробелами, чтобы учитывались начало и конец слова.
+func trigrams(s string) map[string]struct{} {
+	runes := []rune("  " + s + " ")
+	result := make(map[string]struct{}, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		result[string(runes[i:i+3])] = struct{}{}
+	}
+
+	return result
+}
+
+// Match известное название, похожее на искомое.
+type Match struct {
+	Name  string
+	Score float64
+}
+
+// Vocabulary известные нормализованные названия для нечёткого поиска.
+type Vocabulary struct {
+	algorithm Algorithm
+	names     []string
+	known     map[string]struct{}
+}
+
+func NewVocabulary(algorithm Algorithm, names []string) *Vocabulary {
+	v := &Vocabulary{
+		algorithm: algorithm,
+		known:     make(map[string]struct{}, len(names)),
+	}
+
+	for _, name := range names {
+		if _, ok := v.known[name]; ok {
+			continue
+		}
+
+		v.known[name] = struct{}{}
+		v.names = append(v.names, name)
+	}
+
+	return v
+}
+
+func (v *Vocabulary) Contains(name string) bool {
+	_, ok := v.known[name]
+	return ok
+}
+
+// Lookup возвращает до limit известных названий с похожестью не ниже threshold,
+// самые похожие первыми, при равной похожести — по алфавиту. limit 0 — без ограничения.
+func (v *Vocabulary) Lookup(name string, threshold float64, limit int) []Match {
+	var matches []Match
+	for _, known := range v.names {
+		if score := Similarity(v.algorithm, name, known); score >= threshold {
+			matches = append(matches, Match{Name: known, Score: score})
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b Match) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
@@ -0,0 +1,117 @@
+package names
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm Algorithm
+		a         string
+		b         string
+		expected  float64
+	}{
+		{
+			name:      "одинаковые названия",
+			algorithm: AlgorithmEditDistance,
+			a:         "крапива",
+			b:         "крапива",
+			expected:  1,
+		},
+		{
+			name:      "одна опечатка",
+			algorithm: AlgorithmEditDistance,
+			a:         "nettel",
+			b:         "nettle",
+			expected:  1 - 1.0/6,
+		},
+		{
+			name:      "пропущенная буква в кириллице",
+			algorithm: AlgorithmEditDistance,
+			a:         "крапва",
+			b:         "крапива",
+			expected:  1 - 1.0/7,
+		},
+		{
+			name:      "совсем разные названия",
+			algorithm: AlgorithmEditDistance,
+			a:         "abc",
+			b:         "xyz",
+			expected:  0,
+		},
+		{
+			name:      "триграммы без общих частей",
+			algorithm: AlgorithmTrigram,
+			a:         "abc",
+			b:         "xyz",
+			expected:  0,
+		},
+		{
+			name:      "триграммы с общим началом",
+			algorithm: AlgorithmTrigram,
+			a:         "ab",
+			b:         "abc",
+			expected:  2.0 / 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := Similarity(tt.algorithm, tt.a, tt.b)
+
+			// Assert
+			assert.InDelta(t, tt.expected, result, 1e-9)
+		})
+	}
+}
+
+func TestVocabulary_Lookup(t *testing.T) {
+	vocabulary := NewVocabulary(AlgorithmEditDistance, []string{"nettle", "kettle", "needle", "вода", "nettle"})
+
+	tests := []struct {
+		name      string
+		input     string
+		threshold float64
+		limit     int
+		expected  []string
+	}{
+		{
+			name:      "самые похожие первыми, при равенстве по алфавиту",
+			input:     "nettel",
+			threshold: 0.5,
+			limit:     0,
+			expected:  []string{"nettle", "kettle", "needle"},
+		},
+		{
+			name:      "ограничение количества",
+			input:     "nettel",
+			threshold: 0.5,
+			limit:     1,
+			expected:  []string{"nettle"},
+		},
+		{
+			name:      "ничего не похоже",
+			input:     "мандрагора",
+			threshold: 0.6,
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			matches := vocabulary.Lookup(tt.input, tt.threshold, tt.limit)
+
+			// Assert
+			var result []string
+			for _, match := range matches {
+				result = append(result, match.Name)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package names
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+)
+
+// Normalizer приводит названия ингредиентов к виду, в котором они сравниваются:
+// Unicode NFC, без различия регистра, с одиночными пробелами между словами
+// и, если включено, с кириллицей, записанной латиницей.
+type Normalizer struct {
+	transliterate bool
+}
+
+type Option func(*Normalizer)
+
+// WithTransliteration записывает кириллицу латиницей, чтобы "krapiva" и "крапива" совпадали.
+func WithTransliteration(enabled bool) Option {
+	return func(n *Normalizer) {
+		n.transliterate = enabled
+	}
+}
+
+func NewNormalizer(opts ...Option) *Normalizer {
+	n := &Normalizer{}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n
+}
+
+func (n *Normalizer) Normalize(name string) string {
+	name = norm.NFC.String(name)
+	// Caser хранит состояние и не разделяется между горутинами.
+	name = cases.Fold().String(name)
+	name = strings.Join(strings.FieldsFunc(name, unicode.IsSpace), " ")
+
+	if n.transliterate {
+		name = transliterate(name)
+	}
+
+	return name
+}
+
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+func transliterate(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	for _, r := range name {
+		if latin, ok := cyrillic[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// Dictionary сводит названия ингредиентов к каноническим с учётом синонимов.
+type Dictionary struct {
+	normalizer *Normalizer
+	synonyms   map[string]string
+}
+
+func NewDictionary(normalizer *Normalizer, synonyms []domain.Synonym) *Dictionary {
+	d := &Dictionary{
+		normalizer: normalizer,
+		synonyms:   make(map[string]string, len(synonyms)),
+	}
+
+	for _, synonym := range synonyms {
+		d.synonyms[normalizer.Normalize(synonym.Name)] = normalizer.Normalize(synonym.Canonical)
+	}
+
+	return d
+}
+
+// Canonical возвращает нормализованное название, заменённое по словарю синонимов.
+func (d *Dictionary) Canonical(name string) string {
+	name = d.normalizer.Normalize(name)
+	if canonical, ok := d.synonyms[name]; ok {
+		return canonical
+	}
+
+	return name
+}
+
+// SynonymSource хранилище словаря синонимов.
+type SynonymSource interface {
+	GetSynonyms(ctx context.Context) ([]domain.Synonym, error)
+}
+
+// LoadDictionary читает словарь синонимов из source. Без source словарь только нормализует названия.
+func LoadDictionary(ctx context.Context, normalizer *Normalizer, source SynonymSource) (*Dictionary, error) {
+	if source == nil {
+		return NewDictionary(normalizer, nil), nil
+	}
+
+	synonyms, err := source.GetSynonyms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get synonyms: %w", err)
+	}
+
+	return NewDictionary(normalizer, synonyms), nil
+}
@@ -0,0 +1,96 @@
+package names
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+)
+
+func TestNormalizer_Normalize(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		transliterate bool
+		expected      string
+	}{
+		{
+			name:     "регистр и пробелы по краям",
+			input:    " Nettle ",
+			expected: "nettle",
+		},
+		{
+			name:     "несколько пробелов между словами",
+			input:    "Корень\t мандрагоры",
+			expected: "корень мандрагоры",
+		},
+		{
+			name:     "составной символ приводится к NFC",
+			input:    "мёд",
+			expected: "мёд",
+		},
+		{
+			name:          "кириллица записывается латиницей",
+			input:         "Крапива жгучая",
+			transliterate: true,
+			expected:      "krapiva zhguchaya",
+		},
+		{
+			name:     "без транслитерации кириллица сохраняется",
+			input:    "Крапива",
+			expected: "крапива",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			normalizer := NewNormalizer(WithTransliteration(tt.transliterate))
+
+			// Act
+			result := normalizer.Normalize(tt.input)
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestDictionary_Canonical(t *testing.T) {
+	dictionary := NewDictionary(NewNormalizer(), []domain.Synonym{
+		{Name: "Крапива", Canonical: "Nettle"},
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "синоним заменяется каноническим названием",
+			input:    "крапива ",
+			expected: "nettle",
+		},
+		{
+			name:     "каноническое название нормализуется",
+			input:    "NETTLE",
+			expected: "nettle",
+		},
+		{
+			name:     "название без синонима только нормализуется",
+			input:    "Вода",
+			expected: "вода",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := dictionary.Canonical(tt.input)
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
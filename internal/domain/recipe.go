@@ -1,7 +1,11 @@
 package domain
 
+import "time"
+
 type Recipe struct {
 	ID          int64        `db:"id"`
 	Name        string       `db:"name"`
 	Ingredients []Ingredient `db:"ingredients"`
+	// BrewDuration сколько длится варка рецепта, 0 — длительность по умолчанию.
+	BrewDuration time.Duration `db:"brew_duration_ms"`
 }
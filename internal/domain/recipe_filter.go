@@ -0,0 +1,33 @@
+package domain
+
+// RecipeFilter отбирает рецепты по названиям ингредиентов. Условия объединяются через AND.
+type RecipeFilter struct {
+	AllOf  []string
+	AnyOf  []string
+	NoneOf []string
+}
+
+func (f RecipeFilter) IsEmpty() bool {
+	return len(f.AllOf) == 0 && len(f.AnyOf) == 0 && len(f.NoneOf) == 0
+}
+
+type RecipeOrder string
+
+const (
+	RecipeOrderID   RecipeOrder = "id"
+	RecipeOrderName RecipeOrder = "name"
+)
+
+// RecipeCursor последний рецепт предыдущей страницы для keyset-пагинации.
+type RecipeCursor struct {
+	ID   int64
+	Name string
+}
+
+// RecipeQuery описывает выборку рецептов. Limit = 0 означает выборку без ограничения.
+type RecipeQuery struct {
+	Filter  RecipeFilter
+	OrderBy RecipeOrder
+	After   *RecipeCursor
+	Limit   int
+}
@@ -0,0 +1,11 @@
+package domain
+
+// Substitution ингредиент Substitute может заменить Ingredient: одна единица Ingredient
+// заменяется Ratio единицами Substitute. Без RecipeID правило действует во всех рецептах.
+type Substitution struct {
+	ID         int64   `db:"id"`
+	Ingredient string  `db:"ingredient"`
+	Substitute string  `db:"substitute"`
+	Ratio      float64 `db:"ratio"`
+	RecipeID   *int64  `db:"recipe_id"`
+}
@@ -0,0 +1,8 @@
+package domain
+
+// Synonym другое название ингредиента: Name при сопоставлении заменяется на Canonical.
+type Synonym struct {
+	ID        int64  `db:"id"`
+	Name      string `db:"name"`
+	Canonical string `db:"canonical"`
+}
@@ -0,0 +1,177 @@
+package units
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Unit единица измерения количества ингредиента.
+type Unit string
+
+const (
+	Milligram  Unit = "mg"
+	Gram       Unit = "g"
+	Kilogram   Unit = "kg"
+	Millilitre Unit = "ml"
+	Litre      Unit = "l"
+	// Piece штуки, пучки, капли — всё, что считается поштучно. Единица по умолчанию.
+	Piece Unit = "pcs"
+)
+
+// Dimension величина, которую измеряет единица. Переводить можно только в пределах величины,
+// масса и объём переводятся друг в друга через плотность ингредиента.
+type Dimension string
+
+const (
+	Mass   Dimension = "mass"
+	Volume Dimension = "volume"
+	Count  Dimension = "count"
+)
+
+type definition struct {
+	dimension Dimension
+	// factor сколько базовых единиц величины в одной единице.
+	factor float64
+}
+
+var definitions = map[Unit]definition{
+	Milligram:  {dimension: Mass, factor: 0.001},
+	Gram:       {dimension: Mass, factor: 1},
+	Kilogram:   {dimension: Mass, factor: 1000},
+	Millilitre: {dimension: Volume, factor: 1},
+	Litre:      {dimension: Volume, factor: 1000},
+	Piece:      {dimension: Count, factor: 1},
+}
+
+// base базовая единица каждой величины.
+var base = map[Dimension]Unit{
+	Mass:   Gram,
+	Volume: Millilitre,
+	Count:  Piece,
+}
+
+// Parse приводит запись единицы к Unit. Пустая запись означает штуки: так хранятся
+// количества, заведённые до появления единиц.
+func Parse(s string) (Unit, error) {
+	unit := Unit(strings.ToLower(strings.TrimSpace(s)))
+	if unit == "" {
+		return Piece, nil
+	}
+
+	if _, ok := definitions[unit]; !ok {
+		return "", fmt.Errorf("unknown unit %q", s)
+	}
+
+	return unit, nil
+}
+
+// Registry переводит количества между единицами с учётом плотностей отдельных ингредиентов.
+type Registry struct {
+	// densities плотность ингредиента по названию, грамм на миллилитр.
+	densities map[string]float64
+}
+
+type Option func(*Registry)
+
+// WithDensities задаёт плотности ингредиентов в граммах на миллилитр.
+func WithDensities(densities map[string]float64) Option {
+	return func(r *Registry) {
+		for name, density := range densities {
+			if density > 0 {
+				r.densities[name] = density
+			}
+		}
+	}
+}
+
+func NewRegistry(opts ...Option) *Registry {
+	r := &Registry{
+		densities: make(map[string]float64),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// WithNames возвращает реестр, в котором названия ингредиентов с плотностью приведены canonical.
+// Так плотность, заданная под любым из синонимов, находит ингредиент под его каноническим названием.
+// Если плотности заданы под несколькими синонимами одного ингредиента, побеждает заданная под самим
+// каноническим названием, иначе — под первым по алфавиту синонимом.
+func (r *Registry) WithNames(canonical func(string) string) *Registry {
+	result := &Registry{
+		densities: make(map[string]float64, len(r.densities)),
+	}
+
+	configured := make([]string, 0, len(r.densities))
+	for name := range r.densities {
+		configured = append(configured, name)
+	}
+	sort.Strings(configured)
+
+	source := make(map[string]string, len(configured))
+	for _, name := range configured {
+		key := canonical(name)
+		if winner, ok := source[key]; ok && (winner == key || name != key) {
+			continue
+		}
+
+		source[key] = name
+		result.densities[key] = r.densities[name]
+	}
+
+	return result
+}
+
+// Convert переводит quantity ингредиента name из единицы from в единицу to.
+func (r *Registry) Convert(name string, quantity float64, from, to Unit) (float64, error) {
+	fromDef, ok := definitions[from]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+
+	toDef, ok := definitions[to]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+
+	amount := quantity * fromDef.factor
+	if fromDef.dimension != toDef.dimension {
+		density, ok := r.densities[name]
+		switch {
+		case ok && fromDef.dimension == Volume && toDef.dimension == Mass:
+			amount *= density
+		case ok && fromDef.dimension == Mass && toDef.dimension == Volume:
+			amount /= density
+		default:
+			return 0, fmt.Errorf("cannot convert %s of %q to %s", from, name, to)
+		}
+	}
+
+	return amount / toDef.factor, nil
+}
+
+// Normalize переводит quantity ингредиента name в базовую единицу: граммы, миллилитры или штуки.
+// Объём ингредиента с известной плотностью переводится в граммы, чтобы его можно было
+// сравнивать с количеством, указанным по массе.
+func (r *Registry) Normalize(name string, quantity float64, unit Unit) (float64, Unit, error) {
+	def, ok := definitions[unit]
+	if !ok {
+		return 0, "", fmt.Errorf("unknown unit %q", unit)
+	}
+
+	to := base[def.dimension]
+	if _, ok := r.densities[name]; ok && def.dimension == Volume {
+		to = Gram
+	}
+
+	amount, err := r.Convert(name, quantity, unit, to)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return amount, to, nil
+}
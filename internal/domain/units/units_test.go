@@ -0,0 +1,195 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Convert(t *testing.T) {
+	registry := NewRegistry(WithDensities(map[string]float64{"мёд": 1.4}))
+
+	tests := []struct {
+		name          string
+		ingredient    string
+		quantity      float64
+		from          Unit
+		to            Unit
+		expected      float64
+		expectedError bool
+	}{
+		{
+			name:       "килограммы в граммы",
+			ingredient: "мука",
+			quantity:   0.25,
+			from:       Kilogram,
+			to:         Gram,
+			expected:   250,
+		},
+		{
+			name:       "миллилитры в литры",
+			ingredient: "вода",
+			quantity:   1500,
+			from:       Millilitre,
+			to:         Litre,
+			expected:   1.5,
+		},
+		{
+			name:       "объём в массу по плотности",
+			ingredient: "мёд",
+			quantity:   0.5,
+			from:       Litre,
+			to:         Gram,
+			expected:   700,
+		},
+		{
+			name:       "масса в объём по плотности",
+			ingredient: "мёд",
+			quantity:   70,
+			from:       Gram,
+			to:         Millilitre,
+			expected:   50,
+		},
+		{
+			name:          "объём в массу без плотности",
+			ingredient:    "вода",
+			quantity:      1,
+			from:          Litre,
+			to:            Kilogram,
+			expectedError: true,
+		},
+		{
+			name:          "штуки не переводятся в граммы",
+			ingredient:    "яйцо",
+			quantity:      2,
+			from:          Piece,
+			to:            Gram,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := registry.Convert(tt.ingredient, tt.quantity, tt.from, tt.to)
+
+			// Assert
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-9)
+		})
+	}
+}
+
+func TestRegistry_Normalize(t *testing.T) {
+	registry := NewRegistry(WithDensities(map[string]float64{"мёд": 1.4}))
+
+	tests := []struct {
+		name         string
+		ingredient   string
+		quantity     float64
+		unit         Unit
+		expected     float64
+		expectedUnit Unit
+	}{
+		{name: "масса в граммы", ingredient: "мука", quantity: 1.2, unit: Kilogram, expected: 1200, expectedUnit: Gram},
+		{name: "объём в миллилитры", ingredient: "вода", quantity: 0.3, unit: Litre, expected: 300, expectedUnit: Millilitre},
+		{name: "объём с плотностью в граммы", ingredient: "мёд", quantity: 10, unit: Millilitre, expected: 14, expectedUnit: Gram},
+		{name: "штуки остаются штуками", ingredient: "яйцо", quantity: 3, unit: Piece, expected: 3, expectedUnit: Piece},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, unit, err := registry.Normalize(tt.ingredient, tt.quantity, tt.unit)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-9)
+			assert.Equal(t, tt.expectedUnit, unit)
+		})
+	}
+}
+
+func TestRegistry_WithNames(t *testing.T) {
+	synonyms := map[string]string{"honey": "мёд", "липовый мёд": "мёд", "гречишный мёд": "мёд"}
+	canonical := func(name string) string {
+		if result, ok := synonyms[name]; ok {
+			return result
+		}
+
+		return name
+	}
+
+	tests := []struct {
+		name      string
+		densities map[string]float64
+		expected  float64
+	}{
+		{
+			name:      "плотность под синонимом",
+			densities: map[string]float64{"honey": 1.4},
+			expected:  1.4,
+		},
+		{
+			name:      "плотность под каноническим названием важнее синонима",
+			densities: map[string]float64{"honey": 1.4, "мёд": 1.5},
+			expected:  1.5,
+		},
+		{
+			name:      "из синонимов побеждает первый по алфавиту",
+			densities: map[string]float64{"липовый мёд": 1.3, "honey": 1.4, "гречишный мёд": 1.5},
+			expected:  1.4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			registry := NewRegistry(WithDensities(tt.densities))
+
+			// Порядок обхода map случаен, поэтому реестр собирается несколько раз.
+			for i := 0; i < 20; i++ {
+				// Act
+				result, err := registry.WithNames(canonical).Convert("мёд", 1, Millilitre, Gram)
+
+				// Assert
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      Unit
+		expectedError bool
+	}{
+		{name: "пустая единица означает штуки", input: "", expected: Piece},
+		{name: "регистр и пробелы не важны", input: " KG ", expected: Kilogram},
+		{name: "неизвестная единица", input: "пучок", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := Parse(tt.input)
+
+			// Assert
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vostelmakh/mixturka/internal/application/processor/brew"
+	"github.com/vostelmakh/mixturka/internal/application/processor/recipe"
+	"github.com/vostelmakh/mixturka/internal/application/server"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/gateway"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/grpc/interceptors"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/health"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/jsonrpc"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/proxy"
+)
+
+type Config struct {
+	BrewFuzzy    brew.FuzzyConfig
+	BrewMatch    brew.Match
+	BrewPlanner  brew.PlannerConfig
+	BrewSessions brew.SessionConfig
+	Gateway      gateway.Config
+	GRPC         GRPC
+	Health       health.Config
+	JSONRPC      jsonrpc.Config
+	RecipeWatch  recipe.WatchConfig
+	Server       server.Config
+	// UnitDensities плотности ингредиентов в граммах на миллилитр для перевода объёма в массу.
+	UnitDensities map[string]float64
+	// NameTransliteration сравнивает названия ингредиентов, записанные кириллицей и латиницей.
+	NameTransliteration bool
+	// TrustedProxies прокси, которым разрешено сообщать автора вызова и адрес клиента.
+	TrustedProxies proxy.Trusted
+	// MaxBodySize ограничивает размер тела HTTP-запроса в байтах, больший запрос отклоняется с 413.
+	MaxBodySize int64
+}
+
+type GRPC struct {
+	// Reflection включает grpc.reflection для отладки через grpcurl.
+	Reflection bool
+	// DrainDelay время между переводом health-статуса в NOT_SERVING и остановкой сервера.
+	DrainDelay   time.Duration
+	Interceptors interceptors.Config
+}
+
+// Load читает конфигурацию сервиса из переменных окружения.
+func Load() Config {
+	tolerancePercent := getEnvAsInt("BREW_MATCH_TOLERANCE_PERCENT", 10)
+	trustedProxies := getEnvAsTrusted("TRUSTED_PROXIES", nil)
+
+	return Config{
+		BrewFuzzy: brew.FuzzyConfig{
+			Algorithm:            names.Algorithm(getEnv("BREW_FUZZY_ALGORITHM", string(names.AlgorithmEditDistance))),
+			Threshold:            getEnvAsFloat("BREW_FUZZY_THRESHOLD", 0.6),
+			MaxSuggestions:       getEnvAsInt("BREW_FUZZY_MAX_SUGGESTIONS", 3),
+			AutoCorrect:          getEnvAsBool("BREW_FUZZY_AUTO_CORRECT", false),
+			AutoCorrectThreshold: getEnvAsFloat("BREW_FUZZY_AUTO_CORRECT_THRESHOLD", 0.85),
+		},
+		BrewMatch: brew.Match{
+			Mode:             brew.MatchMode(getEnv("BREW_MATCH_MODE", string(brew.MatchSuperset))),
+			TolerancePercent: &tolerancePercent,
+		},
+		BrewPlanner: brew.PlannerConfig{
+			TimeBudget:      getEnvAsDuration("BREW_PLANNER_TIME_BUDGET", 2*time.Second),
+			ExactMaxRecipes: getEnvAsInt("BREW_PLANNER_EXACT_MAX_RECIPES", 20),
+		},
+		BrewSessions: brew.SessionConfig{
+			DefaultDuration:  getEnvAsDuration("BREW_DEFAULT_DURATION", 30*time.Second),
+			CancelPolicy:     brew.CancelPolicy(getEnv("BREW_CANCEL_POLICY", string(brew.CancelReturnQueued))),
+			MaxScheduleAhead: getEnvAsDuration("BREW_SCHEDULE_MAX_AHEAD", 7*24*time.Hour),
+			Cauldrons:        getEnvAsInt("BREW_CAULDRONS", 4),
+			QueueSize:        getEnvAsInt("BREW_QUEUE_SIZE", 100),
+		},
+		Gateway: gateway.Config{
+			Prefix:          getEnv("HTTP_GATEWAY_PREFIX", "/gateway"),
+			EmitUnpopulated: getEnvAsBool("HTTP_GATEWAY_EMIT_UNPOPULATED", true),
+			UseProtoNames:   getEnvAsBool("HTTP_GATEWAY_USE_PROTO_NAMES", true),
+		},
+		GRPC: GRPC{
+			Reflection: getEnvAsBool("GRPC_REFLECTION", false),
+			DrainDelay: getEnvAsDuration("GRPC_DRAIN_DELAY", 5*time.Second),
+			Interceptors: interceptors.Config{
+				MaxDeadline: getEnvAsDuration("GRPC_MAX_DEADLINE", 30*time.Second),
+				AccessLog:   getEnvAsBool("GRPC_ACCESS_LOG", true),
+				AuthToken:   getEnv("GRPC_AUTH_TOKEN", ""),
+			},
+		},
+		Health: health.Config{
+			Interval: getEnvAsDuration("HEALTH_CHECK_INTERVAL", 10*time.Second),
+			Timeout:  getEnvAsDuration("HEALTH_CHECK_TIMEOUT", 3*time.Second),
+		},
+		JSONRPC: jsonrpc.Config{
+			MaxBatchSize:  getEnvAsInt("JSONRPC_MAX_BATCH_SIZE", 100),
+			MaxBodySize:   int64(getEnvAsInt("JSONRPC_MAX_BODY_SIZE", 1<<20)),
+			PreserveOrder: getEnvAsBool("JSONRPC_PRESERVE_ORDER", true),
+		},
+		RecipeWatch: recipe.WatchConfig{
+			BufferSize:  getEnvAsInt("RECIPE_WATCH_BUFFER_SIZE", 64),
+			HistorySize: getEnvAsInt("RECIPE_WATCH_HISTORY_SIZE", 1024),
+		},
+		Server: server.Config{
+			LegacyBrewErrors: getEnvAsBool("GRPC_LEGACY_BREW_ERRORS", false),
+			TrustedProxies:   trustedProxies,
+		},
+		UnitDensities:       getEnvAsFloatMap("UNIT_DENSITIES", map[string]float64{}),
+		NameTransliteration: getEnvAsBool("NAME_TRANSLITERATION", false),
+		TrustedProxies:      trustedProxies,
+		MaxBodySize:         int64(getEnvAsInt("HTTP_MAX_BODY_SIZE", 4<<20)),
+	}
+}
+
+func getEnv(key string, defaultVal string) string {
+	if val, ok := os.LookupEnv(key); ok {
+		return val
+	}
+	return defaultVal
+}
+
+func getEnvAsInt(key string, defaultVal int) int {
+	if valStr, ok := os.LookupEnv(key); ok {
+		if val, err := strconv.Atoi(valStr); err == nil {
+			return val
+		}
+	}
+	return defaultVal
+}
+
+func getEnvAsBool(key string, defaultVal bool) bool {
+	if valStr, ok := os.LookupEnv(key); ok {
+		if val, err := strconv.ParseBool(valStr); err == nil {
+			return val
+		}
+	}
+	return defaultVal
+}
+
+func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
+	if valStr, ok := os.LookupEnv(key); ok {
+		if val, err := time.ParseDuration(valStr); err == nil {
+			return val
+		}
+	}
+	return defaultVal
+}
+
+func getEnvAsFloat(key string, defaultVal float64) float64 {
+	if valStr, ok := os.LookupEnv(key); ok {
+		if val, err := strconv.ParseFloat(valStr, 64); err == nil {
+			return val
+		}
+	}
+	return defaultVal
+}
+
+// getEnvAsFloatMap читает значения вида "мёд=1.42,масло=0.92". Если хотя бы одна пара
+// не разбирается, используется defaultVal.
+func getEnvAsFloatMap(key string, defaultVal map[string]float64) map[string]float64 {
+	valStr, ok := os.LookupEnv(key)
+	if !ok || strings.TrimSpace(valStr) == "" {
+		return defaultVal
+	}
+
+	result := make(map[string]float64)
+	for _, pair := range strings.Split(valStr, ",") {
+		name, value, found := strings.Cut(pair, "=")
+		if !found {
+			return defaultVal
+		}
+
+		val, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return defaultVal
+		}
+
+		result[strings.TrimSpace(name)] = val
+	}
+
+	return result
+}
+
+// getEnvAsTrusted читает адреса и сети прокси через запятую. Если хотя бы одно значение
+// не разбирается, используется defaultVal.
+func getEnvAsTrusted(key string, defaultVal proxy.Trusted) proxy.Trusted {
+	valStr, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultVal
+	}
+
+	trusted, err := proxy.Parse(strings.Split(valStr, ","))
+	if err != nil {
+		return defaultVal
+	}
+
+	return trusted
+}
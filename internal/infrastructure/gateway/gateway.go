@@ -0,0 +1,145 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	mixturkaGrpc "github.com/vostelmakh/mixturka/internal/infrastructure/grpc"
+)
+
+type Config struct {
+	// Prefix путь, под которым шлюз монтируется в HTTP-роутер, например "/gateway".
+	Prefix string
+	// EmitUnpopulated выводит в ответах поля со значениями по умолчанию.
+	EmitUnpopulated bool
+	// UseProtoNames использует имена полей из proto (snake_case) вместо lowerCamelCase.
+	UseProtoNames bool
+}
+
+// Gateway HTTP/JSON-шлюз к сервису Mixturka по аннотациям google.api.http из api/mixturka.proto.
+// Вызовы идут в сервер напрямую, без сетевого gRPC-соединения.
+type Gateway struct {
+	prefix  string
+	handler http.Handler
+}
+
+// NewGateway регистрирует методы server в шлюзе. Каждый вызов проходит через interceptor,
+// поэтому к нему применяются те же логирование, метрики, дедлайны и аутентификация, что и в gRPC.
+func NewGateway(ctx context.Context, server mixturkaGrpc.MixturkaServer, interceptor grpc.UnaryServerInterceptor, config Config) (*Gateway, error) {
+	marshaler := &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			EmitUnpopulated: config.EmitUnpopulated,
+			UseProtoNames:   config.UseProtoNames,
+		},
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			DiscardUnknown: true,
+		},
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, marshaler),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+	)
+
+	if interceptor != nil {
+		server = &interceptedServer{MixturkaServer: server, interceptor: interceptor}
+	}
+
+	if err := mixturkaGrpc.RegisterMixturkaHandlerServer(ctx, mux, server); err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimSuffix(config.Prefix, "/")
+
+	return &Gateway{
+		prefix:  prefix,
+		handler: http.StripPrefix(prefix, mux),
+	}, nil
+}
+
+func (g *Gateway) Prefix() string {
+	return g.prefix
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.handler.ServeHTTP(w, r)
+}
+
+// headerMatcher передаёт заголовок Authorization в метаданные как есть, чтобы его
+// проверяла та же аутентификация, что и у gRPC-вызовов, а X-Caller — чтобы доверенный
+// прокси мог сообщить автора вызова. От остальных клиентов X-Caller сервер не принимает.
+func headerMatcher(key string) (string, bool) {
+	switch {
+	case strings.EqualFold(key, "Authorization"):
+		return "authorization", true
+	case strings.EqualFold(key, "X-Caller"):
+		return "x-caller", true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// interceptedServer вызывает методы сервера через цепочку перехватчиков.
+type interceptedServer struct {
+	mixturkaGrpc.MixturkaServer
+	interceptor grpc.UnaryServerInterceptor
+}
+
+func (s *interceptedServer) GetRecipes(ctx context.Context, req *mixturkaGrpc.GetRecipesRequest) (*mixturkaGrpc.GetRecipesResponse, error) {
+	return invoke(ctx, s, mixturkaGrpc.Mixturka_GetRecipes_FullMethodName, req, s.MixturkaServer.GetRecipes)
+}
+
+func (s *interceptedServer) BrewPot(ctx context.Context, req *mixturkaGrpc.PotBrewRequest) (*mixturkaGrpc.PotBrewResponse, error) {
+	return invoke(ctx, s, mixturkaGrpc.Mixturka_BrewPot_FullMethodName, req, s.MixturkaServer.BrewPot)
+}
+
+func (s *interceptedServer) SuggestRecipes(ctx context.Context, req *mixturkaGrpc.SuggestRecipesRequest) (*mixturkaGrpc.SuggestRecipesResponse, error) {
+	return invoke(ctx, s, mixturkaGrpc.Mixturka_SuggestRecipes_FullMethodName, req, s.MixturkaServer.SuggestRecipes)
+}
+
+func (s *interceptedServer) GetShoppingList(ctx context.Context, req *mixturkaGrpc.ShoppingListRequest) (*mixturkaGrpc.ShoppingListResponse, error) {
+	return invoke(ctx, s, mixturkaGrpc.Mixturka_GetShoppingList_FullMethodName, req, s.MixturkaServer.GetShoppingList)
+}
+
+func (s *interceptedServer) CalculateYield(ctx context.Context, req *mixturkaGrpc.YieldRequest) (*mixturkaGrpc.YieldResponse, error) {
+	return invoke(ctx, s, mixturkaGrpc.Mixturka_CalculateYield_FullMethodName, req, s.MixturkaServer.CalculateYield)
+}
+
+func (s *interceptedServer) PlanProduction(ctx context.Context, req *mixturkaGrpc.PlanProductionRequest) (*mixturkaGrpc.PlanProductionResponse, error) {
+	return invoke(ctx, s, mixturkaGrpc.Mixturka_PlanProduction_FullMethodName, req, s.MixturkaServer.PlanProduction)
+}
+
+func (s *interceptedServer) GetBrewStatus(ctx context.Context, req *mixturkaGrpc.GetBrewStatusRequest) (*mixturkaGrpc.BrewSession, error) {
+	return invoke(ctx, s, mixturkaGrpc.Mixturka_GetBrewStatus_FullMethodName, req, s.MixturkaServer.GetBrewStatus)
+}
+
+func (s *interceptedServer) ListBrews(ctx context.Context, req *mixturkaGrpc.ListBrewsRequest) (*mixturkaGrpc.ListBrewsResponse, error) {
+	return invoke(ctx, s, mixturkaGrpc.Mixturka_ListBrews_FullMethodName, req, s.MixturkaServer.ListBrews)
+}
+
+func (s *interceptedServer) CancelBrew(ctx context.Context, req *mixturkaGrpc.CancelBrewRequest) (*mixturkaGrpc.BrewSession, error) {
+	return invoke(ctx, s, mixturkaGrpc.Mixturka_CancelBrew_FullMethodName, req, s.MixturkaServer.CancelBrew)
+}
+
+func (s *interceptedServer) ListBrewHistory(ctx context.Context, req *mixturkaGrpc.ListBrewHistoryRequest) (*mixturkaGrpc.ListBrewHistoryResponse, error) {
+	return invoke(ctx, s, mixturkaGrpc.Mixturka_ListBrewHistory_FullMethodName, req, s.MixturkaServer.ListBrewHistory)
+}
+
+func invoke[Req, Resp any](ctx context.Context, s *interceptedServer, fullMethod string, req Req, method func(context.Context, Req) (Resp, error)) (Resp, error) {
+	info := &grpc.UnaryServerInfo{Server: s.MixturkaServer, FullMethod: fullMethod}
+
+	resp, err := s.interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return method(ctx, req.(Req))
+	})
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+
+	return resp.(Resp), nil
+}
@@ -0,0 +1,155 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	mixturkaGrpc "github.com/vostelmakh/mixturka/internal/infrastructure/grpc"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/grpc/interceptors"
+)
+
+type stubServer struct {
+	mixturkaGrpc.UnimplementedMixturkaServer
+	getRecipesRequest *mixturkaGrpc.GetRecipesRequest
+	brewPotRequest    *mixturkaGrpc.PotBrewRequest
+	brewPotMetadata   metadata.MD
+	getBrewRequest    *mixturkaGrpc.GetBrewStatusRequest
+}
+
+func (s *stubServer) GetRecipes(ctx context.Context, req *mixturkaGrpc.GetRecipesRequest) (*mixturkaGrpc.GetRecipesResponse, error) {
+	s.getRecipesRequest = req
+	return &mixturkaGrpc.GetRecipesResponse{
+		Recipes:       []*mixturkaGrpc.Recipe{{Id: 1, Name: "Зелье", Ingredients: []*mixturkaGrpc.Ingredient{{Id: 2, Name: "Вода", Quantity: 1}}}},
+		NextPageToken: "next",
+	}, nil
+}
+
+func (s *stubServer) BrewPot(ctx context.Context, req *mixturkaGrpc.PotBrewRequest) (*mixturkaGrpc.PotBrewResponse, error) {
+	s.brewPotRequest = req
+	s.brewPotMetadata, _ = metadata.FromIncomingContext(ctx)
+	return &mixturkaGrpc.PotBrewResponse{}, nil
+}
+
+func (s *stubServer) GetBrewStatus(ctx context.Context, req *mixturkaGrpc.GetBrewStatusRequest) (*mixturkaGrpc.BrewSession, error) {
+	s.getBrewRequest = req
+	return &mixturkaGrpc.BrewSession{Id: req.GetBrewId(), State: mixturkaGrpc.BrewState_BREW_STATE_BREWING, RecipeName: "Зелье"}, nil
+}
+
+func TestGateway(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         Config
+		method         string
+		target         string
+		body           string
+		header         http.Header
+		expectedStatus int
+		expectedBody   string
+		assertServer   func(t *testing.T, server *stubServer)
+	}{
+		{
+			name:           "GET /v1/recipes с фильтром и пагинацией в query",
+			config:         Config{Prefix: "/gateway", UseProtoNames: true},
+			method:         http.MethodGet,
+			target:         "/gateway/v1/recipes?ingredients_filter.all_of=Вода&ingredients_filter.all_of=Соль&page_size=10&order_by=RECIPE_ORDER_NAME",
+			header:         http.Header{"Authorization": []string{"Bearer secret"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"recipes":[{"id":"1","name":"Зелье","ingredients":[{"id":"2","name":"Вода","quantity":1}]}],"next_page_token":"next"}`,
+			assertServer: func(t *testing.T, server *stubServer) {
+				assert.Equal(t, []string{"Вода", "Соль"}, server.getRecipesRequest.GetIngredientsFilter().GetAllOf())
+				assert.Equal(t, int32(10), server.getRecipesRequest.GetPageSize())
+				assert.Equal(t, mixturkaGrpc.RecipeOrder_RECIPE_ORDER_NAME, server.getRecipesRequest.GetOrderBy())
+			},
+		},
+		{
+			name:           "POST /v1/pot:brew с пустыми полями в ответе",
+			config:         Config{Prefix: "/gateway", EmitUnpopulated: true},
+			method:         http.MethodPost,
+			target:         "/gateway/v1/pot:brew",
+			body:           `{"ingredients":[{"name":"Вода","quantity":2}],"startAt":"2025-06-01T22:00:00Z"}`,
+			header:         http.Header{"Authorization": []string{"Bearer secret"}, "X-Caller": []string{"alchemist"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"started":false,"error":null,"brewId":"","recipeId":"0","recipeName":"","consumed":[],"leftovers":[],"substitutions":[],"corrections":[],"state":"BREW_STATE_UNSPECIFIED","startAt":null,"queuePosition":0}`,
+			assertServer: func(t *testing.T, server *stubServer) {
+				require.Len(t, server.brewPotRequest.GetIngredients(), 1)
+				assert.Equal(t, "Вода", server.brewPotRequest.GetIngredients()[0].GetName())
+				assert.Equal(t, int32(2), server.brewPotRequest.GetIngredients()[0].GetQuantity())
+				assert.Equal(t, time.Date(2025, 6, 1, 22, 0, 0, 0, time.UTC), server.brewPotRequest.GetStartAt().AsTime())
+				assert.Equal(t, []string{"alchemist"}, server.brewPotMetadata.Get("x-caller"))
+			},
+		},
+		{
+			name:           "GET /v1/brews/{brew_id} с идентификатором в пути",
+			config:         Config{Prefix: "/gateway", UseProtoNames: true},
+			method:         http.MethodGet,
+			target:         "/gateway/v1/brews/00000000-0000-0000-0000-000000000001",
+			header:         http.Header{"Authorization": []string{"Bearer secret"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","state":"BREW_STATE_BREWING","recipe_name":"Зелье"}`,
+			assertServer: func(t *testing.T, server *stubServer) {
+				assert.Equal(t, "00000000-0000-0000-0000-000000000001", server.getBrewRequest.GetBrewId())
+			},
+		},
+		{
+			name:           "вызов без токена отклоняется перехватчиком",
+			config:         Config{Prefix: "/gateway"},
+			method:         http.MethodGet,
+			target:         "/gateway/v1/recipes",
+			expectedStatus: http.StatusUnauthorized,
+			assertServer: func(t *testing.T, server *stubServer) {
+				assert.Nil(t, server.getRecipesRequest)
+			},
+		},
+		{
+			name:           "токен из заголовка Authorization",
+			config:         Config{Prefix: "/gateway"},
+			method:         http.MethodGet,
+			target:         "/gateway/v1/recipes",
+			header:         http.Header{"Authorization": []string{"Bearer secret"}},
+			expectedStatus: http.StatusOK,
+			assertServer: func(t *testing.T, server *stubServer) {
+				assert.NotNil(t, server.getRecipesRequest)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := &stubServer{}
+			interceptor := interceptors.UnaryChain(interceptors.Config{
+				AuthToken: "secret",
+				Logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+			})
+
+			gateway, err := NewGateway(context.Background(), server, interceptor, tt.config)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.header != nil {
+				req.Header = tt.header
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			gateway.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			tt.assertServer(t, server)
+		})
+	}
+}
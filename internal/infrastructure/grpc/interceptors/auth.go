@@ -0,0 +1,78 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthFunc проверяет вызов метода fullMethod. Возвращённый контекст передаётся обработчику,
+// например с данными о вызывающей стороне; ошибка прерывает вызов.
+type AuthFunc func(ctx context.Context, fullMethod string) (context.Context, error)
+
+type identityKey struct{}
+
+// WithIdentity возвращает контекст, в котором AuthFunc сообщает, кого она аутентифицировала.
+func WithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// Identity возвращает вызывающую сторону, установленную AuthFunc, или пустую строку.
+func Identity(ctx context.Context) string {
+	identity, _ := ctx.Value(identityKey{}).(string)
+
+	return identity
+}
+
+func UnaryAuth(auth AuthFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := auth(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamAuth(auth AuthFunc) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := auth(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// TokenAuth требует заголовок "authorization: Bearer <token>" у всех методов,
+// кроме health-проверок и reflection, которые нужны оркестратору и отладке.
+func TokenAuth(token string) AuthFunc {
+	return func(ctx context.Context, fullMethod string) (context.Context, error) {
+		if isPublicMethod(fullMethod) {
+			return ctx, nil
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get("authorization") {
+			bearer, ok := strings.CutPrefix(value, "Bearer ")
+			if ok && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1 {
+				return ctx, nil
+			}
+		}
+
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+	}
+}
+
+func isPublicMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") ||
+		strings.HasPrefix(fullMethod, "/grpc.reflection.")
+}
@@ -0,0 +1,122 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type Config struct {
+	// MaxDeadline ограничивает время выполнения unary-вызова на стороне сервера:
+	// более поздний дедлайн клиента или его отсутствие заменяются на этот.
+	// Потоковые вызовы не ограничиваются, так как живут столько, сколько нужно клиенту.
+	MaxDeadline time.Duration
+	// AccessLog включает структурированный лог каждого вызова.
+	AccessLog bool
+	// AuthToken включает проверку заголовка "authorization: Bearer <token>", если Auth не задан.
+	AuthToken string
+	// Auth точка расширения для аутентификации вызовов.
+	Auth AuthFunc
+	// Logger логгер для access-логов и паник, по умолчанию slog.Default().
+	Logger *slog.Logger
+}
+
+// ServerOptions собирает цепочку перехватчиков: логирование и метрики, восстановление
+// после паник, ограничение дедлайна и аутентификация.
+func ServerOptions(config Config) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors(config)...),
+		grpc.ChainStreamInterceptor(streamInterceptors(config)...),
+	}
+}
+
+// UnaryChain возвращает ту же цепочку unary-перехватчиков одним перехватчиком
+// для вызовов сервиса в обход gRPC-транспорта, например из HTTP-шлюза.
+func UnaryChain(config Config) grpc.UnaryServerInterceptor {
+	interceptors := unaryInterceptors(config)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+
+		return chained(ctx, req)
+	}
+}
+
+func unaryInterceptors(config Config) []grpc.UnaryServerInterceptor {
+	logger := loggerOf(config)
+	interceptors := []grpc.UnaryServerInterceptor{
+		UnaryObserve(logger, config.AccessLog),
+		UnaryRecovery(logger),
+	}
+
+	if config.MaxDeadline > 0 {
+		interceptors = append(interceptors, UnaryDeadline(config.MaxDeadline))
+	}
+
+	if auth := authOf(config); auth != nil {
+		interceptors = append(interceptors, UnaryAuth(auth))
+	}
+
+	return interceptors
+}
+
+func streamInterceptors(config Config) []grpc.StreamServerInterceptor {
+	logger := loggerOf(config)
+	interceptors := []grpc.StreamServerInterceptor{
+		StreamObserve(logger, config.AccessLog),
+		StreamRecovery(logger),
+	}
+
+	if auth := authOf(config); auth != nil {
+		interceptors = append(interceptors, StreamAuth(auth))
+	}
+
+	return interceptors
+}
+
+func loggerOf(config Config) *slog.Logger {
+	if config.Logger != nil {
+		return config.Logger
+	}
+
+	return slog.Default()
+}
+
+func authOf(config Config) AuthFunc {
+	if config.Auth == nil && config.AuthToken != "" {
+		return TokenAuth(config.AuthToken)
+	}
+
+	return config.Auth
+}
+
+// UnaryDeadline ограничивает дедлайн вызова значением max.
+func UnaryDeadline(max time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > max {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, max)
+			defer cancel()
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// serverStream подменяет контекст потока.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
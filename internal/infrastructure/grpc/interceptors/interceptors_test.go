@@ -0,0 +1,84 @@
+package interceptors
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryChain(t *testing.T) {
+	const method = "/mixturka.Mixturka/BrewPot"
+
+	tests := []struct {
+		name         string
+		config       Config
+		ctx          func() context.Context
+		handler      grpc.UnaryHandler
+		expectedCode codes.Code
+	}{
+		{
+			name:   "паника превращается в Internal",
+			config: Config{},
+			ctx:    context.Background,
+			handler: func(ctx context.Context, req any) (any, error) {
+				panic("котёл взорвался")
+			},
+			expectedCode: codes.Internal,
+		},
+		{
+			name:   "дедлайн ограничивается максимальным",
+			config: Config{MaxDeadline: time.Minute},
+			ctx:    context.Background,
+			handler: func(ctx context.Context, req any) (any, error) {
+				deadline, ok := ctx.Deadline()
+				if !ok || time.Until(deadline) > time.Minute {
+					return nil, status.Error(codes.FailedPrecondition, "deadline is not limited")
+				}
+				return "ok", nil
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:   "без токена вызов отклоняется",
+			config: Config{AuthToken: "secret"},
+			ctx:    context.Background,
+			handler: func(ctx context.Context, req any) (any, error) {
+				return "ok", nil
+			},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:   "с верным токеном вызов проходит",
+			config: Config{AuthToken: "secret"},
+			ctx: func() context.Context {
+				return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+			},
+			handler: func(ctx context.Context, req any) (any, error) {
+				return "ok", nil
+			},
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.config.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+			chain := UnaryChain(tt.config)
+
+			// Act
+			_, err := chain(tt.ctx(), nil, &grpc.UnaryServerInfo{FullMethod: method}, tt.handler)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package interceptors
+
+import (
+	"context"
+	"expvar"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Метрики публикуются через expvar (GET /debug/vars на служебном порту ADMIN_ADDR):
+// количество вызовов по методу и коду ответа и суммарная длительность вызовов метода.
+var (
+	requestsTotal   = expvar.NewMap("grpc_requests_total")
+	requestDuration = expvar.NewMap("grpc_request_duration_seconds_sum")
+)
+
+func UnaryObserve(logger *slog.Logger, accessLog bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe(ctx, logger, accessLog, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+func StreamObserve(logger *slog.Logger, accessLog bool) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(ss.Context(), logger, accessLog, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func observe(ctx context.Context, logger *slog.Logger, accessLog bool, method string, start time.Time, err error) {
+	duration := time.Since(start)
+	code := status.Code(err)
+
+	requestsTotal.Add(method+" "+code.String(), 1)
+	requestDuration.AddFloat(method, duration.Seconds())
+
+	if !accessLog {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", duration),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	logger.LogAttrs(ctx, slog.LevelInfo, "grpc request", attrs...)
+}
@@ -7,8 +7,11 @@
 package grpc
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,28 +24,2337 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Sort order of recipes
+type RecipeOrder int32
+
+const (
+	RecipeOrder_RECIPE_ORDER_UNSPECIFIED RecipeOrder = 0
+	RecipeOrder_RECIPE_ORDER_ID          RecipeOrder = 1
+	RecipeOrder_RECIPE_ORDER_NAME        RecipeOrder = 2
+)
+
+// Enum value maps for RecipeOrder.
+var (
+	RecipeOrder_name = map[int32]string{
+		0: "RECIPE_ORDER_UNSPECIFIED",
+		1: "RECIPE_ORDER_ID",
+		2: "RECIPE_ORDER_NAME",
+	}
+	RecipeOrder_value = map[string]int32{
+		"RECIPE_ORDER_UNSPECIFIED": 0,
+		"RECIPE_ORDER_ID":          1,
+		"RECIPE_ORDER_NAME":        2,
+	}
+)
+
+func (x RecipeOrder) Enum() *RecipeOrder {
+	p := new(RecipeOrder)
+	*p = x
+	return p
+}
+
+func (x RecipeOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecipeOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_mixturka_proto_enumTypes[0].Descriptor()
+}
+
+func (RecipeOrder) Type() protoreflect.EnumType {
+	return &file_mixturka_proto_enumTypes[0]
+}
+
+func (x RecipeOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecipeOrder.Descriptor instead.
+func (RecipeOrder) EnumDescriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{0}
+}
+
+// Kind of catalog change
+type RecipeEventType int32
+
+const (
+	RecipeEventType_RECIPE_EVENT_TYPE_UNSPECIFIED  RecipeEventType = 0
+	RecipeEventType_RECIPE_EVENT_TYPE_SNAPSHOT     RecipeEventType = 1 // Recipe of the current catalog
+	RecipeEventType_RECIPE_EVENT_TYPE_SNAPSHOT_END RecipeEventType = 2 // Current catalog is sent, live changes follow
+	RecipeEventType_RECIPE_EVENT_TYPE_CREATED      RecipeEventType = 3
+	RecipeEventType_RECIPE_EVENT_TYPE_UPDATED      RecipeEventType = 4
+	RecipeEventType_RECIPE_EVENT_TYPE_DELETED      RecipeEventType = 5
+)
+
+// Enum value maps for RecipeEventType.
+var (
+	RecipeEventType_name = map[int32]string{
+		0: "RECIPE_EVENT_TYPE_UNSPECIFIED",
+		1: "RECIPE_EVENT_TYPE_SNAPSHOT",
+		2: "RECIPE_EVENT_TYPE_SNAPSHOT_END",
+		3: "RECIPE_EVENT_TYPE_CREATED",
+		4: "RECIPE_EVENT_TYPE_UPDATED",
+		5: "RECIPE_EVENT_TYPE_DELETED",
+	}
+	RecipeEventType_value = map[string]int32{
+		"RECIPE_EVENT_TYPE_UNSPECIFIED":  0,
+		"RECIPE_EVENT_TYPE_SNAPSHOT":     1,
+		"RECIPE_EVENT_TYPE_SNAPSHOT_END": 2,
+		"RECIPE_EVENT_TYPE_CREATED":      3,
+		"RECIPE_EVENT_TYPE_UPDATED":      4,
+		"RECIPE_EVENT_TYPE_DELETED":      5,
+	}
+)
+
+func (x RecipeEventType) Enum() *RecipeEventType {
+	p := new(RecipeEventType)
+	*p = x
+	return p
+}
+
+func (x RecipeEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecipeEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_mixturka_proto_enumTypes[1].Descriptor()
+}
+
+func (RecipeEventType) Type() protoreflect.EnumType {
+	return &file_mixturka_proto_enumTypes[1]
+}
+
+func (x RecipeEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecipeEventType.Descriptor instead.
+func (RecipeEventType) EnumDescriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{1}
+}
+
+// Matching of the pot ingredients against a recipe
+type BrewMatchMode int32
+
+const (
+	BrewMatchMode_BREW_MATCH_MODE_UNSPECIFIED BrewMatchMode = 0
+	BrewMatchMode_BREW_MATCH_MODE_EXACT       BrewMatchMode = 1 // Pot contains exactly the recipe ingredients and quantities
+	BrewMatchMode_BREW_MATCH_MODE_SUPERSET    BrewMatchMode = 2 // Pot contains at least the recipe, the rest is left over
+	BrewMatchMode_BREW_MATCH_MODE_TOLERANCE   BrewMatchMode = 3 // Pot contains the recipe ingredients, each quantity within tolerance_percent
+	// Deprecated: Marked as deprecated in mixturka.proto.
+	BrewMatchMode_BREW_MATCH_MODE_SUBSET BrewMatchMode = 4 // Removed, rejected with INVALID_ARGUMENT; use BREW_MATCH_MODE_SUPERSET
+)
+
+// Enum value maps for BrewMatchMode.
+var (
+	BrewMatchMode_name = map[int32]string{
+		0: "BREW_MATCH_MODE_UNSPECIFIED",
+		1: "BREW_MATCH_MODE_EXACT",
+		2: "BREW_MATCH_MODE_SUPERSET",
+		3: "BREW_MATCH_MODE_TOLERANCE",
+		4: "BREW_MATCH_MODE_SUBSET",
+	}
+	BrewMatchMode_value = map[string]int32{
+		"BREW_MATCH_MODE_UNSPECIFIED": 0,
+		"BREW_MATCH_MODE_EXACT":       1,
+		"BREW_MATCH_MODE_SUPERSET":    2,
+		"BREW_MATCH_MODE_TOLERANCE":   3,
+		"BREW_MATCH_MODE_SUBSET":      4,
+	}
+)
+
+func (x BrewMatchMode) Enum() *BrewMatchMode {
+	p := new(BrewMatchMode)
+	*p = x
+	return p
+}
+
+func (x BrewMatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BrewMatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_mixturka_proto_enumTypes[2].Descriptor()
+}
+
+func (BrewMatchMode) Type() protoreflect.EnumType {
+	return &file_mixturka_proto_enumTypes[2]
+}
+
+func (x BrewMatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BrewMatchMode.Descriptor instead.
+func (BrewMatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{2}
+}
+
+// Stage of a brew lifecycle
+type BrewState int32
+
+const (
+	BrewState_BREW_STATE_UNSPECIFIED BrewState = 0
+	BrewState_BREW_STATE_QUEUED      BrewState = 1 // Waiting to start
+	BrewState_BREW_STATE_BREWING     BrewState = 2 // In the pot for the recipe brew duration
+	BrewState_BREW_STATE_FINISHED    BrewState = 3
+	BrewState_BREW_STATE_FAILED      BrewState = 4 // See error
+	BrewState_BREW_STATE_CANCELLED   BrewState = 5
+)
+
+// Enum value maps for BrewState.
+var (
+	BrewState_name = map[int32]string{
+		0: "BREW_STATE_UNSPECIFIED",
+		1: "BREW_STATE_QUEUED",
+		2: "BREW_STATE_BREWING",
+		3: "BREW_STATE_FINISHED",
+		4: "BREW_STATE_FAILED",
+		5: "BREW_STATE_CANCELLED",
+	}
+	BrewState_value = map[string]int32{
+		"BREW_STATE_UNSPECIFIED": 0,
+		"BREW_STATE_QUEUED":      1,
+		"BREW_STATE_BREWING":     2,
+		"BREW_STATE_FINISHED":    3,
+		"BREW_STATE_FAILED":      4,
+		"BREW_STATE_CANCELLED":   5,
+	}
+)
+
+func (x BrewState) Enum() *BrewState {
+	p := new(BrewState)
+	*p = x
+	return p
+}
+
+func (x BrewState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BrewState) Descriptor() protoreflect.EnumDescriptor {
+	return file_mixturka_proto_enumTypes[3].Descriptor()
+}
+
+func (BrewState) Type() protoreflect.EnumType {
+	return &file_mixturka_proto_enumTypes[3]
+}
+
+func (x BrewState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BrewState.Descriptor instead.
+func (BrewState) EnumDescriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{3}
+}
+
+// Result of a BrewPot call
+type BrewOutcome int32
+
+const (
+	BrewOutcome_BREW_OUTCOME_UNSPECIFIED BrewOutcome = 0
+	BrewOutcome_BREW_OUTCOME_STARTED     BrewOutcome = 1
+	BrewOutcome_BREW_OUTCOME_SCHEDULED   BrewOutcome = 2 // Brew was scheduled for start_at
+	BrewOutcome_BREW_OUTCOME_NO_MATCH    BrewOutcome = 3 // No recipe matched the ingredients
+	BrewOutcome_BREW_OUTCOME_REJECTED    BrewOutcome = 4 // Invalid request or the brew queue was full, see error
+	BrewOutcome_BREW_OUTCOME_ERROR       BrewOutcome = 5 // See error
+)
+
+// Enum value maps for BrewOutcome.
+var (
+	BrewOutcome_name = map[int32]string{
+		0: "BREW_OUTCOME_UNSPECIFIED",
+		1: "BREW_OUTCOME_STARTED",
+		2: "BREW_OUTCOME_SCHEDULED",
+		3: "BREW_OUTCOME_NO_MATCH",
+		4: "BREW_OUTCOME_REJECTED",
+		5: "BREW_OUTCOME_ERROR",
+	}
+	BrewOutcome_value = map[string]int32{
+		"BREW_OUTCOME_UNSPECIFIED": 0,
+		"BREW_OUTCOME_STARTED":     1,
+		"BREW_OUTCOME_SCHEDULED":   2,
+		"BREW_OUTCOME_NO_MATCH":    3,
+		"BREW_OUTCOME_REJECTED":    4,
+		"BREW_OUTCOME_ERROR":       5,
+	}
+)
+
+func (x BrewOutcome) Enum() *BrewOutcome {
+	p := new(BrewOutcome)
+	*p = x
+	return p
+}
+
+func (x BrewOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BrewOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_mixturka_proto_enumTypes[4].Descriptor()
+}
+
+func (BrewOutcome) Type() protoreflect.EnumType {
+	return &file_mixturka_proto_enumTypes[4]
+}
+
+func (x BrewOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BrewOutcome.Descriptor instead.
+func (BrewOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{4}
+}
+
 // Request to get recipes
 type GetRecipesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	IngredientsFilter *IngredientsFilter     `protobuf:"bytes,1,opt,name=ingredients_filter,json=ingredientsFilter,proto3" json:"ingredients_filter,omitempty"` // Filter recipes by the ingredients they contain
+	PageSize          int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                           // Maximum number of recipes to return, 100 by default and 1000 at most
+	PageToken         string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                         // next_page_token from the previous response
+	OrderBy           RecipeOrder            `protobuf:"varint,4,opt,name=order_by,json=orderBy,proto3,enum=mixturka.RecipeOrder" json:"order_by,omitempty"`    // Sort order, by id if unspecified
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetRecipesRequest) Reset() {
+	*x = GetRecipesRequest{}
+	mi := &file_mixturka_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecipesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecipesRequest) ProtoMessage() {}
+
+func (x *GetRecipesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecipesRequest.ProtoReflect.Descriptor instead.
+func (*GetRecipesRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetRecipesRequest) GetIngredientsFilter() *IngredientsFilter {
+	if x != nil {
+		return x.IngredientsFilter
+	}
+	return nil
+}
+
+func (x *GetRecipesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetRecipesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetRecipesRequest) GetOrderBy() RecipeOrder {
+	if x != nil {
+		return x.OrderBy
+	}
+	return RecipeOrder_RECIPE_ORDER_UNSPECIFIED
+}
+
+// Filter recipes by ingredient names, all conditions are combined with AND
+type IngredientsFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AllOf         []string               `protobuf:"bytes,1,rep,name=all_of,json=allOf,proto3" json:"all_of,omitempty"`    // Recipe contains all of these ingredients
+	AnyOf         []string               `protobuf:"bytes,2,rep,name=any_of,json=anyOf,proto3" json:"any_of,omitempty"`    // Recipe contains at least one of these ingredients
+	NoneOf        []string               `protobuf:"bytes,3,rep,name=none_of,json=noneOf,proto3" json:"none_of,omitempty"` // Recipe contains none of these ingredients
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngredientsFilter) Reset() {
+	*x = IngredientsFilter{}
+	mi := &file_mixturka_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngredientsFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngredientsFilter) ProtoMessage() {}
+
+func (x *IngredientsFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngredientsFilter.ProtoReflect.Descriptor instead.
+func (*IngredientsFilter) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IngredientsFilter) GetAllOf() []string {
+	if x != nil {
+		return x.AllOf
+	}
+	return nil
+}
+
+func (x *IngredientsFilter) GetAnyOf() []string {
+	if x != nil {
+		return x.AnyOf
+	}
+	return nil
+}
+
+func (x *IngredientsFilter) GetNoneOf() []string {
+	if x != nil {
+		return x.NoneOf
+	}
+	return nil
+}
+
+// Response for getting recipes
+type GetRecipesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipes       []*Recipe              `protobuf:"bytes,1,rep,name=recipes,proto3" json:"recipes,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token of the next page, empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecipesResponse) Reset() {
+	*x = GetRecipesResponse{}
+	mi := &file_mixturka_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecipesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecipesResponse) ProtoMessage() {}
+
+func (x *GetRecipesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecipesResponse.ProtoReflect.Descriptor instead.
+func (*GetRecipesResponse) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRecipesResponse) GetRecipes() []*Recipe {
+	if x != nil {
+		return x.Recipes
+	}
+	return nil
+}
+
+func (x *GetRecipesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request to watch catalog changes
+type WatchRecipesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Revision of the last received event to resume from, 0 to start with the current catalog.
+	// If the revision can no longer be resumed, the current catalog is sent again.
+	ResumeRevision int64 `protobuf:"varint,1,opt,name=resume_revision,json=resumeRevision,proto3" json:"resume_revision,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WatchRecipesRequest) Reset() {
+	*x = WatchRecipesRequest{}
+	mi := &file_mixturka_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRecipesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRecipesRequest) ProtoMessage() {}
+
+func (x *WatchRecipesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRecipesRequest.ProtoReflect.Descriptor instead.
+func (*WatchRecipesRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WatchRecipesRequest) GetResumeRevision() int64 {
+	if x != nil {
+		return x.ResumeRevision
+	}
+	return 0
+}
+
+// Catalog change event
+type RecipeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          RecipeEventType        `protobuf:"varint,1,opt,name=type,proto3,enum=mixturka.RecipeEventType" json:"type,omitempty"`
+	Revision      int64                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"` // Catalog revision, pass it as resume_revision to continue after a reconnect
+	Recipe        *Recipe                `protobuf:"bytes,3,opt,name=recipe,proto3" json:"recipe,omitempty"`      // Only id is set for deleted recipes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeEvent) Reset() {
+	*x = RecipeEvent{}
+	mi := &file_mixturka_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeEvent) ProtoMessage() {}
+
+func (x *RecipeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeEvent.ProtoReflect.Descriptor instead.
+func (*RecipeEvent) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecipeEvent) GetType() RecipeEventType {
+	if x != nil {
+		return x.Type
+	}
+	return RecipeEventType_RECIPE_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *RecipeEvent) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RecipeEvent) GetRecipe() *Recipe {
+	if x != nil {
+		return x.Recipe
+	}
+	return nil
+}
+
+// Recipe definition
+type Recipe struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Ingredients   []*Ingredient          `protobuf:"bytes,3,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
+	BrewDuration  *durationpb.Duration   `protobuf:"bytes,4,opt,name=brew_duration,json=brewDuration,proto3" json:"brew_duration,omitempty"` // How long a brew of the recipe takes, server default if unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recipe) Reset() {
+	*x = Recipe{}
+	mi := &file_mixturka_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recipe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recipe) ProtoMessage() {}
+
+func (x *Recipe) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recipe.ProtoReflect.Descriptor instead.
+func (*Recipe) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Recipe) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Recipe) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Recipe) GetIngredients() []*Ingredient {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+func (x *Recipe) GetBrewDuration() *durationpb.Duration {
+	if x != nil {
+		return x.BrewDuration
+	}
+	return nil
+}
+
+// Ingredient definition
+type Ingredient struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"` // Whole quantity for clients without units, rounded amount in responses
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`    // Quantity in unit, may be fractional; quantity is used if 0
+	Unit          string                 `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`          // mg, g, kg, ml, l or pcs, pcs if empty. Amounts in brew responses are in g, ml or pcs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ingredient) Reset() {
+	*x = Ingredient{}
+	mi := &file_mixturka_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ingredient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ingredient) ProtoMessage() {}
+
+func (x *Ingredient) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ingredient.ProtoReflect.Descriptor instead.
+func (*Ingredient) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Ingredient) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Ingredient) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Ingredient) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Ingredient) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Ingredient) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// Request to start brewing
+type PotBrewRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Ingredients      []*Ingredient          `protobuf:"bytes,1,rep,name=ingredients,proto3" json:"ingredients,omitempty"`                                           // List of ingredients for brewing
+	MatchMode        BrewMatchMode          `protobuf:"varint,2,opt,name=match_mode,json=matchMode,proto3,enum=mixturka.BrewMatchMode" json:"match_mode,omitempty"` // How ingredients are matched against recipes, server default if unspecified
+	TolerancePercent *uint32                `protobuf:"varint,3,opt,name=tolerance_percent,json=tolerancePercent,proto3,oneof" json:"tolerance_percent,omitempty"`  // Allowed per-ingredient deviation for BREW_MATCH_MODE_TOLERANCE, server default if unset
+	StartAt          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`                                    // Start the brew at this time instead of now, the recipe must match at scheduling time
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PotBrewRequest) Reset() {
+	*x = PotBrewRequest{}
+	mi := &file_mixturka_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PotBrewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PotBrewRequest) ProtoMessage() {}
+
+func (x *PotBrewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PotBrewRequest.ProtoReflect.Descriptor instead.
+func (*PotBrewRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PotBrewRequest) GetIngredients() []*Ingredient {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+func (x *PotBrewRequest) GetMatchMode() BrewMatchMode {
+	if x != nil {
+		return x.MatchMode
+	}
+	return BrewMatchMode_BREW_MATCH_MODE_UNSPECIFIED
+}
+
+func (x *PotBrewRequest) GetTolerancePercent() uint32 {
+	if x != nil && x.TolerancePercent != nil {
+		return *x.TolerancePercent
+	}
+	return 0
+}
+
+func (x *PotBrewRequest) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+// Request to suggest recipes
+type SuggestRecipesRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Ingredients      []*Ingredient          `protobuf:"bytes,1,rep,name=ingredients,proto3" json:"ingredients,omitempty"`                                           // Ingredients at hand
+	MatchMode        BrewMatchMode          `protobuf:"varint,2,opt,name=match_mode,json=matchMode,proto3,enum=mixturka.BrewMatchMode" json:"match_mode,omitempty"` // When a recipe counts as brewable, server default if unspecified
+	TolerancePercent *uint32                `protobuf:"varint,3,opt,name=tolerance_percent,json=tolerancePercent,proto3,oneof" json:"tolerance_percent,omitempty"`  // Allowed per-ingredient deviation for BREW_MATCH_MODE_TOLERANCE, server default if unset
+	Limit            int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                                                      // Maximum number of suggestions, all if 0
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SuggestRecipesRequest) Reset() {
+	*x = SuggestRecipesRequest{}
+	mi := &file_mixturka_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRecipesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRecipesRequest) ProtoMessage() {}
+
+func (x *SuggestRecipesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRecipesRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRecipesRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SuggestRecipesRequest) GetIngredients() []*Ingredient {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+func (x *SuggestRecipesRequest) GetMatchMode() BrewMatchMode {
+	if x != nil {
+		return x.MatchMode
+	}
+	return BrewMatchMode_BREW_MATCH_MODE_UNSPECIFIED
+}
+
+func (x *SuggestRecipesRequest) GetTolerancePercent() uint32 {
+	if x != nil && x.TolerancePercent != nil {
+		return *x.TolerancePercent
+	}
+	return 0
+}
+
+func (x *SuggestRecipesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Response with suggested recipes, brewable first, then by coverage
+type SuggestRecipesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*RecipeSuggestion    `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestRecipesResponse) Reset() {
+	*x = SuggestRecipesResponse{}
+	mi := &file_mixturka_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRecipesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRecipesResponse) ProtoMessage() {}
+
+func (x *SuggestRecipesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRecipesResponse.ProtoReflect.Descriptor instead.
+func (*SuggestRecipesResponse) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SuggestRecipesResponse) GetSuggestions() []*RecipeSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+// Recipe evaluated against the ingredients at hand
+type RecipeSuggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipe        *Recipe                `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Brewable      bool                   `protobuf:"varint,2,opt,name=brewable,proto3" json:"brewable,omitempty"`  // Recipe can be brewed according to match_mode
+	Coverage      float64                `protobuf:"fixed64,3,opt,name=coverage,proto3" json:"coverage,omitempty"` // Share of the required ingredient quantities at hand, from 0 to 1
+	Missing       []*Ingredient          `protobuf:"bytes,4,rep,name=missing,proto3" json:"missing,omitempty"`     // Quantities lacking to the recipe
+	Excess        []*Ingredient          `protobuf:"bytes,5,rep,name=excess,proto3" json:"excess,omitempty"`       // Quantities at hand beyond the recipe
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeSuggestion) Reset() {
+	*x = RecipeSuggestion{}
+	mi := &file_mixturka_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeSuggestion) ProtoMessage() {}
+
+func (x *RecipeSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeSuggestion.ProtoReflect.Descriptor instead.
+func (*RecipeSuggestion) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RecipeSuggestion) GetRecipe() *Recipe {
+	if x != nil {
+		return x.Recipe
+	}
+	return nil
+}
+
+func (x *RecipeSuggestion) GetBrewable() bool {
+	if x != nil {
+		return x.Brewable
+	}
+	return false
+}
+
+func (x *RecipeSuggestion) GetCoverage() float64 {
+	if x != nil {
+		return x.Coverage
+	}
+	return 0
+}
+
+func (x *RecipeSuggestion) GetMissing() []*Ingredient {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+func (x *RecipeSuggestion) GetExcess() []*Ingredient {
+	if x != nil {
+		return x.Excess
+	}
+	return nil
+}
+
+// Request for a shopping list
+type ShoppingListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Targets       []*ShoppingTarget      `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`         // Recipes to brew, quantities are summed into one list
+	Ingredients   []*Ingredient          `protobuf:"bytes,2,rep,name=ingredients,proto3" json:"ingredients,omitempty"` // Ingredients at hand
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingListRequest) Reset() {
+	*x = ShoppingListRequest{}
+	mi := &file_mixturka_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingListRequest) ProtoMessage() {}
+
+func (x *ShoppingListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingListRequest.ProtoReflect.Descriptor instead.
+func (*ShoppingListRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ShoppingListRequest) GetTargets() []*ShoppingTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *ShoppingListRequest) GetIngredients() []*Ingredient {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+// Recipe to brew for a shopping list
+type ShoppingTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId      int64                  `protobuf:"varint,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	Batches       int32                  `protobuf:"varint,2,opt,name=batches,proto3" json:"batches,omitempty"` // Number of batches, 1 if 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingTarget) Reset() {
+	*x = ShoppingTarget{}
+	mi := &file_mixturka_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingTarget) ProtoMessage() {}
+
+func (x *ShoppingTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingTarget.ProtoReflect.Descriptor instead.
+func (*ShoppingTarget) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ShoppingTarget) GetRecipeId() int64 {
+	if x != nil {
+		return x.RecipeId
+	}
+	return 0
+}
+
+func (x *ShoppingTarget) GetBatches() int32 {
+	if x != nil {
+		return x.Batches
+	}
+	return 0
+}
+
+// Consolidated shopping list in the order ingredients first appear in the recipes
+type ShoppingListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ShoppingItem        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingListResponse) Reset() {
+	*x = ShoppingListResponse{}
+	mi := &file_mixturka_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingListResponse) ProtoMessage() {}
+
+func (x *ShoppingListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingListResponse.ProtoReflect.Descriptor instead.
+func (*ShoppingListResponse) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ShoppingListResponse) GetItems() []*ShoppingItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Ingredient demand of the target recipes
+type ShoppingItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Needed        int32                  `protobuf:"varint,2,opt,name=needed,proto3" json:"needed,omitempty"`                                     // Rounded needed_amount
+	Have          int32                  `protobuf:"varint,3,opt,name=have,proto3" json:"have,omitempty"`                                         // Rounded have_amount
+	Missing       int32                  `protobuf:"varint,4,opt,name=missing,proto3" json:"missing,omitempty"`                                   // Rounded missing_amount
+	NeededAmount  float64                `protobuf:"fixed64,5,opt,name=needed_amount,json=neededAmount,proto3" json:"needed_amount,omitempty"`    // Amount required by all target recipes
+	HaveAmount    float64                `protobuf:"fixed64,6,opt,name=have_amount,json=haveAmount,proto3" json:"have_amount,omitempty"`          // Amount at hand
+	MissingAmount float64                `protobuf:"fixed64,7,opt,name=missing_amount,json=missingAmount,proto3" json:"missing_amount,omitempty"` // Amount to gather, 0 if there is enough
+	Unit          string                 `protobuf:"bytes,8,opt,name=unit,proto3" json:"unit,omitempty"`                                          // Unit of the amounts: g, ml or pcs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingItem) Reset() {
+	*x = ShoppingItem{}
+	mi := &file_mixturka_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingItem) ProtoMessage() {}
+
+func (x *ShoppingItem) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingItem.ProtoReflect.Descriptor instead.
+func (*ShoppingItem) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ShoppingItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShoppingItem) GetNeeded() int32 {
+	if x != nil {
+		return x.Needed
+	}
+	return 0
+}
+
+func (x *ShoppingItem) GetHave() int32 {
+	if x != nil {
+		return x.Have
+	}
+	return 0
+}
+
+func (x *ShoppingItem) GetMissing() int32 {
+	if x != nil {
+		return x.Missing
+	}
+	return 0
+}
+
+func (x *ShoppingItem) GetNeededAmount() float64 {
+	if x != nil {
+		return x.NeededAmount
+	}
+	return 0
+}
+
+func (x *ShoppingItem) GetHaveAmount() float64 {
+	if x != nil {
+		return x.HaveAmount
+	}
+	return 0
+}
+
+func (x *ShoppingItem) GetMissingAmount() float64 {
+	if x != nil {
+		return x.MissingAmount
+	}
+	return 0
+}
+
+func (x *ShoppingItem) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// Request for a yield calculation
+type YieldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId      int64                  `protobuf:"varint,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"` // Recipe to calculate, all recipes if 0
+	Ingredients   []*Ingredient          `protobuf:"bytes,2,rep,name=ingredients,proto3" json:"ingredients,omitempty"`            // Ingredient stock
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *YieldRequest) Reset() {
+	*x = YieldRequest{}
+	mi := &file_mixturka_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *YieldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*YieldRequest) ProtoMessage() {}
+
+func (x *YieldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use YieldRequest.ProtoReflect.Descriptor instead.
+func (*YieldRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *YieldRequest) GetRecipeId() int64 {
+	if x != nil {
+		return x.RecipeId
+	}
+	return 0
+}
+
+func (x *YieldRequest) GetIngredients() []*Ingredient {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+// Yield of the requested recipes
+type YieldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Yields        []*RecipeYield         `protobuf:"bytes,1,rep,name=yields,proto3" json:"yields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *YieldResponse) Reset() {
+	*x = YieldResponse{}
+	mi := &file_mixturka_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *YieldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*YieldResponse) ProtoMessage() {}
+
+func (x *YieldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use YieldResponse.ProtoReflect.Descriptor instead.
+func (*YieldResponse) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *YieldResponse) GetYields() []*RecipeYield {
+	if x != nil {
+		return x.Yields
+	}
+	return nil
+}
+
+// Maximum number of batches of a recipe
+type RecipeYield struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Recipe             *Recipe                `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Batches            int32                  `protobuf:"varint,2,opt,name=batches,proto3" json:"batches,omitempty"`                                                // Whole batches the stock allows
+	LimitingIngredient string                 `protobuf:"bytes,3,opt,name=limiting_ingredient,json=limitingIngredient,proto3" json:"limiting_ingredient,omitempty"` // Ingredient that runs out first, empty for recipes without ingredients
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RecipeYield) Reset() {
+	*x = RecipeYield{}
+	mi := &file_mixturka_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeYield) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeYield) ProtoMessage() {}
+
+func (x *RecipeYield) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeYield.ProtoReflect.Descriptor instead.
+func (*RecipeYield) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecipeYield) GetRecipe() *Recipe {
+	if x != nil {
+		return x.Recipe
+	}
+	return nil
+}
+
+func (x *RecipeYield) GetBatches() int32 {
+	if x != nil {
+		return x.Batches
+	}
+	return 0
+}
+
+func (x *RecipeYield) GetLimitingIngredient() string {
+	if x != nil {
+		return x.LimitingIngredient
+	}
+	return ""
+}
+
+// Request for a production plan
+type PlanProductionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Targets       []*PlanTarget          `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`         // Recipes to plan, all recipes with value 1 if empty
+	Ingredients   []*Ingredient          `protobuf:"bytes,2,rep,name=ingredients,proto3" json:"ingredients,omitempty"` // Ingredient stock shared by all recipes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanProductionRequest) Reset() {
+	*x = PlanProductionRequest{}
+	mi := &file_mixturka_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanProductionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanProductionRequest) ProtoMessage() {}
+
+func (x *PlanProductionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanProductionRequest.ProtoReflect.Descriptor instead.
+func (*PlanProductionRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PlanProductionRequest) GetTargets() []*PlanTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *PlanProductionRequest) GetIngredients() []*Ingredient {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+// Recipe that may be included in a production plan
+type PlanTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId      int64                  `protobuf:"varint,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`                            // Value of a single batch, 1 if 0
+	MinBatches    int32                  `protobuf:"varint,3,opt,name=min_batches,json=minBatches,proto3" json:"min_batches,omitempty"` // Batches the plan must include
+	MaxBatches    int32                  `protobuf:"varint,4,opt,name=max_batches,json=maxBatches,proto3" json:"max_batches,omitempty"` // Batches the plan may include at most, unlimited if 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanTarget) Reset() {
+	*x = PlanTarget{}
+	mi := &file_mixturka_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanTarget) ProtoMessage() {}
+
+func (x *PlanTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanTarget.ProtoReflect.Descriptor instead.
+func (*PlanTarget) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PlanTarget) GetRecipeId() int64 {
+	if x != nil {
+		return x.RecipeId
+	}
+	return 0
+}
+
+func (x *PlanTarget) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *PlanTarget) GetMinBatches() int32 {
+	if x != nil {
+		return x.MinBatches
+	}
+	return 0
+}
+
+func (x *PlanTarget) GetMaxBatches() int32 {
+	if x != nil {
+		return x.MaxBatches
+	}
+	return 0
+}
+
+// Production plan
+type PlanProductionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brews         []*PlannedBrew         `protobuf:"bytes,1,rep,name=brews,proto3" json:"brews,omitempty"` // Recipes with at least one batch, in the order of targets
+	TotalValue    float64                `protobuf:"fixed64,2,opt,name=total_value,json=totalValue,proto3" json:"total_value,omitempty"`
+	Leftovers     []*Ingredient          `protobuf:"bytes,3,rep,name=leftovers,proto3" json:"leftovers,omitempty"` // Stock left after all batches of the plan
+	Optimal       bool                   `protobuf:"varint,4,opt,name=optimal,proto3" json:"optimal,omitempty"`    // False if the plan was found heuristically or the time budget ran out
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanProductionResponse) Reset() {
+	*x = PlanProductionResponse{}
+	mi := &file_mixturka_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanProductionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanProductionResponse) ProtoMessage() {}
+
+func (x *PlanProductionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanProductionResponse.ProtoReflect.Descriptor instead.
+func (*PlanProductionResponse) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PlanProductionResponse) GetBrews() []*PlannedBrew {
+	if x != nil {
+		return x.Brews
+	}
+	return nil
+}
+
+func (x *PlanProductionResponse) GetTotalValue() float64 {
+	if x != nil {
+		return x.TotalValue
+	}
+	return 0
+}
+
+func (x *PlanProductionResponse) GetLeftovers() []*Ingredient {
+	if x != nil {
+		return x.Leftovers
+	}
+	return nil
+}
+
+func (x *PlanProductionResponse) GetOptimal() bool {
+	if x != nil {
+		return x.Optimal
+	}
+	return false
+}
+
+// Batches of a recipe in a production plan
+type PlannedBrew struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipe        *Recipe                `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Batches       int32                  `protobuf:"varint,2,opt,name=batches,proto3" json:"batches,omitempty"`
+	Value         float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"` // Value of all batches of the recipe
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlannedBrew) Reset() {
+	*x = PlannedBrew{}
+	mi := &file_mixturka_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlannedBrew) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlannedBrew) ProtoMessage() {}
+
+func (x *PlannedBrew) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlannedBrew.ProtoReflect.Descriptor instead.
+func (*PlannedBrew) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PlannedBrew) GetRecipe() *Recipe {
+	if x != nil {
+		return x.Recipe
+	}
+	return nil
+}
+
+func (x *PlannedBrew) GetBatches() int32 {
+	if x != nil {
+		return x.Batches
+	}
+	return 0
+}
+
+func (x *PlannedBrew) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Response for brewing process
+type PotBrewResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Started       bool                    `protobuf:"varint,1,opt,name=started,proto3" json:"started,omitempty"`                   // Indicates if brewing started successfully
+	Error         *Error                  `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`                        // Error details, if any
+	BrewId        string                  `protobuf:"bytes,3,opt,name=brew_id,json=brewId,proto3" json:"brew_id,omitempty"`        // Identifier of the brew, empty if brewing did not start
+	RecipeId      int64                   `protobuf:"varint,4,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"` // Brewed recipe
+	RecipeName    string                  `protobuf:"bytes,5,opt,name=recipe_name,json=recipeName,proto3" json:"recipe_name,omitempty"`
+	Consumed      []*Ingredient           `protobuf:"bytes,6,rep,name=consumed,proto3" json:"consumed,omitempty"`                                  // Ingredient quantities used by the recipe
+	Leftovers     []*Ingredient           `protobuf:"bytes,7,rep,name=leftovers,proto3" json:"leftovers,omitempty"`                                // Ingredient quantities left in the pot after brewing
+	Substitutions []*AppliedSubstitution  `protobuf:"bytes,8,rep,name=substitutions,proto3" json:"substitutions,omitempty"`                        // Substitutions the recipe needed, consumed also lists the substitutes
+	Corrections   []*IngredientCorrection `protobuf:"bytes,9,rep,name=corrections,proto3" json:"corrections,omitempty"`                            // Unknown ingredients replaced by auto-correction
+	State         BrewState               `protobuf:"varint,10,opt,name=state,proto3,enum=mixturka.BrewState" json:"state,omitempty"`              // State of the started brew, follow it with GetBrewStatus
+	StartAt       *timestamppb.Timestamp  `protobuf:"bytes,11,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`                    // Start time of a scheduled brew
+	QueuePosition uint32                  `protobuf:"varint,12,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"` // Position in the queue for a free cauldron, 0 if the brew got one or is scheduled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PotBrewResponse) Reset() {
+	*x = PotBrewResponse{}
+	mi := &file_mixturka_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PotBrewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PotBrewResponse) ProtoMessage() {}
+
+func (x *PotBrewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PotBrewResponse.ProtoReflect.Descriptor instead.
+func (*PotBrewResponse) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PotBrewResponse) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+func (x *PotBrewResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *PotBrewResponse) GetBrewId() string {
+	if x != nil {
+		return x.BrewId
+	}
+	return ""
+}
+
+func (x *PotBrewResponse) GetRecipeId() int64 {
+	if x != nil {
+		return x.RecipeId
+	}
+	return 0
+}
+
+func (x *PotBrewResponse) GetRecipeName() string {
+	if x != nil {
+		return x.RecipeName
+	}
+	return ""
+}
+
+func (x *PotBrewResponse) GetConsumed() []*Ingredient {
+	if x != nil {
+		return x.Consumed
+	}
+	return nil
+}
+
+func (x *PotBrewResponse) GetLeftovers() []*Ingredient {
+	if x != nil {
+		return x.Leftovers
+	}
+	return nil
+}
+
+func (x *PotBrewResponse) GetSubstitutions() []*AppliedSubstitution {
+	if x != nil {
+		return x.Substitutions
+	}
+	return nil
+}
+
+func (x *PotBrewResponse) GetCorrections() []*IngredientCorrection {
+	if x != nil {
+		return x.Corrections
+	}
+	return nil
+}
+
+func (x *PotBrewResponse) GetState() BrewState {
+	if x != nil {
+		return x.State
+	}
+	return BrewState_BREW_STATE_UNSPECIFIED
+}
+
+func (x *PotBrewResponse) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *PotBrewResponse) GetQueuePosition() uint32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+// Brew started by BrewPot
+type BrewSession struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State               BrewState              `protobuf:"varint,2,opt,name=state,proto3,enum=mixturka.BrewState" json:"state,omitempty"`
+	RecipeId            int64                  `protobuf:"varint,3,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"` // 0 if the recipe was deleted
+	RecipeName          string                 `protobuf:"bytes,4,opt,name=recipe_name,json=recipeName,proto3" json:"recipe_name,omitempty"`
+	Consumed            []*Ingredient          `protobuf:"bytes,5,rep,name=consumed,proto3" json:"consumed,omitempty"`
+	Leftovers           []*Ingredient          `protobuf:"bytes,6,rep,name=leftovers,proto3" json:"leftovers,omitempty"`
+	Duration            *durationpb.Duration   `protobuf:"bytes,7,opt,name=duration,proto3" json:"duration,omitempty"` // How long the brew takes once started
+	Error               string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`       // Failure reason for BREW_STATE_FAILED
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt           *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`       // Unset while queued
+	FinishedAt          *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`    // Unset until the brew ends
+	CancelledBy         string                 `protobuf:"bytes,12,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"` // Who cancelled a BREW_STATE_CANCELLED brew, as stated by the client
+	CancelReason        string                 `protobuf:"bytes,13,opt,name=cancel_reason,json=cancelReason,proto3" json:"cancel_reason,omitempty"`
+	IngredientsReturned bool                   `protobuf:"varint,14,opt,name=ingredients_returned,json=ingredientsReturned,proto3" json:"ingredients_returned,omitempty"` // Consumed ingredients were returned on cancellation, lost otherwise
+	StartAt             *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`                                      // Start time of a scheduled brew, unset if it started right away
+	CancelledByCaller   string                 `protobuf:"bytes,16,opt,name=cancelled_by_caller,json=cancelledByCaller,proto3" json:"cancelled_by_caller,omitempty"`      // Caller that cancelled the brew, as identified by the transport
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BrewSession) Reset() {
+	*x = BrewSession{}
+	mi := &file_mixturka_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrewSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrewSession) ProtoMessage() {}
+
+func (x *BrewSession) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrewSession.ProtoReflect.Descriptor instead.
+func (*BrewSession) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BrewSession) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BrewSession) GetState() BrewState {
+	if x != nil {
+		return x.State
+	}
+	return BrewState_BREW_STATE_UNSPECIFIED
+}
+
+func (x *BrewSession) GetRecipeId() int64 {
+	if x != nil {
+		return x.RecipeId
+	}
+	return 0
+}
+
+func (x *BrewSession) GetRecipeName() string {
+	if x != nil {
+		return x.RecipeName
+	}
+	return ""
+}
+
+func (x *BrewSession) GetConsumed() []*Ingredient {
+	if x != nil {
+		return x.Consumed
+	}
+	return nil
+}
+
+func (x *BrewSession) GetLeftovers() []*Ingredient {
+	if x != nil {
+		return x.Leftovers
+	}
+	return nil
+}
+
+func (x *BrewSession) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *BrewSession) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BrewSession) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *BrewSession) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *BrewSession) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *BrewSession) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+func (x *BrewSession) GetCancelReason() string {
+	if x != nil {
+		return x.CancelReason
+	}
+	return ""
+}
+
+func (x *BrewSession) GetIngredientsReturned() bool {
+	if x != nil {
+		return x.IngredientsReturned
+	}
+	return false
+}
+
+func (x *BrewSession) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *BrewSession) GetCancelledByCaller() string {
+	if x != nil {
+		return x.CancelledByCaller
+	}
+	return ""
+}
+
+// Request for a brew status
+type GetBrewStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BrewId        string                 `protobuf:"bytes,1,opt,name=brew_id,json=brewId,proto3" json:"brew_id,omitempty"` // brew_id from PotBrewResponse
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBrewStatusRequest) Reset() {
+	*x = GetBrewStatusRequest{}
+	mi := &file_mixturka_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBrewStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBrewStatusRequest) ProtoMessage() {}
+
+func (x *GetBrewStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBrewStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBrewStatusRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetBrewStatusRequest) GetBrewId() string {
+	if x != nil {
+		return x.BrewId
+	}
+	return ""
+}
+
+// Request to cancel a brew
+type CancelBrewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BrewId        string                 `protobuf:"bytes,1,opt,name=brew_id,json=brewId,proto3" json:"brew_id,omitempty"`
+	CancelledBy   string                 `protobuf:"bytes,2,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"` // Who cancels the brew, defaults to the caller
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBrewRequest) Reset() {
+	*x = CancelBrewRequest{}
+	mi := &file_mixturka_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBrewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBrewRequest) ProtoMessage() {}
+
+func (x *CancelBrewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBrewRequest.ProtoReflect.Descriptor instead.
+func (*CancelBrewRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CancelBrewRequest) GetBrewId() string {
+	if x != nil {
+		return x.BrewId
+	}
+	return ""
+}
+
+func (x *CancelBrewRequest) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+func (x *CancelBrewRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Request to list brews
+type ListBrewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	States        []BrewState            `protobuf:"varint,1,rep,packed,name=states,proto3,enum=mixturka.BrewState" json:"states,omitempty"` // Brews in any of these states, all if empty
+	RecipeId      int64                  `protobuf:"varint,2,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`            // Brews of the recipe, all if 0
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`            // Maximum number of brews to return, 100 by default and 1000 at most
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`          // next_page_token from the previous response
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBrewsRequest) Reset() {
+	*x = ListBrewsRequest{}
+	mi := &file_mixturka_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBrewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBrewsRequest) ProtoMessage() {}
+
+func (x *ListBrewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBrewsRequest.ProtoReflect.Descriptor instead.
+func (*ListBrewsRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListBrewsRequest) GetStates() []BrewState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListBrewsRequest) GetRecipeId() int64 {
+	if x != nil {
+		return x.RecipeId
+	}
+	return 0
+}
+
+func (x *ListBrewsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBrewsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Page of brews, newest first
+type ListBrewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brews         []*BrewSession         `protobuf:"bytes,1,rep,name=brews,proto3" json:"brews,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token of the next page, empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBrewsResponse) Reset() {
+	*x = ListBrewsResponse{}
+	mi := &file_mixturka_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBrewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBrewsResponse) ProtoMessage() {}
+
+func (x *ListBrewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBrewsResponse.ProtoReflect.Descriptor instead.
+func (*ListBrewsResponse) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListBrewsResponse) GetBrews() []*BrewSession {
+	if x != nil {
+		return x.Brews
+	}
+	return nil
+}
+
+func (x *ListBrewsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// BrewPot call from the brew history
+type BrewRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Caller        string                 `protobuf:"bytes,2,opt,name=caller,proto3" json:"caller,omitempty"`                                                     // Who called BrewPot: x-caller metadata or the client address
+	Ingredients   []*Ingredient          `protobuf:"bytes,3,rep,name=ingredients,proto3" json:"ingredients,omitempty"`                                           // Ingredients from the request
+	MatchMode     BrewMatchMode          `protobuf:"varint,4,opt,name=match_mode,json=matchMode,proto3,enum=mixturka.BrewMatchMode" json:"match_mode,omitempty"` // Match mode from the request, unspecified if the server default was used
+	RecipeId      int64                  `protobuf:"varint,5,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`                                // Matched recipe, 0 if none or the recipe was deleted
+	RecipeName    string                 `protobuf:"bytes,6,opt,name=recipe_name,json=recipeName,proto3" json:"recipe_name,omitempty"`
+	BrewId        string                 `protobuf:"bytes,7,opt,name=brew_id,json=brewId,proto3" json:"brew_id,omitempty"` // Started brew, empty if none
+	Outcome       BrewOutcome            `protobuf:"varint,8,opt,name=outcome,proto3,enum=mixturka.BrewOutcome" json:"outcome,omitempty"`
+	Error         string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	BrewState     BrewState              `protobuf:"varint,10,opt,name=brew_state,json=brewState,proto3,enum=mixturka.BrewState" json:"brew_state,omitempty"` // Current state of the started brew
+	StartAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`                                // Requested start time of a scheduled brew
+	RequestedAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrewRecord) Reset() {
+	*x = BrewRecord{}
+	mi := &file_mixturka_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrewRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrewRecord) ProtoMessage() {}
+
+func (x *BrewRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrewRecord.ProtoReflect.Descriptor instead.
+func (*BrewRecord) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BrewRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BrewRecord) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *BrewRecord) GetIngredients() []*Ingredient {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+func (x *BrewRecord) GetMatchMode() BrewMatchMode {
+	if x != nil {
+		return x.MatchMode
+	}
+	return BrewMatchMode_BREW_MATCH_MODE_UNSPECIFIED
+}
+
+func (x *BrewRecord) GetRecipeId() int64 {
+	if x != nil {
+		return x.RecipeId
+	}
+	return 0
+}
+
+func (x *BrewRecord) GetRecipeName() string {
+	if x != nil {
+		return x.RecipeName
+	}
+	return ""
+}
+
+func (x *BrewRecord) GetBrewId() string {
+	if x != nil {
+		return x.BrewId
+	}
+	return ""
+}
+
+func (x *BrewRecord) GetOutcome() BrewOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return BrewOutcome_BREW_OUTCOME_UNSPECIFIED
+}
+
+func (x *BrewRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BrewRecord) GetBrewState() BrewState {
+	if x != nil {
+		return x.BrewState
+	}
+	return BrewState_BREW_STATE_UNSPECIFIED
+}
+
+func (x *BrewRecord) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *BrewRecord) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+// Request to list the brew history
+type ListBrewHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId      int64                  `protobuf:"varint,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`                  // Calls that matched the recipe, all if 0
+	Outcomes      []BrewOutcome          `protobuf:"varint,2,rep,packed,name=outcomes,proto3,enum=mixturka.BrewOutcome" json:"outcomes,omitempty"` // Calls with any of these outcomes, all if empty
+	RequestedFrom *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=requested_from,json=requestedFrom,proto3" json:"requested_from,omitempty"`    // Calls at or after this time
+	RequestedTo   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=requested_to,json=requestedTo,proto3" json:"requested_to,omitempty"`          // Calls before this time
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                  // Maximum number of records to return, 100 by default and 1000 at most
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                // next_page_token from the previous response
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRecipesRequest) Reset() {
-	*x = GetRecipesRequest{}
-	mi := &file_mixturka_proto_msgTypes[0]
+func (x *ListBrewHistoryRequest) Reset() {
+	*x = ListBrewHistoryRequest{}
+	mi := &file_mixturka_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRecipesRequest) String() string {
+func (x *ListBrewHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRecipesRequest) ProtoMessage() {}
+func (*ListBrewHistoryRequest) ProtoMessage() {}
 
-func (x *GetRecipesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mixturka_proto_msgTypes[0]
+func (x *ListBrewHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -53,34 +2365,77 @@ func (x *GetRecipesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRecipesRequest.ProtoReflect.Descriptor instead.
-func (*GetRecipesRequest) Descriptor() ([]byte, []int) {
-	return file_mixturka_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ListBrewHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListBrewHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{29}
 }
 
-// Response for getting recipes
-type GetRecipesResponse struct {
+func (x *ListBrewHistoryRequest) GetRecipeId() int64 {
+	if x != nil {
+		return x.RecipeId
+	}
+	return 0
+}
+
+func (x *ListBrewHistoryRequest) GetOutcomes() []BrewOutcome {
+	if x != nil {
+		return x.Outcomes
+	}
+	return nil
+}
+
+func (x *ListBrewHistoryRequest) GetRequestedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedFrom
+	}
+	return nil
+}
+
+func (x *ListBrewHistoryRequest) GetRequestedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedTo
+	}
+	return nil
+}
+
+func (x *ListBrewHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBrewHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Page of the brew history, newest first
+type ListBrewHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Recipes       []*Recipe              `protobuf:"bytes,1,rep,name=recipes,proto3" json:"recipes,omitempty"`
+	Records       []*BrewRecord          `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token of the next page, empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRecipesResponse) Reset() {
-	*x = GetRecipesResponse{}
-	mi := &file_mixturka_proto_msgTypes[1]
+func (x *ListBrewHistoryResponse) Reset() {
+	*x = ListBrewHistoryResponse{}
+	mi := &file_mixturka_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRecipesResponse) String() string {
+func (x *ListBrewHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRecipesResponse) ProtoMessage() {}
+func (*ListBrewHistoryResponse) ProtoMessage() {}
 
-func (x *GetRecipesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mixturka_proto_msgTypes[1]
+func (x *ListBrewHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -91,43 +2446,50 @@ func (x *GetRecipesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRecipesResponse.ProtoReflect.Descriptor instead.
-func (*GetRecipesResponse) Descriptor() ([]byte, []int) {
-	return file_mixturka_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListBrewHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListBrewHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *GetRecipesResponse) GetRecipes() []*Recipe {
+func (x *ListBrewHistoryResponse) GetRecords() []*BrewRecord {
 	if x != nil {
-		return x.Recipes
+		return x.Records
 	}
 	return nil
 }
 
-// Recipe definition
-type Recipe struct {
+func (x *ListBrewHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Unknown ingredient replaced by a known one
+type IngredientCorrection struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Ingredients   []*Ingredient          `protobuf:"bytes,3,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`           // Ingredient name from the request
+	Corrected     string                 `protobuf:"bytes,2,opt,name=corrected,proto3" json:"corrected,omitempty"` // Known ingredient used instead
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`       // Similarity from 0 to 1
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Recipe) Reset() {
-	*x = Recipe{}
-	mi := &file_mixturka_proto_msgTypes[2]
+func (x *IngredientCorrection) Reset() {
+	*x = IngredientCorrection{}
+	mi := &file_mixturka_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Recipe) String() string {
+func (x *IngredientCorrection) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Recipe) ProtoMessage() {}
+func (*IngredientCorrection) ProtoMessage() {}
 
-func (x *Recipe) ProtoReflect() protoreflect.Message {
-	mi := &file_mixturka_proto_msgTypes[2]
+func (x *IngredientCorrection) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -138,57 +2500,62 @@ func (x *Recipe) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Recipe.ProtoReflect.Descriptor instead.
-func (*Recipe) Descriptor() ([]byte, []int) {
-	return file_mixturka_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use IngredientCorrection.ProtoReflect.Descriptor instead.
+func (*IngredientCorrection) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *Recipe) GetId() int64 {
+func (x *IngredientCorrection) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *Recipe) GetName() string {
+func (x *IngredientCorrection) GetCorrected() string {
 	if x != nil {
-		return x.Name
+		return x.Corrected
 	}
 	return ""
 }
 
-func (x *Recipe) GetIngredients() []*Ingredient {
+func (x *IngredientCorrection) GetScore() float64 {
 	if x != nil {
-		return x.Ingredients
+		return x.Score
 	}
-	return nil
+	return 0
 }
 
-// Ingredient definition
-type Ingredient struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Substitution used for brewing
+type AppliedSubstitution struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Ingredient         string                 `protobuf:"bytes,1,opt,name=ingredient,proto3" json:"ingredient,omitempty"`                                            // Recipe ingredient that was lacking
+	Substitute         string                 `protobuf:"bytes,2,opt,name=substitute,proto3" json:"substitute,omitempty"`                                            // Ingredient used instead
+	Quantity           int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`                                               // Rounded amount
+	SubstituteQuantity int32                  `protobuf:"varint,4,opt,name=substitute_quantity,json=substituteQuantity,proto3" json:"substitute_quantity,omitempty"` // Rounded substitute_amount
+	Amount             float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`                                                  // Amount of the recipe ingredient covered
+	SubstituteAmount   float64                `protobuf:"fixed64,6,opt,name=substitute_amount,json=substituteAmount,proto3" json:"substitute_amount,omitempty"`      // Amount of the substitute consumed
+	Unit               string                 `protobuf:"bytes,7,opt,name=unit,proto3" json:"unit,omitempty"`                                                        // Unit of amount: g, ml or pcs
+	SubstituteUnit     string                 `protobuf:"bytes,8,opt,name=substitute_unit,json=substituteUnit,proto3" json:"substitute_unit,omitempty"`              // Unit of substitute_amount: g, ml or pcs
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *Ingredient) Reset() {
-	*x = Ingredient{}
-	mi := &file_mixturka_proto_msgTypes[3]
+func (x *AppliedSubstitution) Reset() {
+	*x = AppliedSubstitution{}
+	mi := &file_mixturka_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Ingredient) String() string {
+func (x *AppliedSubstitution) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Ingredient) ProtoMessage() {}
+func (*AppliedSubstitution) ProtoMessage() {}
 
-func (x *Ingredient) ProtoReflect() protoreflect.Message {
-	mi := &file_mixturka_proto_msgTypes[3]
+func (x *AppliedSubstitution) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -199,55 +2566,90 @@ func (x *Ingredient) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Ingredient.ProtoReflect.Descriptor instead.
-func (*Ingredient) Descriptor() ([]byte, []int) {
-	return file_mixturka_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use AppliedSubstitution.ProtoReflect.Descriptor instead.
+func (*AppliedSubstitution) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *Ingredient) GetId() int64 {
+func (x *AppliedSubstitution) GetIngredient() string {
 	if x != nil {
-		return x.Id
+		return x.Ingredient
 	}
-	return 0
+	return ""
 }
 
-func (x *Ingredient) GetName() string {
+func (x *AppliedSubstitution) GetSubstitute() string {
 	if x != nil {
-		return x.Name
+		return x.Substitute
 	}
 	return ""
 }
 
-func (x *Ingredient) GetQuantity() int32 {
+func (x *AppliedSubstitution) GetQuantity() int32 {
 	if x != nil {
 		return x.Quantity
 	}
 	return 0
 }
 
-// Request to start brewing
-type PotBrewRequest struct {
+func (x *AppliedSubstitution) GetSubstituteQuantity() int32 {
+	if x != nil {
+		return x.SubstituteQuantity
+	}
+	return 0
+}
+
+func (x *AppliedSubstitution) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AppliedSubstitution) GetSubstituteAmount() float64 {
+	if x != nil {
+		return x.SubstituteAmount
+	}
+	return 0
+}
+
+func (x *AppliedSubstitution) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *AppliedSubstitution) GetSubstituteUnit() string {
+	if x != nil {
+		return x.SubstituteUnit
+	}
+	return ""
+}
+
+// Did-you-mean corrections of unrecognised request values, sent as a google.rpc.Status detail
+type FieldSuggestions struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ingredients   []*Ingredient          `protobuf:"bytes,1,rep,name=ingredients,proto3" json:"ingredients,omitempty"` // List of ingredients for brewing
+	Suggestions   []*FieldSuggestion     `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PotBrewRequest) Reset() {
-	*x = PotBrewRequest{}
-	mi := &file_mixturka_proto_msgTypes[4]
+func (x *FieldSuggestions) Reset() {
+	*x = FieldSuggestions{}
+	mi := &file_mixturka_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PotBrewRequest) String() string {
+func (x *FieldSuggestions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PotBrewRequest) ProtoMessage() {}
+func (*FieldSuggestions) ProtoMessage() {}
 
-func (x *PotBrewRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mixturka_proto_msgTypes[4]
+func (x *FieldSuggestions) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -258,42 +2660,43 @@ func (x *PotBrewRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PotBrewRequest.ProtoReflect.Descriptor instead.
-func (*PotBrewRequest) Descriptor() ([]byte, []int) {
-	return file_mixturka_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use FieldSuggestions.ProtoReflect.Descriptor instead.
+func (*FieldSuggestions) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *PotBrewRequest) GetIngredients() []*Ingredient {
+func (x *FieldSuggestions) GetSuggestions() []*FieldSuggestion {
 	if x != nil {
-		return x.Ingredients
+		return x.Suggestions
 	}
 	return nil
 }
 
-// Response for brewing process
-type PotBrewResponse struct {
+// Probable intended values of a request field
+type FieldSuggestion struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Started       bool                   `protobuf:"varint,1,opt,name=started,proto3" json:"started,omitempty"` // Indicates if brewing started successfully
-	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`      // Error details, if any
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`           // Request field, e.g. ingredients[0].name
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`           // Value that was not recognised
+	Candidates    []*SuggestedValue      `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"` // Most similar first
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PotBrewResponse) Reset() {
-	*x = PotBrewResponse{}
-	mi := &file_mixturka_proto_msgTypes[5]
+func (x *FieldSuggestion) Reset() {
+	*x = FieldSuggestion{}
+	mi := &file_mixturka_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PotBrewResponse) String() string {
+func (x *FieldSuggestion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PotBrewResponse) ProtoMessage() {}
+func (*FieldSuggestion) ProtoMessage() {}
 
-func (x *PotBrewResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mixturka_proto_msgTypes[5]
+func (x *FieldSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -304,25 +2707,85 @@ func (x *PotBrewResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PotBrewResponse.ProtoReflect.Descriptor instead.
-func (*PotBrewResponse) Descriptor() ([]byte, []int) {
-	return file_mixturka_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use FieldSuggestion.ProtoReflect.Descriptor instead.
+func (*FieldSuggestion) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *PotBrewResponse) GetStarted() bool {
+func (x *FieldSuggestion) GetField() string {
 	if x != nil {
-		return x.Started
+		return x.Field
 	}
-	return false
+	return ""
 }
 
-func (x *PotBrewResponse) GetError() *Error {
+func (x *FieldSuggestion) GetValue() string {
 	if x != nil {
-		return x.Error
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FieldSuggestion) GetCandidates() []*SuggestedValue {
+	if x != nil {
+		return x.Candidates
 	}
 	return nil
 }
 
+// Candidate value of a request field
+type SuggestedValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // Similarity from 0 to 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestedValue) Reset() {
+	*x = SuggestedValue{}
+	mi := &file_mixturka_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestedValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestedValue) ProtoMessage() {}
+
+func (x *SuggestedValue) ProtoReflect() protoreflect.Message {
+	mi := &file_mixturka_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestedValue.ProtoReflect.Descriptor instead.
+func (*SuggestedValue) Descriptor() ([]byte, []int) {
+	return file_mixturka_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SuggestedValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SuggestedValue) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
 // Error message for gRPC responses
 type Error struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -335,7 +2798,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_mixturka_proto_msgTypes[6]
+	mi := &file_mixturka_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -347,7 +2810,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_mixturka_proto_msgTypes[6]
+	mi := &file_mixturka_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -360,7 +2823,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_mixturka_proto_rawDescGZIP(), []int{6}
+	return file_mixturka_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *Error) GetCode() int32 {
@@ -388,35 +2851,269 @@ var File_mixturka_proto protoreflect.FileDescriptor
 
 const file_mixturka_proto_rawDesc = "" +
 	"\n" +
-	"\x0emixturka.proto\x12\bmixturka\"\x13\n" +
-	"\x11GetRecipesRequest\"@\n" +
+	"\x0emixturka.proto\x12\bmixturka\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcd\x01\n" +
+	"\x11GetRecipesRequest\x12J\n" +
+	"\x12ingredients_filter\x18\x01 \x01(\v2\x1b.mixturka.IngredientsFilterR\x11ingredientsFilter\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x120\n" +
+	"\border_by\x18\x04 \x01(\x0e2\x15.mixturka.RecipeOrderR\aorderBy\"Z\n" +
+	"\x11IngredientsFilter\x12\x15\n" +
+	"\x06all_of\x18\x01 \x03(\tR\x05allOf\x12\x15\n" +
+	"\x06any_of\x18\x02 \x03(\tR\x05anyOf\x12\x17\n" +
+	"\anone_of\x18\x03 \x03(\tR\x06noneOf\"h\n" +
 	"\x12GetRecipesResponse\x12*\n" +
-	"\arecipes\x18\x01 \x03(\v2\x10.mixturka.RecipeR\arecipes\"d\n" +
+	"\arecipes\x18\x01 \x03(\v2\x10.mixturka.RecipeR\arecipes\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\">\n" +
+	"\x13WatchRecipesRequest\x12'\n" +
+	"\x0fresume_revision\x18\x01 \x01(\x03R\x0eresumeRevision\"\x82\x01\n" +
+	"\vRecipeEvent\x12-\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x19.mixturka.RecipeEventTypeR\x04type\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\x12(\n" +
+	"\x06recipe\x18\x03 \x01(\v2\x10.mixturka.RecipeR\x06recipe\"\xa4\x01\n" +
 	"\x06Recipe\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x126\n" +
-	"\vingredients\x18\x03 \x03(\v2\x14.mixturka.IngredientR\vingredients\"L\n" +
+	"\vingredients\x18\x03 \x03(\v2\x14.mixturka.IngredientR\vingredients\x12>\n" +
+	"\rbrew_duration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fbrewDuration\"x\n" +
 	"\n" +
 	"Ingredient\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x05R\bquantity\"H\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\"\xff\x01\n" +
 	"\x0ePotBrewRequest\x126\n" +
-	"\vingredients\x18\x01 \x03(\v2\x14.mixturka.IngredientR\vingredients\"R\n" +
+	"\vingredients\x18\x01 \x03(\v2\x14.mixturka.IngredientR\vingredients\x126\n" +
+	"\n" +
+	"match_mode\x18\x02 \x01(\x0e2\x17.mixturka.BrewMatchModeR\tmatchMode\x120\n" +
+	"\x11tolerance_percent\x18\x03 \x01(\rH\x00R\x10tolerancePercent\x88\x01\x01\x125\n" +
+	"\bstart_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\astartAtB\x14\n" +
+	"\x12_tolerance_percent\"\xe5\x01\n" +
+	"\x15SuggestRecipesRequest\x126\n" +
+	"\vingredients\x18\x01 \x03(\v2\x14.mixturka.IngredientR\vingredients\x126\n" +
+	"\n" +
+	"match_mode\x18\x02 \x01(\x0e2\x17.mixturka.BrewMatchModeR\tmatchMode\x120\n" +
+	"\x11tolerance_percent\x18\x03 \x01(\rH\x00R\x10tolerancePercent\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limitB\x14\n" +
+	"\x12_tolerance_percent\"V\n" +
+	"\x16SuggestRecipesResponse\x12<\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x1a.mixturka.RecipeSuggestionR\vsuggestions\"\xd2\x01\n" +
+	"\x10RecipeSuggestion\x12(\n" +
+	"\x06recipe\x18\x01 \x01(\v2\x10.mixturka.RecipeR\x06recipe\x12\x1a\n" +
+	"\bbrewable\x18\x02 \x01(\bR\bbrewable\x12\x1a\n" +
+	"\bcoverage\x18\x03 \x01(\x01R\bcoverage\x12.\n" +
+	"\amissing\x18\x04 \x03(\v2\x14.mixturka.IngredientR\amissing\x12,\n" +
+	"\x06excess\x18\x05 \x03(\v2\x14.mixturka.IngredientR\x06excess\"\x81\x01\n" +
+	"\x13ShoppingListRequest\x122\n" +
+	"\atargets\x18\x01 \x03(\v2\x18.mixturka.ShoppingTargetR\atargets\x126\n" +
+	"\vingredients\x18\x02 \x03(\v2\x14.mixturka.IngredientR\vingredients\"G\n" +
+	"\x0eShoppingTarget\x12\x1b\n" +
+	"\trecipe_id\x18\x01 \x01(\x03R\brecipeId\x12\x18\n" +
+	"\abatches\x18\x02 \x01(\x05R\abatches\"D\n" +
+	"\x14ShoppingListResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.mixturka.ShoppingItemR\x05items\"\xe9\x01\n" +
+	"\fShoppingItem\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06needed\x18\x02 \x01(\x05R\x06needed\x12\x12\n" +
+	"\x04have\x18\x03 \x01(\x05R\x04have\x12\x18\n" +
+	"\amissing\x18\x04 \x01(\x05R\amissing\x12#\n" +
+	"\rneeded_amount\x18\x05 \x01(\x01R\fneededAmount\x12\x1f\n" +
+	"\vhave_amount\x18\x06 \x01(\x01R\n" +
+	"haveAmount\x12%\n" +
+	"\x0emissing_amount\x18\a \x01(\x01R\rmissingAmount\x12\x12\n" +
+	"\x04unit\x18\b \x01(\tR\x04unit\"c\n" +
+	"\fYieldRequest\x12\x1b\n" +
+	"\trecipe_id\x18\x01 \x01(\x03R\brecipeId\x126\n" +
+	"\vingredients\x18\x02 \x03(\v2\x14.mixturka.IngredientR\vingredients\">\n" +
+	"\rYieldResponse\x12-\n" +
+	"\x06yields\x18\x01 \x03(\v2\x15.mixturka.RecipeYieldR\x06yields\"\x82\x01\n" +
+	"\vRecipeYield\x12(\n" +
+	"\x06recipe\x18\x01 \x01(\v2\x10.mixturka.RecipeR\x06recipe\x12\x18\n" +
+	"\abatches\x18\x02 \x01(\x05R\abatches\x12/\n" +
+	"\x13limiting_ingredient\x18\x03 \x01(\tR\x12limitingIngredient\"\x7f\n" +
+	"\x15PlanProductionRequest\x12.\n" +
+	"\atargets\x18\x01 \x03(\v2\x14.mixturka.PlanTargetR\atargets\x126\n" +
+	"\vingredients\x18\x02 \x03(\v2\x14.mixturka.IngredientR\vingredients\"\x81\x01\n" +
+	"\n" +
+	"PlanTarget\x12\x1b\n" +
+	"\trecipe_id\x18\x01 \x01(\x03R\brecipeId\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\x12\x1f\n" +
+	"\vmin_batches\x18\x03 \x01(\x05R\n" +
+	"minBatches\x12\x1f\n" +
+	"\vmax_batches\x18\x04 \x01(\x05R\n" +
+	"maxBatches\"\xb4\x01\n" +
+	"\x16PlanProductionResponse\x12+\n" +
+	"\x05brews\x18\x01 \x03(\v2\x15.mixturka.PlannedBrewR\x05brews\x12\x1f\n" +
+	"\vtotal_value\x18\x02 \x01(\x01R\n" +
+	"totalValue\x122\n" +
+	"\tleftovers\x18\x03 \x03(\v2\x14.mixturka.IngredientR\tleftovers\x12\x18\n" +
+	"\aoptimal\x18\x04 \x01(\bR\aoptimal\"g\n" +
+	"\vPlannedBrew\x12(\n" +
+	"\x06recipe\x18\x01 \x01(\v2\x10.mixturka.RecipeR\x06recipe\x12\x18\n" +
+	"\abatches\x18\x02 \x01(\x05R\abatches\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\"\x9f\x04\n" +
 	"\x0fPotBrewResponse\x12\x18\n" +
 	"\astarted\x18\x01 \x01(\bR\astarted\x12%\n" +
-	"\x05error\x18\x02 \x01(\v2\x0f.mixturka.ErrorR\x05error\"\x9d\x01\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.mixturka.ErrorR\x05error\x12\x17\n" +
+	"\abrew_id\x18\x03 \x01(\tR\x06brewId\x12\x1b\n" +
+	"\trecipe_id\x18\x04 \x01(\x03R\brecipeId\x12\x1f\n" +
+	"\vrecipe_name\x18\x05 \x01(\tR\n" +
+	"recipeName\x120\n" +
+	"\bconsumed\x18\x06 \x03(\v2\x14.mixturka.IngredientR\bconsumed\x122\n" +
+	"\tleftovers\x18\a \x03(\v2\x14.mixturka.IngredientR\tleftovers\x12C\n" +
+	"\rsubstitutions\x18\b \x03(\v2\x1d.mixturka.AppliedSubstitutionR\rsubstitutions\x12@\n" +
+	"\vcorrections\x18\t \x03(\v2\x1e.mixturka.IngredientCorrectionR\vcorrections\x12)\n" +
+	"\x05state\x18\n" +
+	" \x01(\x0e2\x13.mixturka.BrewStateR\x05state\x125\n" +
+	"\bstart_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x12%\n" +
+	"\x0equeue_position\x18\f \x01(\rR\rqueuePosition\"\xce\x05\n" +
+	"\vBrewSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x13.mixturka.BrewStateR\x05state\x12\x1b\n" +
+	"\trecipe_id\x18\x03 \x01(\x03R\brecipeId\x12\x1f\n" +
+	"\vrecipe_name\x18\x04 \x01(\tR\n" +
+	"recipeName\x120\n" +
+	"\bconsumed\x18\x05 \x03(\v2\x14.mixturka.IngredientR\bconsumed\x122\n" +
+	"\tleftovers\x18\x06 \x03(\v2\x14.mixturka.IngredientR\tleftovers\x125\n" +
+	"\bduration\x18\a \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12!\n" +
+	"\fcancelled_by\x18\f \x01(\tR\vcancelledBy\x12#\n" +
+	"\rcancel_reason\x18\r \x01(\tR\fcancelReason\x121\n" +
+	"\x14ingredients_returned\x18\x0e \x01(\bR\x13ingredientsReturned\x125\n" +
+	"\bstart_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x12.\n" +
+	"\x13cancelled_by_caller\x18\x10 \x01(\tR\x11cancelledByCaller\"/\n" +
+	"\x14GetBrewStatusRequest\x12\x17\n" +
+	"\abrew_id\x18\x01 \x01(\tR\x06brewId\"g\n" +
+	"\x11CancelBrewRequest\x12\x17\n" +
+	"\abrew_id\x18\x01 \x01(\tR\x06brewId\x12!\n" +
+	"\fcancelled_by\x18\x02 \x01(\tR\vcancelledBy\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x98\x01\n" +
+	"\x10ListBrewsRequest\x12+\n" +
+	"\x06states\x18\x01 \x03(\x0e2\x13.mixturka.BrewStateR\x06states\x12\x1b\n" +
+	"\trecipe_id\x18\x02 \x01(\x03R\brecipeId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"h\n" +
+	"\x11ListBrewsResponse\x12+\n" +
+	"\x05brews\x18\x01 \x03(\v2\x15.mixturka.BrewSessionR\x05brews\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xec\x03\n" +
+	"\n" +
+	"BrewRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06caller\x18\x02 \x01(\tR\x06caller\x126\n" +
+	"\vingredients\x18\x03 \x03(\v2\x14.mixturka.IngredientR\vingredients\x126\n" +
+	"\n" +
+	"match_mode\x18\x04 \x01(\x0e2\x17.mixturka.BrewMatchModeR\tmatchMode\x12\x1b\n" +
+	"\trecipe_id\x18\x05 \x01(\x03R\brecipeId\x12\x1f\n" +
+	"\vrecipe_name\x18\x06 \x01(\tR\n" +
+	"recipeName\x12\x17\n" +
+	"\abrew_id\x18\a \x01(\tR\x06brewId\x12/\n" +
+	"\aoutcome\x18\b \x01(\x0e2\x15.mixturka.BrewOutcomeR\aoutcome\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x122\n" +
+	"\n" +
+	"brew_state\x18\n" +
+	" \x01(\x0e2\x13.mixturka.BrewStateR\tbrewState\x125\n" +
+	"\bstart_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x12=\n" +
+	"\frequested_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\"\xa6\x02\n" +
+	"\x16ListBrewHistoryRequest\x12\x1b\n" +
+	"\trecipe_id\x18\x01 \x01(\x03R\brecipeId\x121\n" +
+	"\boutcomes\x18\x02 \x03(\x0e2\x15.mixturka.BrewOutcomeR\boutcomes\x12A\n" +
+	"\x0erequested_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rrequestedFrom\x12=\n" +
+	"\frequested_to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedTo\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"q\n" +
+	"\x17ListBrewHistoryResponse\x12.\n" +
+	"\arecords\x18\x01 \x03(\v2\x14.mixturka.BrewRecordR\arecords\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"^\n" +
+	"\x14IngredientCorrection\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tcorrected\x18\x02 \x01(\tR\tcorrected\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\"\xa4\x02\n" +
+	"\x13AppliedSubstitution\x12\x1e\n" +
+	"\n" +
+	"ingredient\x18\x01 \x01(\tR\n" +
+	"ingredient\x12\x1e\n" +
+	"\n" +
+	"substitute\x18\x02 \x01(\tR\n" +
+	"substitute\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12/\n" +
+	"\x13substitute_quantity\x18\x04 \x01(\x05R\x12substituteQuantity\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12+\n" +
+	"\x11substitute_amount\x18\x06 \x01(\x01R\x10substituteAmount\x12\x12\n" +
+	"\x04unit\x18\a \x01(\tR\x04unit\x12'\n" +
+	"\x0fsubstitute_unit\x18\b \x01(\tR\x0esubstituteUnit\"O\n" +
+	"\x10FieldSuggestions\x12;\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x19.mixturka.FieldSuggestionR\vsuggestions\"w\n" +
+	"\x0fFieldSuggestion\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x128\n" +
+	"\n" +
+	"candidates\x18\x03 \x03(\v2\x18.mixturka.SuggestedValueR\n" +
+	"candidates\"<\n" +
+	"\x0eSuggestedValue\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"\x9d\x01\n" +
 	"\x05Error\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12-\n" +
 	"\x04data\x18\x03 \x03(\v2\x19.mixturka.Error.DataEntryR\x04data\x1a7\n" +
 	"\tDataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\x97\x01\n" +
-	"\bMixturka\x12I\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*W\n" +
+	"\vRecipeOrder\x12\x1c\n" +
+	"\x18RECIPE_ORDER_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fRECIPE_ORDER_ID\x10\x01\x12\x15\n" +
+	"\x11RECIPE_ORDER_NAME\x10\x02*\xd5\x01\n" +
+	"\x0fRecipeEventType\x12!\n" +
+	"\x1dRECIPE_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aRECIPE_EVENT_TYPE_SNAPSHOT\x10\x01\x12\"\n" +
+	"\x1eRECIPE_EVENT_TYPE_SNAPSHOT_END\x10\x02\x12\x1d\n" +
+	"\x19RECIPE_EVENT_TYPE_CREATED\x10\x03\x12\x1d\n" +
+	"\x19RECIPE_EVENT_TYPE_UPDATED\x10\x04\x12\x1d\n" +
+	"\x19RECIPE_EVENT_TYPE_DELETED\x10\x05*\xa8\x01\n" +
+	"\rBrewMatchMode\x12\x1f\n" +
+	"\x1bBREW_MATCH_MODE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15BREW_MATCH_MODE_EXACT\x10\x01\x12\x1c\n" +
+	"\x18BREW_MATCH_MODE_SUPERSET\x10\x02\x12\x1d\n" +
+	"\x19BREW_MATCH_MODE_TOLERANCE\x10\x03\x12\x1e\n" +
+	"\x16BREW_MATCH_MODE_SUBSET\x10\x04\x1a\x02\b\x01*\xa0\x01\n" +
+	"\tBrewState\x12\x1a\n" +
+	"\x16BREW_STATE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11BREW_STATE_QUEUED\x10\x01\x12\x16\n" +
+	"\x12BREW_STATE_BREWING\x10\x02\x12\x17\n" +
+	"\x13BREW_STATE_FINISHED\x10\x03\x12\x15\n" +
+	"\x11BREW_STATE_FAILED\x10\x04\x12\x18\n" +
+	"\x14BREW_STATE_CANCELLED\x10\x05*\xaf\x01\n" +
+	"\vBrewOutcome\x12\x1c\n" +
+	"\x18BREW_OUTCOME_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14BREW_OUTCOME_STARTED\x10\x01\x12\x1a\n" +
+	"\x16BREW_OUTCOME_SCHEDULED\x10\x02\x12\x19\n" +
+	"\x15BREW_OUTCOME_NO_MATCH\x10\x03\x12\x19\n" +
+	"\x15BREW_OUTCOME_REJECTED\x10\x04\x12\x16\n" +
+	"\x12BREW_OUTCOME_ERROR\x10\x052\xef\b\n" +
+	"\bMixturka\x12\\\n" +
+	"\n" +
+	"GetRecipes\x12\x1b.mixturka.GetRecipesRequest\x1a\x1c.mixturka.GetRecipesResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/recipes\x12W\n" +
+	"\aBrewPot\x12\x18.mixturka.PotBrewRequest\x1a\x19.mixturka.PotBrewResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/pot:brew\x12s\n" +
+	"\x0eSuggestRecipes\x12\x1f.mixturka.SuggestRecipesRequest\x1a .mixturka.SuggestRecipesResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/recipes:suggest\x12n\n" +
+	"\x0fGetShoppingList\x12\x1d.mixturka.ShoppingListRequest\x1a\x1e.mixturka.ShoppingListResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/shopping-list\x12_\n" +
+	"\x0eCalculateYield\x12\x16.mixturka.YieldRequest\x1a\x17.mixturka.YieldResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/recipes:yield\x12s\n" +
+	"\x0ePlanProduction\x12\x1f.mixturka.PlanProductionRequest\x1a .mixturka.PlanProductionResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/production:plan\x12c\n" +
+	"\rGetBrewStatus\x12\x1e.mixturka.GetBrewStatusRequest\x1a\x15.mixturka.BrewSession\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/brews/{brew_id}\x12_\n" +
+	"\tListBrews\x12\x1a.mixturka.ListBrewsRequest\x1a\x1b.mixturka.ListBrewsResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/brews:list\x12g\n" +
 	"\n" +
-	"GetRecipes\x12\x1b.mixturka.GetRecipesRequest\x1a\x1c.mixturka.GetRecipesResponse\"\x00\x12@\n" +
-	"\aBrewPot\x12\x18.mixturka.PotBrewRequest\x1a\x19.mixturka.PotBrewResponse\"\x00B\x1cZ\x1a../internal/infrastructure/grpcb\x06proto3"
+	"CancelBrew\x12\x1b.mixturka.CancelBrewRequest\x1a\x15.mixturka.BrewSession\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/brews/{brew_id}:cancel\x12x\n" +
+	"\x0fListBrewHistory\x12 .mixturka.ListBrewHistoryRequest\x1a!.mixturka.ListBrewHistoryResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/brew-history:list\x12H\n" +
+	"\fWatchRecipes\x12\x1d.mixturka.WatchRecipesRequest\x1a\x15.mixturka.RecipeEvent\"\x000\x01B!Z\x1f../internal/infrastructure/grpcb\x06proto3"
 
 var (
 	file_mixturka_proto_rawDescOnce sync.Once
@@ -430,32 +3127,140 @@ func file_mixturka_proto_rawDescGZIP() []byte {
 	return file_mixturka_proto_rawDescData
 }
 
-var file_mixturka_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_mixturka_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_mixturka_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_mixturka_proto_goTypes = []any{
-	(*GetRecipesRequest)(nil),  // 0: mixturka.GetRecipesRequest
-	(*GetRecipesResponse)(nil), // 1: mixturka.GetRecipesResponse
-	(*Recipe)(nil),             // 2: mixturka.Recipe
-	(*Ingredient)(nil),         // 3: mixturka.Ingredient
-	(*PotBrewRequest)(nil),     // 4: mixturka.PotBrewRequest
-	(*PotBrewResponse)(nil),    // 5: mixturka.PotBrewResponse
-	(*Error)(nil),              // 6: mixturka.Error
-	nil,                        // 7: mixturka.Error.DataEntry
+	(RecipeOrder)(0),                // 0: mixturka.RecipeOrder
+	(RecipeEventType)(0),            // 1: mixturka.RecipeEventType
+	(BrewMatchMode)(0),              // 2: mixturka.BrewMatchMode
+	(BrewState)(0),                  // 3: mixturka.BrewState
+	(BrewOutcome)(0),                // 4: mixturka.BrewOutcome
+	(*GetRecipesRequest)(nil),       // 5: mixturka.GetRecipesRequest
+	(*IngredientsFilter)(nil),       // 6: mixturka.IngredientsFilter
+	(*GetRecipesResponse)(nil),      // 7: mixturka.GetRecipesResponse
+	(*WatchRecipesRequest)(nil),     // 8: mixturka.WatchRecipesRequest
+	(*RecipeEvent)(nil),             // 9: mixturka.RecipeEvent
+	(*Recipe)(nil),                  // 10: mixturka.Recipe
+	(*Ingredient)(nil),              // 11: mixturka.Ingredient
+	(*PotBrewRequest)(nil),          // 12: mixturka.PotBrewRequest
+	(*SuggestRecipesRequest)(nil),   // 13: mixturka.SuggestRecipesRequest
+	(*SuggestRecipesResponse)(nil),  // 14: mixturka.SuggestRecipesResponse
+	(*RecipeSuggestion)(nil),        // 15: mixturka.RecipeSuggestion
+	(*ShoppingListRequest)(nil),     // 16: mixturka.ShoppingListRequest
+	(*ShoppingTarget)(nil),          // 17: mixturka.ShoppingTarget
+	(*ShoppingListResponse)(nil),    // 18: mixturka.ShoppingListResponse
+	(*ShoppingItem)(nil),            // 19: mixturka.ShoppingItem
+	(*YieldRequest)(nil),            // 20: mixturka.YieldRequest
+	(*YieldResponse)(nil),           // 21: mixturka.YieldResponse
+	(*RecipeYield)(nil),             // 22: mixturka.RecipeYield
+	(*PlanProductionRequest)(nil),   // 23: mixturka.PlanProductionRequest
+	(*PlanTarget)(nil),              // 24: mixturka.PlanTarget
+	(*PlanProductionResponse)(nil),  // 25: mixturka.PlanProductionResponse
+	(*PlannedBrew)(nil),             // 26: mixturka.PlannedBrew
+	(*PotBrewResponse)(nil),         // 27: mixturka.PotBrewResponse
+	(*BrewSession)(nil),             // 28: mixturka.BrewSession
+	(*GetBrewStatusRequest)(nil),    // 29: mixturka.GetBrewStatusRequest
+	(*CancelBrewRequest)(nil),       // 30: mixturka.CancelBrewRequest
+	(*ListBrewsRequest)(nil),        // 31: mixturka.ListBrewsRequest
+	(*ListBrewsResponse)(nil),       // 32: mixturka.ListBrewsResponse
+	(*BrewRecord)(nil),              // 33: mixturka.BrewRecord
+	(*ListBrewHistoryRequest)(nil),  // 34: mixturka.ListBrewHistoryRequest
+	(*ListBrewHistoryResponse)(nil), // 35: mixturka.ListBrewHistoryResponse
+	(*IngredientCorrection)(nil),    // 36: mixturka.IngredientCorrection
+	(*AppliedSubstitution)(nil),     // 37: mixturka.AppliedSubstitution
+	(*FieldSuggestions)(nil),        // 38: mixturka.FieldSuggestions
+	(*FieldSuggestion)(nil),         // 39: mixturka.FieldSuggestion
+	(*SuggestedValue)(nil),          // 40: mixturka.SuggestedValue
+	(*Error)(nil),                   // 41: mixturka.Error
+	nil,                             // 42: mixturka.Error.DataEntry
+	(*durationpb.Duration)(nil),     // 43: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),   // 44: google.protobuf.Timestamp
 }
 var file_mixturka_proto_depIdxs = []int32{
-	2, // 0: mixturka.GetRecipesResponse.recipes:type_name -> mixturka.Recipe
-	3, // 1: mixturka.Recipe.ingredients:type_name -> mixturka.Ingredient
-	3, // 2: mixturka.PotBrewRequest.ingredients:type_name -> mixturka.Ingredient
-	6, // 3: mixturka.PotBrewResponse.error:type_name -> mixturka.Error
-	7, // 4: mixturka.Error.data:type_name -> mixturka.Error.DataEntry
-	0, // 5: mixturka.Mixturka.GetRecipes:input_type -> mixturka.GetRecipesRequest
-	4, // 6: mixturka.Mixturka.BrewPot:input_type -> mixturka.PotBrewRequest
-	1, // 7: mixturka.Mixturka.GetRecipes:output_type -> mixturka.GetRecipesResponse
-	5, // 8: mixturka.Mixturka.BrewPot:output_type -> mixturka.PotBrewResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	6,  // 0: mixturka.GetRecipesRequest.ingredients_filter:type_name -> mixturka.IngredientsFilter
+	0,  // 1: mixturka.GetRecipesRequest.order_by:type_name -> mixturka.RecipeOrder
+	10, // 2: mixturka.GetRecipesResponse.recipes:type_name -> mixturka.Recipe
+	1,  // 3: mixturka.RecipeEvent.type:type_name -> mixturka.RecipeEventType
+	10, // 4: mixturka.RecipeEvent.recipe:type_name -> mixturka.Recipe
+	11, // 5: mixturka.Recipe.ingredients:type_name -> mixturka.Ingredient
+	43, // 6: mixturka.Recipe.brew_duration:type_name -> google.protobuf.Duration
+	11, // 7: mixturka.PotBrewRequest.ingredients:type_name -> mixturka.Ingredient
+	2,  // 8: mixturka.PotBrewRequest.match_mode:type_name -> mixturka.BrewMatchMode
+	44, // 9: mixturka.PotBrewRequest.start_at:type_name -> google.protobuf.Timestamp
+	11, // 10: mixturka.SuggestRecipesRequest.ingredients:type_name -> mixturka.Ingredient
+	2,  // 11: mixturka.SuggestRecipesRequest.match_mode:type_name -> mixturka.BrewMatchMode
+	15, // 12: mixturka.SuggestRecipesResponse.suggestions:type_name -> mixturka.RecipeSuggestion
+	10, // 13: mixturka.RecipeSuggestion.recipe:type_name -> mixturka.Recipe
+	11, // 14: mixturka.RecipeSuggestion.missing:type_name -> mixturka.Ingredient
+	11, // 15: mixturka.RecipeSuggestion.excess:type_name -> mixturka.Ingredient
+	17, // 16: mixturka.ShoppingListRequest.targets:type_name -> mixturka.ShoppingTarget
+	11, // 17: mixturka.ShoppingListRequest.ingredients:type_name -> mixturka.Ingredient
+	19, // 18: mixturka.ShoppingListResponse.items:type_name -> mixturka.ShoppingItem
+	11, // 19: mixturka.YieldRequest.ingredients:type_name -> mixturka.Ingredient
+	22, // 20: mixturka.YieldResponse.yields:type_name -> mixturka.RecipeYield
+	10, // 21: mixturka.RecipeYield.recipe:type_name -> mixturka.Recipe
+	24, // 22: mixturka.PlanProductionRequest.targets:type_name -> mixturka.PlanTarget
+	11, // 23: mixturka.PlanProductionRequest.ingredients:type_name -> mixturka.Ingredient
+	26, // 24: mixturka.PlanProductionResponse.brews:type_name -> mixturka.PlannedBrew
+	11, // 25: mixturka.PlanProductionResponse.leftovers:type_name -> mixturka.Ingredient
+	10, // 26: mixturka.PlannedBrew.recipe:type_name -> mixturka.Recipe
+	41, // 27: mixturka.PotBrewResponse.error:type_name -> mixturka.Error
+	11, // 28: mixturka.PotBrewResponse.consumed:type_name -> mixturka.Ingredient
+	11, // 29: mixturka.PotBrewResponse.leftovers:type_name -> mixturka.Ingredient
+	37, // 30: mixturka.PotBrewResponse.substitutions:type_name -> mixturka.AppliedSubstitution
+	36, // 31: mixturka.PotBrewResponse.corrections:type_name -> mixturka.IngredientCorrection
+	3,  // 32: mixturka.PotBrewResponse.state:type_name -> mixturka.BrewState
+	44, // 33: mixturka.PotBrewResponse.start_at:type_name -> google.protobuf.Timestamp
+	3,  // 34: mixturka.BrewSession.state:type_name -> mixturka.BrewState
+	11, // 35: mixturka.BrewSession.consumed:type_name -> mixturka.Ingredient
+	11, // 36: mixturka.BrewSession.leftovers:type_name -> mixturka.Ingredient
+	43, // 37: mixturka.BrewSession.duration:type_name -> google.protobuf.Duration
+	44, // 38: mixturka.BrewSession.created_at:type_name -> google.protobuf.Timestamp
+	44, // 39: mixturka.BrewSession.started_at:type_name -> google.protobuf.Timestamp
+	44, // 40: mixturka.BrewSession.finished_at:type_name -> google.protobuf.Timestamp
+	44, // 41: mixturka.BrewSession.start_at:type_name -> google.protobuf.Timestamp
+	3,  // 42: mixturka.ListBrewsRequest.states:type_name -> mixturka.BrewState
+	28, // 43: mixturka.ListBrewsResponse.brews:type_name -> mixturka.BrewSession
+	11, // 44: mixturka.BrewRecord.ingredients:type_name -> mixturka.Ingredient
+	2,  // 45: mixturka.BrewRecord.match_mode:type_name -> mixturka.BrewMatchMode
+	4,  // 46: mixturka.BrewRecord.outcome:type_name -> mixturka.BrewOutcome
+	3,  // 47: mixturka.BrewRecord.brew_state:type_name -> mixturka.BrewState
+	44, // 48: mixturka.BrewRecord.start_at:type_name -> google.protobuf.Timestamp
+	44, // 49: mixturka.BrewRecord.requested_at:type_name -> google.protobuf.Timestamp
+	4,  // 50: mixturka.ListBrewHistoryRequest.outcomes:type_name -> mixturka.BrewOutcome
+	44, // 51: mixturka.ListBrewHistoryRequest.requested_from:type_name -> google.protobuf.Timestamp
+	44, // 52: mixturka.ListBrewHistoryRequest.requested_to:type_name -> google.protobuf.Timestamp
+	33, // 53: mixturka.ListBrewHistoryResponse.records:type_name -> mixturka.BrewRecord
+	39, // 54: mixturka.FieldSuggestions.suggestions:type_name -> mixturka.FieldSuggestion
+	40, // 55: mixturka.FieldSuggestion.candidates:type_name -> mixturka.SuggestedValue
+	42, // 56: mixturka.Error.data:type_name -> mixturka.Error.DataEntry
+	5,  // 57: mixturka.Mixturka.GetRecipes:input_type -> mixturka.GetRecipesRequest
+	12, // 58: mixturka.Mixturka.BrewPot:input_type -> mixturka.PotBrewRequest
+	13, // 59: mixturka.Mixturka.SuggestRecipes:input_type -> mixturka.SuggestRecipesRequest
+	16, // 60: mixturka.Mixturka.GetShoppingList:input_type -> mixturka.ShoppingListRequest
+	20, // 61: mixturka.Mixturka.CalculateYield:input_type -> mixturka.YieldRequest
+	23, // 62: mixturka.Mixturka.PlanProduction:input_type -> mixturka.PlanProductionRequest
+	29, // 63: mixturka.Mixturka.GetBrewStatus:input_type -> mixturka.GetBrewStatusRequest
+	31, // 64: mixturka.Mixturka.ListBrews:input_type -> mixturka.ListBrewsRequest
+	30, // 65: mixturka.Mixturka.CancelBrew:input_type -> mixturka.CancelBrewRequest
+	34, // 66: mixturka.Mixturka.ListBrewHistory:input_type -> mixturka.ListBrewHistoryRequest
+	8,  // 67: mixturka.Mixturka.WatchRecipes:input_type -> mixturka.WatchRecipesRequest
+	7,  // 68: mixturka.Mixturka.GetRecipes:output_type -> mixturka.GetRecipesResponse
+	27, // 69: mixturka.Mixturka.BrewPot:output_type -> mixturka.PotBrewResponse
+	14, // 70: mixturka.Mixturka.SuggestRecipes:output_type -> mixturka.SuggestRecipesResponse
+	18, // 71: mixturka.Mixturka.GetShoppingList:output_type -> mixturka.ShoppingListResponse
+	21, // 72: mixturka.Mixturka.CalculateYield:output_type -> mixturka.YieldResponse
+	25, // 73: mixturka.Mixturka.PlanProduction:output_type -> mixturka.PlanProductionResponse
+	28, // 74: mixturka.Mixturka.GetBrewStatus:output_type -> mixturka.BrewSession
+	32, // 75: mixturka.Mixturka.ListBrews:output_type -> mixturka.ListBrewsResponse
+	28, // 76: mixturka.Mixturka.CancelBrew:output_type -> mixturka.BrewSession
+	35, // 77: mixturka.Mixturka.ListBrewHistory:output_type -> mixturka.ListBrewHistoryResponse
+	9,  // 78: mixturka.Mixturka.WatchRecipes:output_type -> mixturka.RecipeEvent
+	68, // [68:79] is the sub-list for method output_type
+	57, // [57:68] is the sub-list for method input_type
+	57, // [57:57] is the sub-list for extension type_name
+	57, // [57:57] is the sub-list for extension extendee
+	0,  // [0:57] is the sub-list for field type_name
 }
 
 func init() { file_mixturka_proto_init() }
@@ -463,18 +3268,21 @@ func file_mixturka_proto_init() {
 	if File_mixturka_proto != nil {
 		return
 	}
+	file_mixturka_proto_msgTypes[7].OneofWrappers = []any{}
+	file_mixturka_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mixturka_proto_rawDesc), len(file_mixturka_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      5,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_mixturka_proto_goTypes,
 		DependencyIndexes: file_mixturka_proto_depIdxs,
+		EnumInfos:         file_mixturka_proto_enumTypes,
 		MessageInfos:      file_mixturka_proto_msgTypes,
 	}.Build()
 	File_mixturka_proto = out.File
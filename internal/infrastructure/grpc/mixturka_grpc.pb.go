@@ -19,18 +19,47 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Mixturka_GetRecipes_FullMethodName = "/mixturka.Mixturka/GetRecipes"
-	Mixturka_BrewPot_FullMethodName    = "/mixturka.Mixturka/BrewPot"
+	Mixturka_GetRecipes_FullMethodName      = "/mixturka.Mixturka/GetRecipes"
+	Mixturka_BrewPot_FullMethodName         = "/mixturka.Mixturka/BrewPot"
+	Mixturka_SuggestRecipes_FullMethodName  = "/mixturka.Mixturka/SuggestRecipes"
+	Mixturka_GetShoppingList_FullMethodName = "/mixturka.Mixturka/GetShoppingList"
+	Mixturka_CalculateYield_FullMethodName  = "/mixturka.Mixturka/CalculateYield"
+	Mixturka_PlanProduction_FullMethodName  = "/mixturka.Mixturka/PlanProduction"
+	Mixturka_GetBrewStatus_FullMethodName   = "/mixturka.Mixturka/GetBrewStatus"
+	Mixturka_ListBrews_FullMethodName       = "/mixturka.Mixturka/ListBrews"
+	Mixturka_CancelBrew_FullMethodName      = "/mixturka.Mixturka/CancelBrew"
+	Mixturka_ListBrewHistory_FullMethodName = "/mixturka.Mixturka/ListBrewHistory"
+	Mixturka_WatchRecipes_FullMethodName    = "/mixturka.Mixturka/WatchRecipes"
 )
 
 // MixturkaClient is the client API for Mixturka service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MixturkaClient interface {
-	// GetRecipes retrieves a list of all recipes
+	// GetRecipes retrieves a list of recipes, optionally filtered by ingredients
 	GetRecipes(ctx context.Context, in *GetRecipesRequest, opts ...grpc.CallOption) (*GetRecipesResponse, error)
-	// BrewPot starts the brewing process with the specified ingredients.
+	// BrewPot starts the brewing process with the specified ingredients,
+	// or schedules it to start at start_at. Fails with RESOURCE_EXHAUSTED
+	// if all cauldrons are busy and the brew queue is full.
 	BrewPot(ctx context.Context, in *PotBrewRequest, opts ...grpc.CallOption) (*PotBrewResponse, error)
+	// SuggestRecipes ranks recipes that can be brewed, or almost brewed, from the given ingredients.
+	SuggestRecipes(ctx context.Context, in *SuggestRecipesRequest, opts ...grpc.CallOption) (*SuggestRecipesResponse, error)
+	// GetShoppingList returns the ingredient shortfall for brewing the target recipes.
+	GetShoppingList(ctx context.Context, in *ShoppingListRequest, opts ...grpc.CallOption) (*ShoppingListResponse, error)
+	// CalculateYield returns how many whole batches of a recipe, or of every recipe, the stock allows.
+	CalculateYield(ctx context.Context, in *YieldRequest, opts ...grpc.CallOption) (*YieldResponse, error)
+	// PlanProduction picks the number of batches of each recipe maximising the total value within the stock.
+	PlanProduction(ctx context.Context, in *PlanProductionRequest, opts ...grpc.CallOption) (*PlanProductionResponse, error)
+	// GetBrewStatus returns a brew started by BrewPot.
+	GetBrewStatus(ctx context.Context, in *GetBrewStatusRequest, opts ...grpc.CallOption) (*BrewSession, error)
+	// ListBrews lists brews, newest first.
+	ListBrews(ctx context.Context, in *ListBrewsRequest, opts ...grpc.CallOption) (*ListBrewsResponse, error)
+	// CancelBrew cancels a queued or brewing brew.
+	CancelBrew(ctx context.Context, in *CancelBrewRequest, opts ...grpc.CallOption) (*BrewSession, error)
+	// ListBrewHistory lists BrewPot calls, newest first.
+	ListBrewHistory(ctx context.Context, in *ListBrewHistoryRequest, opts ...grpc.CallOption) (*ListBrewHistoryResponse, error)
+	// WatchRecipes streams the current catalog followed by live recipe changes.
+	WatchRecipes(ctx context.Context, in *WatchRecipesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RecipeEvent], error)
 }
 
 type mixturkaClient struct {
@@ -61,14 +90,133 @@ func (c *mixturkaClient) BrewPot(ctx context.Context, in *PotBrewRequest, opts .
 	return out, nil
 }
 
+func (c *mixturkaClient) SuggestRecipes(ctx context.Context, in *SuggestRecipesRequest, opts ...grpc.CallOption) (*SuggestRecipesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestRecipesResponse)
+	err := c.cc.Invoke(ctx, Mixturka_SuggestRecipes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixturkaClient) GetShoppingList(ctx context.Context, in *ShoppingListRequest, opts ...grpc.CallOption) (*ShoppingListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingListResponse)
+	err := c.cc.Invoke(ctx, Mixturka_GetShoppingList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixturkaClient) CalculateYield(ctx context.Context, in *YieldRequest, opts ...grpc.CallOption) (*YieldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(YieldResponse)
+	err := c.cc.Invoke(ctx, Mixturka_CalculateYield_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixturkaClient) PlanProduction(ctx context.Context, in *PlanProductionRequest, opts ...grpc.CallOption) (*PlanProductionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanProductionResponse)
+	err := c.cc.Invoke(ctx, Mixturka_PlanProduction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixturkaClient) GetBrewStatus(ctx context.Context, in *GetBrewStatusRequest, opts ...grpc.CallOption) (*BrewSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BrewSession)
+	err := c.cc.Invoke(ctx, Mixturka_GetBrewStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixturkaClient) ListBrews(ctx context.Context, in *ListBrewsRequest, opts ...grpc.CallOption) (*ListBrewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBrewsResponse)
+	err := c.cc.Invoke(ctx, Mixturka_ListBrews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixturkaClient) CancelBrew(ctx context.Context, in *CancelBrewRequest, opts ...grpc.CallOption) (*BrewSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BrewSession)
+	err := c.cc.Invoke(ctx, Mixturka_CancelBrew_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixturkaClient) ListBrewHistory(ctx context.Context, in *ListBrewHistoryRequest, opts ...grpc.CallOption) (*ListBrewHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBrewHistoryResponse)
+	err := c.cc.Invoke(ctx, Mixturka_ListBrewHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixturkaClient) WatchRecipes(ctx context.Context, in *WatchRecipesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RecipeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Mixturka_ServiceDesc.Streams[0], Mixturka_WatchRecipes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRecipesRequest, RecipeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Mixturka_WatchRecipesClient = grpc.ServerStreamingClient[RecipeEvent]
+
 // MixturkaServer is the server API for Mixturka service.
 // All implementations must embed UnimplementedMixturkaServer
 // for forward compatibility.
 type MixturkaServer interface {
-	// GetRecipes retrieves a list of all recipes
+	// GetRecipes retrieves a list of recipes, optionally filtered by ingredients
 	GetRecipes(context.Context, *GetRecipesRequest) (*GetRecipesResponse, error)
-	// BrewPot starts the brewing process with the specified ingredients.
+	// BrewPot starts the brewing process with the specified ingredients,
+	// or schedules it to start at start_at. Fails with RESOURCE_EXHAUSTED
+	// if all cauldrons are busy and the brew queue is full.
 	BrewPot(context.Context, *PotBrewRequest) (*PotBrewResponse, error)
+	// SuggestRecipes ranks recipes that can be brewed, or almost brewed, from the given ingredients.
+	SuggestRecipes(context.Context, *SuggestRecipesRequest) (*SuggestRecipesResponse, error)
+	// GetShoppingList returns the ingredient shortfall for brewing the target recipes.
+	GetShoppingList(context.Context, *ShoppingListRequest) (*ShoppingListResponse, error)
+	// CalculateYield returns how many whole batches of a recipe, or of every recipe, the stock allows.
+	CalculateYield(context.Context, *YieldRequest) (*YieldResponse, error)
+	// PlanProduction picks the number of batches of each recipe maximising the total value within the stock.
+	PlanProduction(context.Context, *PlanProductionRequest) (*PlanProductionResponse, error)
+	// GetBrewStatus returns a brew started by BrewPot.
+	GetBrewStatus(context.Context, *GetBrewStatusRequest) (*BrewSession, error)
+	// ListBrews lists brews, newest first.
+	ListBrews(context.Context, *ListBrewsRequest) (*ListBrewsResponse, error)
+	// CancelBrew cancels a queued or brewing brew.
+	CancelBrew(context.Context, *CancelBrewRequest) (*BrewSession, error)
+	// ListBrewHistory lists BrewPot calls, newest first.
+	ListBrewHistory(context.Context, *ListBrewHistoryRequest) (*ListBrewHistoryResponse, error)
+	// WatchRecipes streams the current catalog followed by live recipe changes.
+	WatchRecipes(*WatchRecipesRequest, grpc.ServerStreamingServer[RecipeEvent]) error
 	mustEmbedUnimplementedMixturkaServer()
 }
 
@@ -85,6 +233,33 @@ func (UnimplementedMixturkaServer) GetRecipes(context.Context, *GetRecipesReques
 func (UnimplementedMixturkaServer) BrewPot(context.Context, *PotBrewRequest) (*PotBrewResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BrewPot not implemented")
 }
+func (UnimplementedMixturkaServer) SuggestRecipes(context.Context, *SuggestRecipesRequest) (*SuggestRecipesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestRecipes not implemented")
+}
+func (UnimplementedMixturkaServer) GetShoppingList(context.Context, *ShoppingListRequest) (*ShoppingListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShoppingList not implemented")
+}
+func (UnimplementedMixturkaServer) CalculateYield(context.Context, *YieldRequest) (*YieldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalculateYield not implemented")
+}
+func (UnimplementedMixturkaServer) PlanProduction(context.Context, *PlanProductionRequest) (*PlanProductionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanProduction not implemented")
+}
+func (UnimplementedMixturkaServer) GetBrewStatus(context.Context, *GetBrewStatusRequest) (*BrewSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBrewStatus not implemented")
+}
+func (UnimplementedMixturkaServer) ListBrews(context.Context, *ListBrewsRequest) (*ListBrewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBrews not implemented")
+}
+func (UnimplementedMixturkaServer) CancelBrew(context.Context, *CancelBrewRequest) (*BrewSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBrew not implemented")
+}
+func (UnimplementedMixturkaServer) ListBrewHistory(context.Context, *ListBrewHistoryRequest) (*ListBrewHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBrewHistory not implemented")
+}
+func (UnimplementedMixturkaServer) WatchRecipes(*WatchRecipesRequest, grpc.ServerStreamingServer[RecipeEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRecipes not implemented")
+}
 func (UnimplementedMixturkaServer) mustEmbedUnimplementedMixturkaServer() {}
 func (UnimplementedMixturkaServer) testEmbeddedByValue()                  {}
 
@@ -142,6 +317,161 @@ func _Mixturka_BrewPot_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mixturka_SuggestRecipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRecipesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixturkaServer).SuggestRecipes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mixturka_SuggestRecipes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixturkaServer).SuggestRecipes(ctx, req.(*SuggestRecipesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixturka_GetShoppingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShoppingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixturkaServer).GetShoppingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mixturka_GetShoppingList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixturkaServer).GetShoppingList(ctx, req.(*ShoppingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixturka_CalculateYield_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(YieldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixturkaServer).CalculateYield(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mixturka_CalculateYield_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixturkaServer).CalculateYield(ctx, req.(*YieldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixturka_PlanProduction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanProductionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixturkaServer).PlanProduction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mixturka_PlanProduction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixturkaServer).PlanProduction(ctx, req.(*PlanProductionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixturka_GetBrewStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBrewStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixturkaServer).GetBrewStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mixturka_GetBrewStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixturkaServer).GetBrewStatus(ctx, req.(*GetBrewStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixturka_ListBrews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBrewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixturkaServer).ListBrews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mixturka_ListBrews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixturkaServer).ListBrews(ctx, req.(*ListBrewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixturka_CancelBrew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBrewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixturkaServer).CancelBrew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mixturka_CancelBrew_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixturkaServer).CancelBrew(ctx, req.(*CancelBrewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixturka_ListBrewHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBrewHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixturkaServer).ListBrewHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mixturka_ListBrewHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixturkaServer).ListBrewHistory(ctx, req.(*ListBrewHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixturka_WatchRecipes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRecipesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MixturkaServer).WatchRecipes(m, &grpc.GenericServerStream[WatchRecipesRequest, RecipeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Mixturka_WatchRecipesServer = grpc.ServerStreamingServer[RecipeEvent]
+
 // Mixturka_ServiceDesc is the grpc.ServiceDesc for Mixturka service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,7 +487,45 @@ var Mixturka_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BrewPot",
 			Handler:    _Mixturka_BrewPot_Handler,
 		},
+		{
+			MethodName: "SuggestRecipes",
+			Handler:    _Mixturka_SuggestRecipes_Handler,
+		},
+		{
+			MethodName: "GetShoppingList",
+			Handler:    _Mixturka_GetShoppingList_Handler,
+		},
+		{
+			MethodName: "CalculateYield",
+			Handler:    _Mixturka_CalculateYield_Handler,
+		},
+		{
+			MethodName: "PlanProduction",
+			Handler:    _Mixturka_PlanProduction_Handler,
+		},
+		{
+			MethodName: "GetBrewStatus",
+			Handler:    _Mixturka_GetBrewStatus_Handler,
+		},
+		{
+			MethodName: "ListBrews",
+			Handler:    _Mixturka_ListBrews_Handler,
+		},
+		{
+			MethodName: "CancelBrew",
+			Handler:    _Mixturka_CancelBrew_Handler,
+		},
+		{
+			MethodName: "ListBrewHistory",
+			Handler:    _Mixturka_ListBrewHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRecipes",
+			Handler:       _Mixturka_WatchRecipes_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "mixturka.proto",
 }
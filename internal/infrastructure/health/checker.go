@@ -0,0 +1,143 @@
+package health
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check проверяет доступность зависимости сервиса.
+type Check func(ctx context.Context) error
+
+type Config struct {
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// Checker периодически выполняет проверки зависимостей и выставляет статус
+// grpc.health.v1.Health: общий статус сервисов SERVING, только если все проверки прошли.
+// Каждая проверка также публикуется отдельным сервисом с именем проверки.
+type Checker struct {
+	server   *health.Server
+	config   Config
+	services []string
+
+	mu     sync.Mutex
+	names  []string
+	checks map[string]Check
+	failed map[string]bool
+}
+
+func NewChecker(server *health.Server, config Config, services ...string) *Checker {
+	if config.Interval <= 0 {
+		config.Interval = 10 * time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 3 * time.Second
+	}
+
+	// Пока проверки не выполнены, сервис не принимает трафик.
+	services = append([]string{""}, services...)
+	for _, service := range services {
+		server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	return &Checker{
+		server:   server,
+		config:   config,
+		services: services,
+		checks:   make(map[string]Check),
+		failed:   make(map[string]bool),
+	}
+}
+
+func (c *Checker) AddCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.names = append(c.names, name)
+	c.checks[name] = check
+	c.server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Start запускает проверки сразу и затем с интервалом из конфигурации, пока не отменён ctx.
+func (c *Checker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(c.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			c.runChecks(ctx)
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown переводит все сервисы в NOT_SERVING и игнорирует дальнейшие результаты проверок,
+// чтобы балансировщики успели снять трафик до остановки сервера.
+func (c *Checker) Shutdown() {
+	c.server.Shutdown()
+}
+
+func (c *Checker) runChecks(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	serving := true
+	for _, name := range c.names {
+		err := c.runCheck(ctx, c.checks[name])
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			serving = false
+		}
+
+		if failed := err != nil; failed != c.failed[name] {
+			if failed {
+				log.Printf("health check %q failed: %v", name, err)
+			} else {
+				log.Printf("health check %q recovered", name)
+			}
+			c.failed[name] = failed
+		}
+
+		c.server.SetServingStatus(name, status)
+	}
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if !serving {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
+
+// runCheck не даёт зависшей проверке заблокировать остальные дольше таймаута.
+func (c *Checker) runCheck(ctx context.Context, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- check(ctx)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,296 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+const (
+	defaultMaxBatchSize = 100
+	defaultMaxBodySize  = 1 << 20
+)
+
+// Handler обрабатывает params вызова и возвращает значение для поля result.
+type Handler func(ctx context.Context, params json.RawMessage) (any, error)
+
+type Config struct {
+	// MaxBatchSize ограничивает количество вызовов в одном batch-запросе.
+	MaxBatchSize int
+	// MaxBodySize ограничивает размер тела запроса в байтах.
+	MaxBodySize int64
+	// PreserveOrder возвращает ответы batch-запроса в порядке вызовов,
+	// иначе ответы отдаются в порядке завершения и сопоставляются по id.
+	PreserveOrder bool
+}
+
+type Server struct {
+	methods map[string]Handler
+	config  Config
+}
+
+func NewServer(config Config) *Server {
+	if config.MaxBatchSize <= 0 {
+		config.MaxBatchSize = defaultMaxBatchSize
+	}
+
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = defaultMaxBodySize
+	}
+
+	return &Server{
+		methods: make(map[string]Handler),
+		config:  config,
+	}
+}
+
+func (s *Server) Register(method string, handler Handler) {
+	s.methods[method] = handler
+}
+
+// Handler возвращает gin-обработчик одиночных и batch-запросов. Если переданы methods,
+// вызовы других методов на этом пути отклоняются с кодом -32601.
+func (s *Server) Handler(methods ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, s.config.MaxBodySize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusOK, errorResponse(nil, NewError(CodeInvalidRequest, "invalid request").
+				WithData("reason", "request body is too large").
+				WithData("max_body_size", s.config.MaxBodySize)))
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusOK, errorResponse(nil, NewError(CodeParseError, "parse error")))
+			return
+		}
+
+		body = bytes.TrimSpace(body)
+		if len(body) > 0 && body[0] == '[' {
+			s.handleBatch(c, body, methods)
+			return
+		}
+
+		if !json.Valid(body) {
+			c.JSON(http.StatusOK, errorResponse(nil, NewError(CodeParseError, "parse error")))
+			return
+		}
+
+		resp := s.call(c.Request.Context(), body, methods)
+		if resp == nil {
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func (s *Server) handleBatch(c *gin.Context, body []byte, methods []string) {
+	var batch []json.RawMessage
+	if err := json.Unmarshal(body, &batch); err != nil {
+		c.JSON(http.StatusOK, errorResponse(nil, NewError(CodeParseError, "parse error")))
+		return
+	}
+
+	if len(batch) == 0 {
+		c.JSON(http.StatusOK, errorResponse(nil, NewError(CodeInvalidRequest, "invalid request").
+			WithData("reason", "batch must not be empty")))
+		return
+	}
+
+	if len(batch) > s.config.MaxBatchSize {
+		c.JSON(http.StatusOK, errorResponse(nil, NewError(CodeInvalidRequest, "invalid request").
+			WithData("reason", "batch is too large").
+			WithData("max_batch_size", s.config.MaxBatchSize)))
+		return
+	}
+
+	ctx := c.Request.Context()
+	ordered := make([]*Response, len(batch))
+	completed := make([]*Response, 0, len(batch))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, raw := range batch {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			resp := s.call(ctx, raw, methods)
+			if resp == nil {
+				return
+			}
+
+			mu.Lock()
+			ordered[i] = resp
+			completed = append(completed, resp)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	responses := completed
+	if s.config.PreserveOrder {
+		responses = slices.DeleteFunc(ordered, func(resp *Response) bool { return resp == nil })
+	}
+
+	// Batch из одних уведомлений не требует ответа.
+	if len(responses) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// call выполняет один вызов. Для уведомлений (вызовов без id) возвращает nil.
+func (s *Server) call(ctx context.Context, raw json.RawMessage, methods []string) *Response {
+	var req Request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return errorResponse(nil, NewError(CodeInvalidRequest, "invalid request").WithData("reason", "request must be an object"))
+	}
+
+	if rpcErr := validate(&req); rpcErr != nil {
+		return errorResponse(req.ID, rpcErr)
+	}
+
+	resp := s.dispatch(ctx, &req, methods)
+	if req.ID == nil {
+		return nil
+	}
+
+	return resp
+}
+
+func (s *Server) dispatch(ctx context.Context, req *Request, methods []string) *Response {
+	if len(methods) > 0 && !slices.Contains(methods, req.Method) {
+		return errorResponse(req.ID, NewError(CodeMethodNotFound, "method not found").WithData("method", req.Method))
+	}
+
+	handler, ok := s.methods[req.Method]
+	if !ok {
+		return errorResponse(req.ID, NewError(CodeMethodNotFound, "method not found").WithData("method", req.Method))
+	}
+
+	result, err := invoke(ctx, handler, req)
+	if err != nil {
+		return errorResponse(req.ID, toError(err))
+	}
+
+	return &Response{
+		JSONRPC: Version,
+		ID:      req.ID,
+		Result:  result,
+	}
+}
+
+// invoke вызывает обработчик и превращает его панику в internal error. Вызовы batch-запроса
+// выполняются в отдельных горутинах, где паника не дошла бы до gin.Recovery и уронила бы процесс.
+func invoke(ctx context.Context, handler Handler, req *Request) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("jsonrpc: panic in %s: %v\n%s", req.Method, r, debug.Stack())
+			err = NewError(CodeInternalError, "internal error")
+		}
+	}()
+
+	return handler(ctx, req.Params)
+}
+
+func validate(req *Request) *Error {
+	if req.JSONRPC != Version {
+		return NewError(CodeInvalidRequest, "invalid request").WithData("reason", `jsonrpc must be "2.0"`)
+	}
+
+	// id null допустим, на такой вызов отвечают с id null.
+	if req.ID != nil && string(req.ID) != "null" {
+		var id string
+		if err := json.Unmarshal(req.ID, &id); err != nil || len(id) != idLength {
+			return NewError(CodeInvalidRequest, "invalid request").WithData("reason", "id must be a 36 characters string")
+		}
+	}
+
+	if req.Method == "" {
+		return NewError(CodeInvalidRequest, "invalid request").WithData("reason", "method is required")
+	}
+
+	if len(req.Params) > 0 && req.Params[0] != '{' && req.Params[0] != '[' {
+		return NewError(CodeInvalidRequest, "invalid request").WithData("reason", "params must be an object or an array")
+	}
+
+	return nil
+}
+
+// toError переводит ошибку обработчика в объект Error из specs/server.yaml.
+func toError(err error) *Error {
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Type {
+		case domainErrors.NotFound:
+			return NewError(CodeNotFound, appErr.Error())
+		case domainErrors.ValidationError:
+			rpcErr := NewError(CodeInvalidParams, appErr.Error())
+			for _, violation := range appErr.Violations {
+				rpcErr.WithData(violation.Field, violation.Description)
+			}
+			if len(appErr.Suggestions) > 0 {
+				rpcErr.WithData("suggestions", toSuggestions(appErr.Suggestions))
+			}
+
+			return rpcErr
+		case domainErrors.ResourceAlreadyExists:
+			return NewError(CodeAlreadyExists, appErr.Error())
+		case domainErrors.NotAuthenticated:
+			return NewError(CodeNotAuthenticated, appErr.Error())
+		case domainErrors.NotAuthorized:
+			return NewError(CodeNotAuthorized, appErr.Error())
+		case domainErrors.FailedPrecondition:
+			return NewError(CodeFailedPrecondition, appErr.Error())
+		case domainErrors.ResourceExhausted:
+			return NewError(CodeResourceExhausted, appErr.Error())
+		}
+	}
+
+	log.Printf("jsonrpc: internal error: %v", err)
+
+	return NewError(CodeInternalError, "internal error")
+}
+
+func toSuggestions(suggestions []domainErrors.Suggestion) []Suggestion {
+	result := make([]Suggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		candidates := make([]Candidate, 0, len(suggestion.Candidates))
+		for _, candidate := range suggestion.Candidates {
+			candidates = append(candidates, Candidate(candidate))
+		}
+
+		result = append(result, Suggestion{Field: suggestion.Field, Value: suggestion.Value, Candidates: candidates})
+	}
+
+	return result
+}
+
+func errorResponse(id json.RawMessage, rpcErr *Error) *Response {
+	return &Response{
+		JSONRPC: Version,
+		ID:      id,
+		Error:   rpcErr,
+	}
+}
@@ -0,0 +1,299 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+const testID = `"550e8400-e29b-41d4-a716-446655440000"`
+
+func TestServer_Handler(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		handler        Handler
+		expectedResult string
+		expectedCode   int
+		expectedData   string
+	}{
+		{
+			name: "успешный вызов",
+			body: `{"jsonrpc":"2.0","id":` + testID + `,"method":"pot.brew","params":{}}`,
+			handler: func(ctx context.Context, params json.RawMessage) (any, error) {
+				return map[string]bool{"started": true}, nil
+			},
+			expectedResult: `{"started":true}`,
+		},
+		{
+			name: "пустой результат",
+			body: `{"jsonrpc":"2.0","id":` + testID + `,"method":"pot.brew","params":{}}`,
+			handler: func(ctx context.Context, params json.RawMessage) (any, error) {
+				return nil, nil
+			},
+			expectedResult: `null`,
+		},
+		{
+			name: "id null",
+			body: `{"jsonrpc":"2.0","id":null,"method":"pot.brew","params":{}}`,
+			handler: func(ctx context.Context, params json.RawMessage) (any, error) {
+				return map[string]bool{"started": true}, nil
+			},
+			expectedResult: `{"started":true}`,
+		},
+		{
+			name:         "некорректный JSON",
+			body:         `{"jsonrpc":`,
+			expectedCode: CodeParseError,
+		},
+		{
+			name:         "неверная версия протокола",
+			body:         `{"jsonrpc":"1.0","id":` + testID + `,"method":"pot.brew"}`,
+			expectedCode: CodeInvalidRequest,
+		},
+		{
+			name:         "id короче 36 символов",
+			body:         `{"jsonrpc":"2.0","id":"1","method":"pot.brew"}`,
+			expectedCode: CodeInvalidRequest,
+		},
+		{
+			name:         "метод не совпадает с эндпоинтом",
+			body:         `{"jsonrpc":"2.0","id":` + testID + `,"method":"recipes.list"}`,
+			expectedCode: CodeMethodNotFound,
+		},
+		{
+			name: "доменная ошибка NotFound",
+			body: `{"jsonrpc":"2.0","id":` + testID + `,"method":"pot.brew","params":{}}`,
+			handler: func(ctx context.Context, params json.RawMessage) (any, error) {
+				return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+			},
+			expectedCode: CodeNotFound,
+		},
+		{
+			name: "доменная ошибка FailedPrecondition",
+			body: `{"jsonrpc":"2.0","id":` + testID + `,"method":"pot.brew","params":{}}`,
+			handler: func(ctx context.Context, params json.RawMessage) (any, error) {
+				return nil, domainErrors.NewAppError(errors.New("brew is already finished"), domainErrors.FailedPrecondition)
+			},
+			expectedCode: CodeFailedPrecondition,
+		},
+		{
+			name: "доменная ошибка ResourceExhausted",
+			body: `{"jsonrpc":"2.0","id":` + testID + `,"method":"pot.brew","params":{}}`,
+			handler: func(ctx context.Context, params json.RawMessage) (any, error) {
+				return nil, domainErrors.NewAppErrorWithType(domainErrors.ResourceExhausted)
+			},
+			expectedCode: CodeResourceExhausted,
+		},
+		{
+			name: "ошибка валидации с подсказками",
+			body: `{"jsonrpc":"2.0","id":` + testID + `,"method":"pot.brew","params":{}}`,
+			handler: func(ctx context.Context, params json.RawMessage) (any, error) {
+				return nil, domainErrors.NewValidationError(errors.New("unknown brew ingredients"),
+					domainErrors.FieldViolation{Field: "ingredients[0].name", Description: "unknown ingredient"},
+				).WithSuggestions(domainErrors.Suggestion{
+					Field:      "ingredients[0].name",
+					Value:      "крапва",
+					Candidates: []domainErrors.Candidate{{Value: "крапива", Score: 0.5}},
+				})
+			},
+			expectedCode: CodeInvalidParams,
+			expectedData: `{"ingredients[0].name":"unknown ingredient","suggestions":[{"field":"ingredients[0].name","value":"крапва","candidates":[{"value":"крапива","score":0.5}]}]}`,
+		},
+		{
+			name: "неизвестная ошибка скрывается за internal error",
+			body: `{"jsonrpc":"2.0","id":` + testID + `,"method":"pot.brew","params":{}}`,
+			handler: func(ctx context.Context, params json.RawMessage) (any, error) {
+				return nil, errors.New("connection refused")
+			},
+			expectedCode: CodeInternalError,
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := NewServer(Config{})
+			if tt.handler != nil {
+				server.Register("pot.brew", tt.handler)
+			}
+
+			router := gin.New()
+			router.POST("/jsonrpc/v1/pot/brew", server.Handler("pot.brew"))
+
+			req := httptest.NewRequest(http.MethodPost, "/jsonrpc/v1/pot/brew", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var resp struct {
+				JSONRPC string          `json:"jsonrpc"`
+				Result  json.RawMessage `json:"result"`
+				Error   *Error          `json:"error"`
+			}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, Version, resp.JSONRPC)
+
+			if tt.expectedCode != 0 {
+				if assert.NotNil(t, resp.Error) {
+					assert.Equal(t, tt.expectedCode, resp.Error.Code)
+				}
+				if tt.expectedData != "" {
+					data, err := json.Marshal(resp.Error.Data)
+					assert.NoError(t, err)
+					assert.JSONEq(t, tt.expectedData, string(data))
+				}
+			} else {
+				assert.Nil(t, resp.Error)
+				assert.JSONEq(t, tt.expectedResult, string(resp.Result))
+			}
+		})
+	}
+}
+
+func TestServer_Batch(t *testing.T) {
+	const otherID = `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+
+	tests := []struct {
+		name           string
+		body           string
+		config         Config
+		expectedStatus int
+		expectedIDs    []string
+		expectedCodes  []int
+	}{
+		{
+			name:           "ответы в порядке вызовов",
+			body:           `[{"jsonrpc":"2.0","id":` + testID + `,"method":"echo","params":{}},{"jsonrpc":"2.0","id":` + otherID + `,"method":"echo","params":{}}]`,
+			config:         Config{PreserveOrder: true},
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{testID, otherID},
+			expectedCodes:  []int{0, 0},
+		},
+		{
+			name:           "уведомления не получают ответа",
+			body:           `[{"jsonrpc":"2.0","method":"echo","params":{}},{"jsonrpc":"2.0","id":` + otherID + `,"method":"echo","params":{}}]`,
+			config:         Config{PreserveOrder: true},
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{otherID},
+			expectedCodes:  []int{0},
+		},
+		{
+			name:           "batch из одних уведомлений",
+			body:           `[{"jsonrpc":"2.0","method":"echo"},{"jsonrpc":"2.0","method":"unknown"}]`,
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "ошибки отдельных вызовов",
+			body:           `[1,{"jsonrpc":"2.0","id":` + otherID + `,"method":"unknown"},{"jsonrpc":"2.0","id":` + testID + `,"method":"echo","params":"x"}]`,
+			config:         Config{PreserveOrder: true},
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{"null", otherID, testID},
+			expectedCodes:  []int{CodeInvalidRequest, CodeMethodNotFound, CodeInvalidRequest},
+		},
+		{
+			name:           "некорректный JSON batch",
+			body:           `[{"jsonrpc":"2.0","method":"echo"},`,
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{"null"},
+			expectedCodes:  []int{CodeParseError},
+		},
+		{
+			name:           "пустой batch",
+			body:           `[]`,
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{"null"},
+			expectedCodes:  []int{CodeInvalidRequest},
+		},
+		{
+			name:           "превышен размер batch",
+			body:           `[{"jsonrpc":"2.0","method":"echo"},{"jsonrpc":"2.0","method":"echo"}]`,
+			config:         Config{MaxBatchSize: 1},
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{"null"},
+			expectedCodes:  []int{CodeInvalidRequest},
+		},
+		{
+			name:           "превышен размер тела",
+			body:           `[{"jsonrpc":"2.0","method":"echo"},{"jsonrpc":"2.0","method":"echo"}]`,
+			config:         Config{MaxBodySize: 32},
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{"null"},
+			expectedCodes:  []int{CodeInvalidRequest},
+		},
+		{
+			name:           "паника в вызове не мешает остальным",
+			body:           `[{"jsonrpc":"2.0","id":` + testID + `,"method":"panic"},{"jsonrpc":"2.0","id":` + otherID + `,"method":"echo","params":{}}]`,
+			config:         Config{PreserveOrder: true},
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{testID, otherID},
+			expectedCodes:  []int{CodeInternalError, 0},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := NewServer(tt.config)
+			server.Register("echo", func(ctx context.Context, params json.RawMessage) (any, error) {
+				return params, nil
+			})
+			server.Register("panic", func(ctx context.Context, params json.RawMessage) (any, error) {
+				panic("boom")
+			})
+
+			router := gin.New()
+			router.POST("/jsonrpc/v1", server.Handler())
+
+			req := httptest.NewRequest(http.MethodPost, "/jsonrpc/v1", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.Empty(t, rec.Body.String())
+				return
+			}
+
+			var responses []Response
+			if strings.HasPrefix(rec.Body.String(), "[") {
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responses))
+			} else {
+				responses = make([]Response, 1)
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responses[0]))
+			}
+
+			if assert.Len(t, responses, len(tt.expectedIDs)) {
+				for i, resp := range responses {
+					assert.Equal(t, tt.expectedIDs[i], string(resp.ID))
+
+					if tt.expectedCodes[i] == 0 {
+						assert.Nil(t, resp.Error)
+					} else if assert.NotNil(t, resp.Error) {
+						assert.Equal(t, tt.expectedCodes[i], resp.Error.Code)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const Version = "2.0"
+
+// idLength длина идентификатора запроса по спецификации specs/server.yaml (UUID).
+const idLength = 36
+
+// Коды ошибок JSON-RPC 2.0 и серверные коды для доменных ошибок (-32000..-32099).
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+
+	CodeNotFound           = -32001
+	CodeAlreadyExists      = -32002
+	CodeNotAuthenticated   = -32003
+	CodeNotAuthorized      = -32004
+	CodeFailedPrecondition = -32005
+	CodeResourceExhausted  = -32006
+)
+
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// MarshalJSON выводит ровно одно из полей result и error, как требует JSON-RPC 2.0:
+// успешный ответ содержит result, даже если он null.
+func (r Response) MarshalJSON() ([]byte, error) {
+	if r.Error != nil {
+		return json.Marshal(struct {
+			JSONRPC string          `json:"jsonrpc"`
+			ID      json.RawMessage `json:"id"`
+			Error   *Error          `json:"error"`
+		}{JSONRPC: r.JSONRPC, ID: r.ID, Error: r.Error})
+	}
+
+	type response Response
+
+	return json.Marshal(response(r))
+}
+
+type Error struct {
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data,omitempty"`
+}
+
+// Suggestion варианты исправления значения поля в error.data.suggestions.
+type Suggestion struct {
+	Field      string      `json:"field"`
+	Value      string      `json:"value"`
+	Candidates []Candidate `json:"candidates"`
+}
+
+type Candidate struct {
+	Value string  `json:"value"`
+	Score float64 `json:"score"`
+}
+
+func NewError(code int, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// WithData добавляет к ошибке произвольные данные для поля error.data.
+func (e *Error) WithData(key string, value any) *Error {
+	if e.Data == nil {
+		e.Data = make(map[string]any)
+	}
+	e.Data[key] = value
+
+	return e
+}
+
+// DecodeParams разбирает params запроса в dst, возвращая ошибку -32602 при несовпадении схемы.
+func DecodeParams(params json.RawMessage, dst any) error {
+	if len(params) == 0 {
+		return NewError(CodeInvalidParams, "params are required")
+	}
+
+	if err := json.Unmarshal(params, dst); err != nil {
+		return NewError(CodeInvalidParams, "invalid params").WithData("reason", err.Error())
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package kafka
 
 import (
 	"context"
+	"errors"
 	"log"
+	"sync/atomic"
 
 	"github.com/IBM/sarama"
 
@@ -12,9 +14,11 @@ import (
 const TopicBabushkaRecipeV1 = "babushka.recipes.v1"
 
 type Consumer struct {
+	client    sarama.Client
 	consumer  sarama.Consumer
 	processor *recipe.Processor
 	topic     string
+	running   atomic.Bool
 }
 
 func NewConsumer(brokers []string, topic string, processor *recipe.Processor) (*Consumer, error) {
@@ -22,12 +26,19 @@ func NewConsumer(brokers []string, topic string, processor *recipe.Processor) (*
 	config := sarama.NewConfig()
 	config.Consumer.Return.Errors = true
 
-	consumer, err := sarama.NewConsumer(brokers, config)
+	client, err := sarama.NewClient(brokers, config)
 	if err != nil {
 		return nil, err
 	}
 
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
 	return &Consumer{
+		client:    client,
 		consumer:  consumer,
 		processor: processor,
 		topic:     topic,
@@ -40,10 +51,17 @@ func (c *Consumer) Start(ctx context.Context) error {
 		return err
 	}
 
+	c.running.Store(true)
+
 	go func() {
+		defer c.running.Store(false)
+
 		for {
 			select {
-			case msg := <-partitionConsumer.Messages():
+			case msg, ok := <-partitionConsumer.Messages():
+				if !ok {
+					return
+				}
 				if err := c.processor.ProcessRecipe(ctx, msg.Value); err != nil {
 					log.Printf("Error processing message: %v", err)
 				}
@@ -58,6 +76,29 @@ func (c *Consumer) Start(ctx context.Context) error {
 	return nil
 }
 
+// Check сообщает, что цикл чтения работает и брокеры отвечают на запрос метаданных топика.
+func (c *Consumer) Check(ctx context.Context) error {
+	if !c.running.Load() {
+		return errors.New("kafka consumer is not running")
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- c.client.RefreshMetadata(c.topic)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (c *Consumer) Close() error {
-	return c.consumer.Close()
+	if err := c.consumer.Close(); err != nil {
+		return err
+	}
+
+	return c.client.Close()
 }
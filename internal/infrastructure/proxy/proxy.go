@@ -0,0 +1,117 @@
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Trusted сети прокси, которым сервис доверяет сообщать автора вызова и адрес клиента.
+// Пустой список не доверяет никому.
+type Trusted []netip.Prefix
+
+// Parse разбирает адреса и сети в нотации CIDR, например "127.0.0.1" или "10.0.0.0/8".
+func Parse(values []string) (Trusted, error) {
+	trusted := make(Trusted, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+			}
+
+			trusted = append(trusted, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+		}
+
+		trusted = append(trusted, prefix.Masked())
+	}
+
+	return trusted, nil
+}
+
+// Strings возвращает сети в нотации CIDR, например для gin.Engine.SetTrustedProxies.
+func (t Trusted) Strings() []string {
+	result := make([]string, 0, len(t))
+	for _, prefix := range t {
+		result = append(result, prefix.String())
+	}
+
+	return result
+}
+
+// Contains сообщает, что addr ("ip" или "ip:port") принадлежит доверенному прокси.
+func (t Trusted) Contains(addr string) bool {
+	ip, err := netip.ParseAddr(host(addr))
+	if err != nil {
+		return false
+	}
+
+	ip = ip.Unmap()
+	for _, prefix := range t {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Caller определяет автора вызова по адресу remote, с которого пришёл запрос.
+// Автор claimed (заголовок X-Caller) и цепочка forwarded (X-Forwarded-For) учитываются,
+// только если remote — доверенный прокси: тогда автором считается claimed или ближайший
+// к сервису адрес цепочки, не принадлежащий доверенным прокси. verified сообщает, что
+// автора подтвердил доверенный прокси; иначе автор — адрес remote.
+func (t Trusted) Caller(remote string, forwarded []string, claimed string) (caller string, verified bool) {
+	caller = host(remote)
+	if !t.Contains(caller) {
+		return caller, false
+	}
+
+	if claimed = strings.TrimSpace(claimed); claimed != "" {
+		return claimed, true
+	}
+
+	hops := Hops(forwarded)
+	for i := len(hops) - 1; i >= 0; i-- {
+		caller = hops[i]
+		if !t.Contains(caller) {
+			break
+		}
+	}
+
+	return caller, true
+}
+
+// Hops разбирает значения X-Forwarded-For в список адресов от клиента к ближайшему прокси.
+func Hops(forwarded []string) []string {
+	hops := make([]string, 0, len(forwarded))
+	for _, value := range forwarded {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+
+	return hops
+}
+
+func host(addr string) string {
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		return h
+	}
+
+	return addr
+}
@@ -0,0 +1,78 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrusted_Caller(t *testing.T) {
+	trusted, err := Parse([]string{"10.0.0.1", "192.168.0.0/16"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		remote           string
+		forwarded        []string
+		claimed          string
+		expectedCaller   string
+		expectedVerified bool
+	}{
+		{
+			name:           "поддельный X-Caller от клиента не учитывается",
+			remote:         "203.0.113.5:51234",
+			claimed:        "admin",
+			expectedCaller: "203.0.113.5",
+		},
+		{
+			name:           "поддельный X-Forwarded-For от клиента не учитывается",
+			remote:         "203.0.113.5:51234",
+			forwarded:      []string{"198.51.100.1"},
+			expectedCaller: "203.0.113.5",
+		},
+		{
+			name:             "X-Caller от доверенного прокси",
+			remote:           "10.0.0.1:443",
+			claimed:          "alchemist",
+			expectedCaller:   "alchemist",
+			expectedVerified: true,
+		},
+		{
+			name:             "клиент за цепочкой доверенных прокси",
+			remote:           "10.0.0.1:443",
+			forwarded:        []string{"198.51.100.9, 203.0.113.5", "192.168.1.2"},
+			expectedCaller:   "203.0.113.5",
+			expectedVerified: true,
+		},
+		{
+			name:             "доверенный прокси без сведений о клиенте",
+			remote:           "192.168.4.4:80",
+			expectedCaller:   "192.168.4.4",
+			expectedVerified: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			caller, verified := trusted.Caller(tt.remote, tt.forwarded, tt.claimed)
+
+			// Assert
+			assert.Equal(t, tt.expectedCaller, caller)
+			assert.Equal(t, tt.expectedVerified, verified)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	// Act
+	trusted, err := Parse([]string{"127.0.0.1", " ::1 ", "10.1.2.3/8", ""})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1/32", "::1/128", "10.0.0.0/8"}, trusted.Strings())
+
+	_, err = Parse([]string{"localhost"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+)
+
+const brewColumns = "id, recipe_id, recipe_name, state, duration_ms, consumed, leftovers, error, " +
+	"cancelled_by, cancelled_by_caller, cancel_reason, ingredients_returned, created_at, start_at, started_at, finished_at"
+
+type BrewRepository struct {
+	db *sql.DB
+}
+
+var _ BrewRepositoryInterface = (*BrewRepository)(nil)
+
+func NewBrewRepository(db *sql.DB) *BrewRepository {
+	return &BrewRepository{db: db}
+}
+
+// brewIngredient ингредиент варки в JSONB-колонках consumed и leftovers.
+type brewIngredient struct {
+	Name     string     `json:"name"`
+	Quantity float64    `json:"quantity"`
+	Unit     units.Unit `json:"unit"`
+}
+
+func (r *BrewRepository) SaveBrew(ctx context.Context, brew *domain.Brew) error {
+	consumed, leftovers, err := marshalBrewIngredients(brew)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		"INSERT INTO brews ("+brewColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)",
+		brew.ID, nullRecipeID(brew.RecipeID), brew.RecipeName, brew.State, brew.Duration.Milliseconds(),
+		consumed, leftovers, brew.Error, brew.CancelledBy, brew.CancelledByCaller, brew.CancelReason, brew.IngredientsReturned,
+		brew.CreatedAt, brew.StartAt, brew.StartedAt, brew.FinishedAt,
+	)
+
+	return err
+}
+
+// UpdateBrew сохраняет состояние, время и отмену варки, если в базе она всё ещё в состоянии from.
+// Возвращает false, если состояние уже изменилось, например варку отменили.
+func (r *BrewRepository) UpdateBrew(ctx context.Context, brew *domain.Brew, from domain.BrewState) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE brews
+		SET state = $1, error = $2, cancelled_by = $3, cancelled_by_caller = $4, cancel_reason = $5,
+			ingredients_returned = $6, started_at = $7, finished_at = $8
+		WHERE id = $9 AND state = $10`,
+		brew.State, brew.Error, brew.CancelledBy, brew.CancelledByCaller, brew.CancelReason, brew.IngredientsReturned,
+		brew.StartedAt, brew.FinishedAt, brew.ID, from,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *BrewRepository) GetBrew(ctx context.Context, id string) (*domain.Brew, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+brewColumns+" FROM brews WHERE id = $1", id)
+
+	brew, err := scanBrew(row)
+	if err == sql.ErrNoRows {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	return brew, err
+}
+
+// FindBrews возвращает варки от новых к старым с keyset-пагинацией по (created_at, id).
+func (r *BrewRepository) FindBrews(ctx context.Context, query domain.BrewQuery) ([]domain.Brew, error) {
+	conditions := []string{"TRUE"}
+	args := make([]any, 0, 5)
+
+	if len(query.States) > 0 {
+		states := make([]string, 0, len(query.States))
+		for _, state := range query.States {
+			states = append(states, string(state))
+		}
+
+		args = append(args, pq.Array(states))
+		conditions = append(conditions, fmt.Sprintf("state = ANY($%d)", len(args)))
+	}
+
+	if query.RecipeID > 0 {
+		args = append(args, query.RecipeID)
+		conditions = append(conditions, fmt.Sprintf("recipe_id = $%d", len(args)))
+	}
+
+	if query.After != nil {
+		args = append(args, query.After.CreatedAt, query.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d::UUID)", len(args)-1, len(args)))
+	}
+
+	limitClause := ""
+	if query.Limit > 0 {
+		args = append(args, query.Limit)
+		limitClause = fmt.Sprintf("LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+brewColumns+`
+		FROM brews
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY created_at DESC, id DESC
+		`+limitClause,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	brews := make([]domain.Brew, 0)
+	for rows.Next() {
+		brew, err := scanBrew(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		brews = append(brews, *brew)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return brews, nil
+}
+
+func nullRecipeID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id > 0}
+}
+
+func marshalBrewIngredients(brew *domain.Brew) ([]byte, []byte, error) {
+	consumed, err := json.Marshal(toBrewIngredients(brew.Consumed))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	leftovers, err := json.Marshal(toBrewIngredients(brew.Leftovers))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return consumed, leftovers, nil
+}
+
+func toBrewIngredients(ingredients []domain.Ingredient) []brewIngredient {
+	result := make([]brewIngredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		result = append(result, brewIngredient{Name: ingredient.Name, Quantity: ingredient.Quantity, Unit: ingredient.Unit})
+	}
+
+	return result
+}
+
+func fromBrewIngredients(data []byte) ([]domain.Ingredient, error) {
+	var stored []brewIngredient
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Ingredient, 0, len(stored))
+	for _, ingredient := range stored {
+		result = append(result, domain.Ingredient{Name: ingredient.Name, Quantity: ingredient.Quantity, Unit: ingredient.Unit})
+	}
+
+	return result, nil
+}
+
+func scanBrew(row rowScanner) (*domain.Brew, error) {
+	var brew domain.Brew
+	var recipeID sql.NullInt64
+	var durationMs int64
+	var consumed, leftovers []byte
+	var startAt, startedAt, finishedAt sql.NullTime
+
+	err := row.Scan(&brew.ID, &recipeID, &brew.RecipeName, &brew.State, &durationMs,
+		&consumed, &leftovers, &brew.Error, &brew.CancelledBy, &brew.CancelledByCaller, &brew.CancelReason, &brew.IngredientsReturned,
+		&brew.CreatedAt, &startAt, &startedAt, &finishedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	brew.RecipeID = recipeID.Int64
+	brew.Duration = time.Duration(durationMs) * time.Millisecond
+	if startAt.Valid {
+		brew.StartAt = &startAt.Time
+	}
+	if startedAt.Valid {
+		brew.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		brew.FinishedAt = &finishedAt.Time
+	}
+
+	if brew.Consumed, err = fromBrewIngredients(consumed); err != nil {
+		return nil, err
+	}
+	if brew.Leftovers, err = fromBrewIngredients(leftovers); err != nil {
+		return nil, err
+	}
+
+	return &brew, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+)
+
+type BrewHistoryRepository struct {
+	db *sql.DB
+}
+
+var _ BrewHistoryRepositoryInterface = (*BrewHistoryRepository)(nil)
+
+func NewBrewHistoryRepository(db *sql.DB) *BrewHistoryRepository {
+	return &BrewHistoryRepository{db: db}
+}
+
+func (r *BrewHistoryRepository) SaveBrewRecord(ctx context.Context, record *domain.BrewRecord) error {
+	ingredients, err := json.Marshal(toBrewIngredients(record.Ingredients))
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO brew_history (id, caller, ingredients, match_mode, recipe_id, recipe_name, brew_id,
+			outcome, error, start_at, requested_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		record.ID, record.Caller, ingredients, record.MatchMode, nullRecipeID(record.RecipeID), record.RecipeName,
+		sql.NullString{String: record.BrewID, Valid: record.BrewID != ""},
+		record.Outcome, record.Error, record.StartAt, record.RequestedAt,
+	)
+
+	return err
+}
+
+// FindBrewRecords возвращает записи истории от новых к старым с keyset-пагинацией
+// по (requested_at, id) и текущим состоянием начатых варок.
+func (r *BrewHistoryRepository) FindBrewRecords(ctx context.Context, query domain.BrewHistoryQuery) ([]domain.BrewRecord, error) {
+	conditions := []string{"TRUE"}
+	args := make([]any, 0, 7)
+
+	if query.RecipeID > 0 {
+		args = append(args, query.RecipeID)
+		conditions = append(conditions, fmt.Sprintf("h.recipe_id = $%d", len(args)))
+	}
+
+	if len(query.Outcomes) > 0 {
+		outcomes := make([]string, 0, len(query.Outcomes))
+		for _, outcome := range query.Outcomes {
+			outcomes = append(outcomes, string(outcome))
+		}
+
+		args = append(args, pq.Array(outcomes))
+		conditions = append(conditions, fmt.Sprintf("h.outcome = ANY($%d)", len(args)))
+	}
+
+	if query.RequestedFrom != nil {
+		args = append(args, *query.RequestedFrom)
+		conditions = append(conditions, fmt.Sprintf("h.requested_at >= $%d", len(args)))
+	}
+
+	if query.RequestedTo != nil {
+		args = append(args, *query.RequestedTo)
+		conditions = append(conditions, fmt.Sprintf("h.requested_at < $%d", len(args)))
+	}
+
+	if query.After != nil {
+		args = append(args, query.After.CreatedAt, query.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(h.requested_at, h.id) < ($%d, $%d::UUID)", len(args)-1, len(args)))
+	}
+
+	limitClause := ""
+	if query.Limit > 0 {
+		args = append(args, query.Limit)
+		limitClause = fmt.Sprintf("LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT h.id, h.caller, h.ingredients, h.match_mode, h.recipe_id, h.recipe_name, h.brew_id,
+			h.outcome, h.error, b.state, h.start_at, h.requested_at
+		FROM brew_history h
+		LEFT JOIN brews b ON b.id = h.brew_id
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY h.requested_at DESC, h.id DESC
+		`+limitClause,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]domain.BrewRecord, 0)
+	for rows.Next() {
+		var record domain.BrewRecord
+		var ingredients []byte
+		var recipeID sql.NullInt64
+		var brewID, brewState sql.NullString
+		var startAt sql.NullTime
+
+		err := rows.Scan(&record.ID, &record.Caller, &ingredients, &record.MatchMode, &recipeID, &record.RecipeName,
+			&brewID, &record.Outcome, &record.Error, &brewState, &startAt, &record.RequestedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		record.RecipeID = recipeID.Int64
+		record.BrewID = brewID.String
+		record.BrewState = domain.BrewState(brewState.String)
+		if startAt.Valid {
+			record.StartAt = &startAt.Time
+		}
+
+		if record.Ingredients, err = fromBrewIngredients(ingredients); err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
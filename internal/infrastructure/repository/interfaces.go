@@ -10,5 +10,38 @@ import (
 
 type RecipeRepositoryInterface interface {
 	GetRecipes(ctx context.Context) ([]domain.Recipe, error)
+	FindRecipes(ctx context.Context, query domain.RecipeQuery) ([]domain.Recipe, error)
+	GetRecipe(ctx context.Context, id int64) (*domain.Recipe, error)
 	SaveRecipe(ctx context.Context, recipe *domain.Recipe) error
+	UpdateRecipe(ctx context.Context, recipe *domain.Recipe) error
+	DeleteRecipe(ctx context.Context, id int64) error
+	UpdateCanonicalNames(ctx context.Context, canonical func(name string) string) error
+}
+
+type SubstitutionRepositoryInterface interface {
+	GetSubstitutions(ctx context.Context) ([]domain.Substitution, error)
+	GetSubstitution(ctx context.Context, id int64) (*domain.Substitution, error)
+	SaveSubstitution(ctx context.Context, substitution *domain.Substitution) error
+	UpdateSubstitution(ctx context.Context, substitution *domain.Substitution) error
+	DeleteSubstitution(ctx context.Context, id int64) error
+}
+
+type SynonymRepositoryInterface interface {
+	GetSynonyms(ctx context.Context) ([]domain.Synonym, error)
+	GetSynonym(ctx context.Context, id int64) (*domain.Synonym, error)
+	SaveSynonym(ctx context.Context, synonym *domain.Synonym) error
+	UpdateSynonym(ctx context.Context, synonym *domain.Synonym) error
+	DeleteSynonym(ctx context.Context, id int64) error
+}
+
+type BrewRepositoryInterface interface {
+	SaveBrew(ctx context.Context, brew *domain.Brew) error
+	UpdateBrew(ctx context.Context, brew *domain.Brew, from domain.BrewState) (bool, error)
+	GetBrew(ctx context.Context, id string) (*domain.Brew, error)
+	FindBrews(ctx context.Context, query domain.BrewQuery) ([]domain.Brew, error)
+}
+
+type BrewHistoryRepositoryInterface interface {
+	SaveBrewRecord(ctx context.Context, record *domain.BrewRecord) error
+	FindBrewRecords(ctx context.Context, query domain.BrewHistoryQuery) ([]domain.BrewRecord, error)
 }
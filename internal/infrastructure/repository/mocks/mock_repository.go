@@ -35,6 +35,50 @@ func (m *MockRecipeRepositoryInterface) EXPECT() *MockRecipeRepositoryInterfaceM
 	return m.recorder
 }
 
+// DeleteRecipe mocks base method.
+func (m *MockRecipeRepositoryInterface) DeleteRecipe(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipe", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipe indicates an expected call of DeleteRecipe.
+func (mr *MockRecipeRepositoryInterfaceMockRecorder) DeleteRecipe(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipe", reflect.TypeOf((*MockRecipeRepositoryInterface)(nil).DeleteRecipe), ctx, id)
+}
+
+// FindRecipes mocks base method.
+func (m *MockRecipeRepositoryInterface) FindRecipes(ctx context.Context, query domain.RecipeQuery) ([]domain.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecipes", ctx, query)
+	ret0, _ := ret[0].([]domain.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecipes indicates an expected call of FindRecipes.
+func (mr *MockRecipeRepositoryInterfaceMockRecorder) FindRecipes(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecipes", reflect.TypeOf((*MockRecipeRepositoryInterface)(nil).FindRecipes), ctx, query)
+}
+
+// GetRecipe mocks base method.
+func (m *MockRecipeRepositoryInterface) GetRecipe(ctx context.Context, id int64) (*domain.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipe", ctx, id)
+	ret0, _ := ret[0].(*domain.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipe indicates an expected call of GetRecipe.
+func (mr *MockRecipeRepositoryInterfaceMockRecorder) GetRecipe(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipe", reflect.TypeOf((*MockRecipeRepositoryInterface)(nil).GetRecipe), ctx, id)
+}
+
 // GetRecipes mocks base method.
 func (m *MockRecipeRepositoryInterface) GetRecipes(ctx context.Context) ([]domain.Recipe, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +107,355 @@ func (mr *MockRecipeRepositoryInterfaceMockRecorder) SaveRecipe(ctx, recipe inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRecipe", reflect.TypeOf((*MockRecipeRepositoryInterface)(nil).SaveRecipe), ctx, recipe)
 }
+
+// UpdateCanonicalNames mocks base method.
+func (m *MockRecipeRepositoryInterface) UpdateCanonicalNames(ctx context.Context, canonical func(string) string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCanonicalNames", ctx, canonical)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCanonicalNames indicates an expected call of UpdateCanonicalNames.
+func (mr *MockRecipeRepositoryInterfaceMockRecorder) UpdateCanonicalNames(ctx, canonical interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCanonicalNames", reflect.TypeOf((*MockRecipeRepositoryInterface)(nil).UpdateCanonicalNames), ctx, canonical)
+}
+
+// UpdateRecipe mocks base method.
+func (m *MockRecipeRepositoryInterface) UpdateRecipe(ctx context.Context, recipe *domain.Recipe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipe", ctx, recipe)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecipe indicates an expected call of UpdateRecipe.
+func (mr *MockRecipeRepositoryInterfaceMockRecorder) UpdateRecipe(ctx, recipe interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipe", reflect.TypeOf((*MockRecipeRepositoryInterface)(nil).UpdateRecipe), ctx, recipe)
+}
+
+// MockSubstitutionRepositoryInterface is a mock of SubstitutionRepositoryInterface interface.
+type MockSubstitutionRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubstitutionRepositoryInterfaceMockRecorder
+}
+
+// MockSubstitutionRepositoryInterfaceMockRecorder is the mock recorder for MockSubstitutionRepositoryInterface.
+type MockSubstitutionRepositoryInterfaceMockRecorder struct {
+	mock *MockSubstitutionRepositoryInterface
+}
+
+// NewMockSubstitutionRepositoryInterface creates a new mock instance.
+func NewMockSubstitutionRepositoryInterface(ctrl *gomock.Controller) *MockSubstitutionRepositoryInterface {
+	mock := &MockSubstitutionRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockSubstitutionRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubstitutionRepositoryInterface) EXPECT() *MockSubstitutionRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteSubstitution mocks base method.
+func (m *MockSubstitutionRepositoryInterface) DeleteSubstitution(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubstitution", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubstitution indicates an expected call of DeleteSubstitution.
+func (mr *MockSubstitutionRepositoryInterfaceMockRecorder) DeleteSubstitution(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubstitution", reflect.TypeOf((*MockSubstitutionRepositoryInterface)(nil).DeleteSubstitution), ctx, id)
+}
+
+// GetSubstitution mocks base method.
+func (m *MockSubstitutionRepositoryInterface) GetSubstitution(ctx context.Context, id int64) (*domain.Substitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubstitution", ctx, id)
+	ret0, _ := ret[0].(*domain.Substitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubstitution indicates an expected call of GetSubstitution.
+func (mr *MockSubstitutionRepositoryInterfaceMockRecorder) GetSubstitution(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubstitution", reflect.TypeOf((*MockSubstitutionRepositoryInterface)(nil).GetSubstitution), ctx, id)
+}
+
+// GetSubstitutions mocks base method.
+func (m *MockSubstitutionRepositoryInterface) GetSubstitutions(ctx context.Context) ([]domain.Substitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubstitutions", ctx)
+	ret0, _ := ret[0].([]domain.Substitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubstitutions indicates an expected call of GetSubstitutions.
+func (mr *MockSubstitutionRepositoryInterfaceMockRecorder) GetSubstitutions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubstitutions", reflect.TypeOf((*MockSubstitutionRepositoryInterface)(nil).GetSubstitutions), ctx)
+}
+
+// SaveSubstitution mocks base method.
+func (m *MockSubstitutionRepositoryInterface) SaveSubstitution(ctx context.Context, substitution *domain.Substitution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSubstitution", ctx, substitution)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSubstitution indicates an expected call of SaveSubstitution.
+func (mr *MockSubstitutionRepositoryInterfaceMockRecorder) SaveSubstitution(ctx, substitution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSubstitution", reflect.TypeOf((*MockSubstitutionRepositoryInterface)(nil).SaveSubstitution), ctx, substitution)
+}
+
+// UpdateSubstitution mocks base method.
+func (m *MockSubstitutionRepositoryInterface) UpdateSubstitution(ctx context.Context, substitution *domain.Substitution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubstitution", ctx, substitution)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubstitution indicates an expected call of UpdateSubstitution.
+func (mr *MockSubstitutionRepositoryInterfaceMockRecorder) UpdateSubstitution(ctx, substitution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubstitution", reflect.TypeOf((*MockSubstitutionRepositoryInterface)(nil).UpdateSubstitution), ctx, substitution)
+}
+
+// MockSynonymRepositoryInterface is a mock of SynonymRepositoryInterface interface.
+type MockSynonymRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSynonymRepositoryInterfaceMockRecorder
+}
+
+// MockSynonymRepositoryInterfaceMockRecorder is the mock recorder for MockSynonymRepositoryInterface.
+type MockSynonymRepositoryInterfaceMockRecorder struct {
+	mock *MockSynonymRepositoryInterface
+}
+
+// NewMockSynonymRepositoryInterface creates a new mock instance.
+func NewMockSynonymRepositoryInterface(ctrl *gomock.Controller) *MockSynonymRepositoryInterface {
+	mock := &MockSynonymRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockSynonymRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSynonymRepositoryInterface) EXPECT() *MockSynonymRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteSynonym mocks base method.
+func (m *MockSynonymRepositoryInterface) DeleteSynonym(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSynonym", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSynonym indicates an expected call of DeleteSynonym.
+func (mr *MockSynonymRepositoryInterfaceMockRecorder) DeleteSynonym(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSynonym", reflect.TypeOf((*MockSynonymRepositoryInterface)(nil).DeleteSynonym), ctx, id)
+}
+
+// GetSynonym mocks base method.
+func (m *MockSynonymRepositoryInterface) GetSynonym(ctx context.Context, id int64) (*domain.Synonym, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSynonym", ctx, id)
+	ret0, _ := ret[0].(*domain.Synonym)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSynonym indicates an expected call of GetSynonym.
+func (mr *MockSynonymRepositoryInterfaceMockRecorder) GetSynonym(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSynonym", reflect.TypeOf((*MockSynonymRepositoryInterface)(nil).GetSynonym), ctx, id)
+}
+
+// GetSynonyms mocks base method.
+func (m *MockSynonymRepositoryInterface) GetSynonyms(ctx context.Context) ([]domain.Synonym, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSynonyms", ctx)
+	ret0, _ := ret[0].([]domain.Synonym)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSynonyms indicates an expected call of GetSynonyms.
+func (mr *MockSynonymRepositoryInterfaceMockRecorder) GetSynonyms(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSynonyms", reflect.TypeOf((*MockSynonymRepositoryInterface)(nil).GetSynonyms), ctx)
+}
+
+// SaveSynonym mocks base method.
+func (m *MockSynonymRepositoryInterface) SaveSynonym(ctx context.Context, synonym *domain.Synonym) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSynonym", ctx, synonym)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSynonym indicates an expected call of SaveSynonym.
+func (mr *MockSynonymRepositoryInterfaceMockRecorder) SaveSynonym(ctx, synonym interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSynonym", reflect.TypeOf((*MockSynonymRepositoryInterface)(nil).SaveSynonym), ctx, synonym)
+}
+
+// UpdateSynonym mocks base method.
+func (m *MockSynonymRepositoryInterface) UpdateSynonym(ctx context.Context, synonym *domain.Synonym) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSynonym", ctx, synonym)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSynonym indicates an expected call of UpdateSynonym.
+func (mr *MockSynonymRepositoryInterfaceMockRecorder) UpdateSynonym(ctx, synonym interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSynonym", reflect.TypeOf((*MockSynonymRepositoryInterface)(nil).UpdateSynonym), ctx, synonym)
+}
+
+// MockBrewRepositoryInterface is a mock of BrewRepositoryInterface interface.
+type MockBrewRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrewRepositoryInterfaceMockRecorder
+}
+
+// MockBrewRepositoryInterfaceMockRecorder is the mock recorder for MockBrewRepositoryInterface.
+type MockBrewRepositoryInterfaceMockRecorder struct {
+	mock *MockBrewRepositoryInterface
+}
+
+// NewMockBrewRepositoryInterface creates a new mock instance.
+func NewMockBrewRepositoryInterface(ctrl *gomock.Controller) *MockBrewRepositoryInterface {
+	mock := &MockBrewRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockBrewRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrewRepositoryInterface) EXPECT() *MockBrewRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindBrews mocks base method.
+func (m *MockBrewRepositoryInterface) FindBrews(ctx context.Context, query domain.BrewQuery) ([]domain.Brew, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBrews", ctx, query)
+	ret0, _ := ret[0].([]domain.Brew)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBrews indicates an expected call of FindBrews.
+func (mr *MockBrewRepositoryInterfaceMockRecorder) FindBrews(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBrews", reflect.TypeOf((*MockBrewRepositoryInterface)(nil).FindBrews), ctx, query)
+}
+
+// GetBrew mocks base method.
+func (m *MockBrewRepositoryInterface) GetBrew(ctx context.Context, id string) (*domain.Brew, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrew", ctx, id)
+	ret0, _ := ret[0].(*domain.Brew)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrew indicates an expected call of GetBrew.
+func (mr *MockBrewRepositoryInterfaceMockRecorder) GetBrew(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrew", reflect.TypeOf((*MockBrewRepositoryInterface)(nil).GetBrew), ctx, id)
+}
+
+// SaveBrew mocks base method.
+func (m *MockBrewRepositoryInterface) SaveBrew(ctx context.Context, brew *domain.Brew) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBrew", ctx, brew)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBrew indicates an expected call of SaveBrew.
+func (mr *MockBrewRepositoryInterfaceMockRecorder) SaveBrew(ctx, brew interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBrew", reflect.TypeOf((*MockBrewRepositoryInterface)(nil).SaveBrew), ctx, brew)
+}
+
+// UpdateBrew mocks base method.
+func (m *MockBrewRepositoryInterface) UpdateBrew(ctx context.Context, brew *domain.Brew, from domain.BrewState) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBrew", ctx, brew, from)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBrew indicates an expected call of UpdateBrew.
+func (mr *MockBrewRepositoryInterfaceMockRecorder) UpdateBrew(ctx, brew, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBrew", reflect.TypeOf((*MockBrewRepositoryInterface)(nil).UpdateBrew), ctx, brew, from)
+}
+
+// MockBrewHistoryRepositoryInterface is a mock of BrewHistoryRepositoryInterface interface.
+type MockBrewHistoryRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrewHistoryRepositoryInterfaceMockRecorder
+}
+
+// MockBrewHistoryRepositoryInterfaceMockRecorder is the mock recorder for MockBrewHistoryRepositoryInterface.
+type MockBrewHistoryRepositoryInterfaceMockRecorder struct {
+	mock *MockBrewHistoryRepositoryInterface
+}
+
+// NewMockBrewHistoryRepositoryInterface creates a new mock instance.
+func NewMockBrewHistoryRepositoryInterface(ctrl *gomock.Controller) *MockBrewHistoryRepositoryInterface {
+	mock := &MockBrewHistoryRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockBrewHistoryRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrewHistoryRepositoryInterface) EXPECT() *MockBrewHistoryRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindBrewRecords mocks base method.
+func (m *MockBrewHistoryRepositoryInterface) FindBrewRecords(ctx context.Context, query domain.BrewHistoryQuery) ([]domain.BrewRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBrewRecords", ctx, query)
+	ret0, _ := ret[0].([]domain.BrewRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBrewRecords indicates an expected call of FindBrewRecords.
+func (mr *MockBrewHistoryRepositoryInterfaceMockRecorder) FindBrewRecords(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBrewRecords", reflect.TypeOf((*MockBrewHistoryRepositoryInterface)(nil).FindBrewRecords), ctx, query)
+}
+
+// SaveBrewRecord mocks base method.
+func (m *MockBrewHistoryRepositoryInterface) SaveBrewRecord(ctx context.Context, record *domain.BrewRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBrewRecord", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBrewRecord indicates an expected call of SaveBrewRecord.
+func (mr *MockBrewHistoryRepositoryInterfaceMockRecorder) SaveBrewRecord(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBrewRecord", reflect.TypeOf((*MockBrewHistoryRepositoryInterface)(nil).SaveBrewRecord), ctx, record)
+}
@@ -3,10 +3,21 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
 )
 
+// uniqueViolation код ошибки PostgreSQL при нарушении уникального индекса.
+const uniqueViolation = "23505"
+
 type RecipeRepository struct {
 	db *sql.DB
 }
@@ -26,23 +37,166 @@ func (r *RecipeRepository) SaveRecipe(ctx context.Context, recipe *domain.Recipe
 
 	var recipeID int64
 	err = tx.QueryRowContext(ctx,
-		"INSERT INTO recipes (name) VALUES ($1) RETURNING id",
-		recipe.Name,
+		"INSERT INTO recipes (name, brew_duration_ms) VALUES ($1, $2) RETURNING id",
+		recipe.Name, recipe.BrewDuration.Milliseconds(),
 	).Scan(&recipeID)
 	if err != nil {
+		return recipeNameError(err, recipe.Name)
+	}
+
+	if err = insertIngredients(ctx, tx, recipeID, recipe.Ingredients); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	recipe.ID = recipeID
+
+	return nil
+}
+
+// UpdateRecipe заменяет название и состав существующего рецепта.
+func (r *RecipeRepository) UpdateRecipe(ctx context.Context, recipe *domain.Recipe) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE recipes SET name = $1, brew_duration_ms = $2 WHERE id = $3",
+		recipe.Name, recipe.BrewDuration.Milliseconds(), recipe.ID,
+	)
+	if err != nil {
+		return recipeNameError(err, recipe.Name)
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	if err = deleteIngredients(ctx, tx, recipe.ID); err != nil {
+		return err
+	}
+
+	if err = insertIngredients(ctx, tx, recipe.ID, recipe.Ingredients); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *RecipeRepository) DeleteRecipe(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = deleteIngredients(ctx, tx, id); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM recipes WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	return tx.Commit()
+}
+
+// UpdateCanonicalNames пересчитывает канонические названия всех ингредиентов каталога функцией canonical.
+// Нужен после изменения словаря синонимов или правил нормализации: иначе фильтр по составу
+// продолжит искать по названиям, вычисленным при сохранении рецепта.
+func (r *RecipeRepository) UpdateCanonicalNames(ctx context.Context, canonical func(name string) string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, "SELECT DISTINCT name, canonical_name FROM ingredients")
+	if err != nil {
+		return err
+	}
+
+	changed := make(map[string]string)
+	for rows.Next() {
+		var name, current string
+		if err = rows.Scan(&name, &current); err != nil {
+			rows.Close()
+			return err
+		}
+
+		if next := canonical(name); next != current {
+			changed[name] = next
+		}
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
 		return err
 	}
 
-	for _, ingredient := range recipe.Ingredients {
+	if len(changed) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(changed))
+	canonicalNames := make([]string, 0, len(changed))
+	for name, canonicalName := range changed {
+		names = append(names, name)
+		canonicalNames = append(canonicalNames, canonicalName)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE ingredients i SET canonical_name = u.canonical_name
+		FROM UNNEST($1::TEXT[], $2::TEXT[]) AS u(name, canonical_name)
+		WHERE i.name = u.name`,
+		pq.Array(names), pq.Array(canonicalNames),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// recipeNameError переводит нарушение уникального индекса idx_recipes_name по названию
+// без учёта регистра и крайних пробелов в ResourceAlreadyExists.
+func recipeNameError(err error, name string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == "idx_recipes_name" {
+		return domainErrors.NewAppError(fmt.Errorf("recipe %q already exists", name), domainErrors.ResourceAlreadyExists)
+	}
+
+	return err
+}
+
+func insertIngredients(ctx context.Context, tx *sql.Tx, recipeID int64, ingredients []domain.Ingredient) error {
+	for i, ingredient := range ingredients {
 		var ingredientID int64
-		err = tx.QueryRowContext(ctx,
-			"INSERT INTO ingredients (recipe_id, name, quantity) VALUES ($1, $2, $3) RETURNING id",
-			recipeID, ingredient.Name, ingredient.Quantity,
+		err := tx.QueryRowContext(ctx,
+			"INSERT INTO ingredients (recipe_id, name, canonical_name, quantity, unit) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+			recipeID, ingredient.Name, ingredient.CanonicalName, ingredient.Quantity, ingredient.Unit,
 		).Scan(&ingredientID)
 		if err != nil {
 			return err
 		}
 
+		ingredients[i].ID = ingredientID
+		ingredients[i].RecipeID = recipeID
+
 		_, err = tx.ExecContext(ctx,
 			"INSERT INTO recipes_ingredients (recipe_id, ingredient_id) VALUES ($1, $2)",
 			recipeID, ingredientID,
@@ -52,61 +206,208 @@ func (r *RecipeRepository) SaveRecipe(ctx context.Context, recipe *domain.Recipe
 		}
 	}
 
-	return tx.Commit()
+	return nil
+}
+
+func deleteIngredients(ctx context.Context, tx *sql.Tx, recipeID int64) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM recipes_ingredients WHERE recipe_id = $1", recipeID); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, "DELETE FROM ingredients WHERE recipe_id = $1", recipeID)
+
+	return err
+}
+
+func (r *RecipeRepository) GetRecipe(ctx context.Context, id int64) (*domain.Recipe, error) {
+	recipes, err := r.queryRecipes(ctx, "r.id = $1", "id", 0, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(recipes) == 0 {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	return &recipes[0], nil
 }
 
 func (r *RecipeRepository) GetRecipes(ctx context.Context) ([]domain.Recipe, error) {
+	return r.FindRecipes(ctx, domain.RecipeQuery{})
+}
+
+// FindRecipes возвращает страницу рецептов, подходящих под фильтр по ингредиентам.
+// Фильтрация и keyset-пагинация выполняются на стороне базы данных.
+func (r *RecipeRepository) FindRecipes(ctx context.Context, query domain.RecipeQuery) ([]domain.Recipe, error) {
+	where, args := recipeFilterCondition(query.Filter)
+
+	order := "id"
+	if query.OrderBy == domain.RecipeOrderName {
+		order = "name, id"
+	}
+
+	if query.After != nil {
+		if query.OrderBy == domain.RecipeOrderName {
+			args = append(args, query.After.Name, query.After.ID)
+			where += fmt.Sprintf(" AND (r.name, r.id) > ($%d, $%d)", len(args)-1, len(args))
+		} else {
+			args = append(args, query.After.ID)
+			where += fmt.Sprintf(" AND r.id > $%d", len(args))
+		}
+	}
+
+	return r.queryRecipes(ctx, where, order, query.Limit, args...)
+}
+
+// queryRecipes выбирает до limit рецептов по условию where (над алиасом r) в порядке order
+// вместе с их ингредиентами. Ограничение применяется к рецептам, а не к строкам соединения.
+func (r *RecipeRepository) queryRecipes(ctx context.Context, where, order string, limit int, args ...any) ([]domain.Recipe, error) {
+	limitClause := ""
+	if limit > 0 {
+		args = append(args, limit)
+		limitClause = fmt.Sprintf("LIMIT $%d", len(args))
+	}
+
 	query := `
-		SELECT r.id, r.name, i.id, i.name, i.quantity
-		FROM recipes r
-		LEFT JOIN recipes_ingredients ri ON r.id = ri.recipe_id
+		WITH page AS (
+			SELECT r.id, r.name, r.brew_duration_ms
+			FROM recipes r
+			WHERE ` + where + `
+			ORDER BY ` + prefixColumns("r", order) + `
+			` + limitClause + `
+		)
+		SELECT p.id, p.name, p.brew_duration_ms, i.id, i.name, i.canonical_name, i.quantity, i.unit
+		FROM page p
+		LEFT JOIN recipes_ingredients ri ON p.id = ri.recipe_id
 		LEFT JOIN ingredients i ON ri.ingredient_id = i.id
-		ORDER BY r.id, i.id
+		ORDER BY ` + prefixColumns("p", order) + `, i.id
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	recipes := make(map[int64]domain.Recipe)
+	return scanRecipes(rows)
+}
+
+func prefixColumns(alias, columns string) string {
+	parts := strings.Split(columns, ", ")
+	for i, part := range parts {
+		parts[i] = alias + "." + part
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// recipeFilterCondition строит условие WHERE для фильтра по каноническим названиям ингредиентов.
+func recipeFilterCondition(filter domain.RecipeFilter) (string, []any) {
+	conditions := []string{"TRUE"}
+	args := make([]any, 0, 4)
+
+	if names := uniqueNames(filter.AllOf); len(names) > 0 {
+		args = append(args, pq.Array(names), len(names))
+		conditions = append(conditions, fmt.Sprintf(`r.id IN (
+			SELECT fri.recipe_id
+			FROM recipes_ingredients fri
+			JOIN ingredients fi ON fri.ingredient_id = fi.id
+			WHERE fi.canonical_name = ANY($%d)
+			GROUP BY fri.recipe_id
+			HAVING COUNT(DISTINCT fi.canonical_name) = $%d
+		)`, len(args)-1, len(args)))
+	}
+
+	if names := uniqueNames(filter.AnyOf); len(names) > 0 {
+		args = append(args, pq.Array(names))
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1
+			FROM recipes_ingredients fri
+			JOIN ingredients fi ON fri.ingredient_id = fi.id
+			WHERE fri.recipe_id = r.id AND fi.canonical_name = ANY($%d)
+		)`, len(args)))
+	}
+
+	if names := uniqueNames(filter.NoneOf); len(names) > 0 {
+		args = append(args, pq.Array(names))
+		conditions = append(conditions, fmt.Sprintf(`NOT EXISTS (
+			SELECT 1
+			FROM recipes_ingredients fri
+			JOIN ingredients fi ON fri.ingredient_id = fi.id
+			WHERE fri.recipe_id = r.id AND fi.canonical_name = ANY($%d)
+		)`, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func uniqueNames(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if _, ok := seen[name]; ok {
+			continue
+		}
+
+		seen[name] = struct{}{}
+		result = append(result, name)
+	}
+
+	return result
+}
+
+// scanRecipes собирает рецепты из строк вида (r.id, r.name, r.brew_duration_ms, i.id, i.name, i.canonical_name,
+// i.quantity, i.unit), сгруппированных по рецепту, сохраняя порядок строк.
+func scanRecipes(rows *sql.Rows) ([]domain.Recipe, error) {
+	recipes := make([]domain.Recipe, 0)
+	indexes := make(map[int64]int)
 	for rows.Next() {
 		var recipeID int64
 		var recipeName string
+		var brewDurationMs int64
 		var ingredientID sql.NullInt64
-		var ingredientName sql.NullString
-		var quantity sql.NullInt32
+		var ingredientName, canonicalName sql.NullString
+		var quantity sql.NullFloat64
+		var unit sql.NullString
 
-		err := rows.Scan(&recipeID, &recipeName, &ingredientID, &ingredientName, &quantity)
+		err := rows.Scan(&recipeID, &recipeName, &brewDurationMs, &ingredientID, &ingredientName, &canonicalName, &quantity, &unit)
 		if err != nil {
 			return nil, err
 		}
 
-		recipe, exists := recipes[recipeID]
+		idx, exists := indexes[recipeID]
 		if !exists {
-			recipe = domain.Recipe{
-				ID:          recipeID,
-				Name:        recipeName,
-				Ingredients: make([]domain.Ingredient, 0),
-			}
+			recipes = append(recipes, domain.Recipe{
+				ID:           recipeID,
+				Name:         recipeName,
+				Ingredients:  make([]domain.Ingredient, 0),
+				BrewDuration: time.Duration(brewDurationMs) * time.Millisecond,
+			})
 
-			recipes[recipeID] = recipe
+			idx = len(recipes) - 1
+			indexes[recipeID] = idx
 		}
 
 		if ingredientID.Valid && ingredientName.Valid && quantity.Valid {
-			recipe.Ingredients = append(recipe.Ingredients, domain.Ingredient{
-				ID:       ingredientID.Int64,
-				Name:     ingredientName.String,
-				Quantity: int(quantity.Int32),
+			recipes[idx].Ingredients = append(recipes[idx].Ingredients, domain.Ingredient{
+				ID:            ingredientID.Int64,
+				RecipeID:      recipeID,
+				Name:          ingredientName.String,
+				Quantity:      quantity.Float64,
+				Unit:          units.Unit(unit.String),
+				CanonicalName: canonicalName.String,
 			})
 		}
 	}
 
-	result := make([]domain.Recipe, 0, len(recipes))
-	for _, recipe := range recipes {
-		result = append(result, recipe)
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return recipes, nil
 }
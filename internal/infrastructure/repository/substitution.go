@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+type SubstitutionRepository struct {
+	db *sql.DB
+}
+
+var _ SubstitutionRepositoryInterface = (*SubstitutionRepository)(nil)
+
+func NewSubstitutionRepository(db *sql.DB) *SubstitutionRepository {
+	return &SubstitutionRepository{db: db}
+}
+
+// GetSubstitutions возвращает все правила замены: сначала правила конкретных рецептов, затем общие.
+func (r *SubstitutionRepository) GetSubstitutions(ctx context.Context) ([]domain.Substitution, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, ingredient, substitute, ratio, recipe_id
+		FROM substitutions
+		ORDER BY recipe_id IS NULL, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	substitutions := make([]domain.Substitution, 0)
+	for rows.Next() {
+		substitution, err := scanSubstitution(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		substitutions = append(substitutions, *substitution)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return substitutions, nil
+}
+
+func (r *SubstitutionRepository) GetSubstitution(ctx context.Context, id int64) (*domain.Substitution, error) {
+	row := r.db.QueryRowContext(ctx,
+		"SELECT id, ingredient, substitute, ratio, recipe_id FROM substitutions WHERE id = $1",
+		id,
+	)
+
+	substitution, err := scanSubstitution(row)
+	if err == sql.ErrNoRows {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	return substitution, err
+}
+
+func (r *SubstitutionRepository) SaveSubstitution(ctx context.Context, substitution *domain.Substitution) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = ensureSubstitutionAvailable(ctx, tx, substitution); err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO substitutions (ingredient, substitute, ratio, recipe_id) VALUES ($1, $2, $3, $4) RETURNING id",
+		substitution.Ingredient, substitution.Substitute, substitution.Ratio, substitution.RecipeID,
+	).Scan(&substitution.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *SubstitutionRepository) UpdateSubstitution(ctx context.Context, substitution *domain.Substitution) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = ensureSubstitutionAvailable(ctx, tx, substitution); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE substitutions SET ingredient = $1, substitute = $2, ratio = $3, recipe_id = $4 WHERE id = $5",
+		substitution.Ingredient, substitution.Substitute, substitution.Ratio, substitution.RecipeID, substitution.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	return tx.Commit()
+}
+
+func (r *SubstitutionRepository) DeleteSubstitution(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM substitutions WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	return nil
+}
+
+// ensureSubstitutionAvailable проверяет, что рецепт правила существует и такого правила ещё нет.
+func ensureSubstitutionAvailable(ctx context.Context, tx *sql.Tx, substitution *domain.Substitution) error {
+	if substitution.RecipeID != nil {
+		var exists bool
+		err := tx.QueryRowContext(ctx,
+			"SELECT EXISTS (SELECT 1 FROM recipes WHERE id = $1)",
+			*substitution.RecipeID,
+		).Scan(&exists)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return domainErrors.NewValidationError(fmt.Errorf("recipe %d not found", *substitution.RecipeID), domainErrors.FieldViolation{
+				Field:       "recipe_id",
+				Description: "recipe does not exist",
+			})
+		}
+	}
+
+	var exists bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM substitutions
+			WHERE ingredient = $1 AND substitute = $2 AND recipe_id IS NOT DISTINCT FROM $3::BIGINT AND id <> $4
+		)`,
+		substitution.Ingredient, substitution.Substitute, substitution.RecipeID, substitution.ID,
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return domainErrors.NewAppError(
+			fmt.Errorf("substitution of %q with %q already exists", substitution.Ingredient, substitution.Substitute),
+			domainErrors.ResourceAlreadyExists,
+		)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSubstitution(row rowScanner) (*domain.Substitution, error) {
+	var substitution domain.Substitution
+	var recipeID sql.NullInt64
+
+	err := row.Scan(&substitution.ID, &substitution.Ingredient, &substitution.Substitute, &substitution.Ratio, &recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipeID.Valid {
+		substitution.RecipeID = &recipeID.Int64
+	}
+
+	return &substitution, nil
+}
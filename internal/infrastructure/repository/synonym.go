@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+type SynonymRepository struct {
+	db *sql.DB
+}
+
+var _ SynonymRepositoryInterface = (*SynonymRepository)(nil)
+
+func NewSynonymRepository(db *sql.DB) *SynonymRepository {
+	return &SynonymRepository{db: db}
+}
+
+func (r *SynonymRepository) GetSynonyms(ctx context.Context) ([]domain.Synonym, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, canonical FROM synonyms ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	synonyms := make([]domain.Synonym, 0)
+	for rows.Next() {
+		var synonym domain.Synonym
+		if err := rows.Scan(&synonym.ID, &synonym.Name, &synonym.Canonical); err != nil {
+			return nil, err
+		}
+
+		synonyms = append(synonyms, synonym)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return synonyms, nil
+}
+
+func (r *SynonymRepository) GetSynonym(ctx context.Context, id int64) (*domain.Synonym, error) {
+	var synonym domain.Synonym
+	err := r.db.QueryRowContext(ctx,
+		"SELECT id, name, canonical FROM synonyms WHERE id = $1",
+		id,
+	).Scan(&synonym.ID, &synonym.Name, &synonym.Canonical)
+	if err == sql.ErrNoRows {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &synonym, nil
+}
+
+func (r *SynonymRepository) SaveSynonym(ctx context.Context, synonym *domain.Synonym) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = ensureSynonymAvailable(ctx, tx, synonym); err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO synonyms (name, canonical) VALUES ($1, $2) RETURNING id",
+		synonym.Name, synonym.Canonical,
+	).Scan(&synonym.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *SynonymRepository) UpdateSynonym(ctx context.Context, synonym *domain.Synonym) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = ensureSynonymAvailable(ctx, tx, synonym); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE synonyms SET name = $1, canonical = $2 WHERE id = $3",
+		synonym.Name, synonym.Canonical, synonym.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	return tx.Commit()
+}
+
+func (r *SynonymRepository) DeleteSynonym(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM synonyms WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+
+	return nil
+}
+
+// ensureSynonymAvailable проверяет, что название ещё не занято и синонимы не образуют цепочек:
+// каноническое название не может само быть синонимом, а синоним — каноническим названием.
+func ensureSynonymAvailable(ctx context.Context, tx *sql.Tx, synonym *domain.Synonym) error {
+	var exists bool
+	err := tx.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM synonyms WHERE name = $1 AND id <> $2)",
+		synonym.Name, synonym.ID,
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return domainErrors.NewAppError(fmt.Errorf("synonym %q already exists", synonym.Name), domainErrors.ResourceAlreadyExists)
+	}
+
+	var chained bool
+	err = tx.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM synonyms WHERE (name = $1 OR canonical = $2) AND id <> $3)",
+		synonym.Canonical, synonym.Name, synonym.ID,
+	).Scan(&chained)
+	if err != nil {
+		return err
+	}
+
+	if chained {
+		return domainErrors.NewValidationError(fmt.Errorf("synonym %q would form a chain", synonym.Name), domainErrors.FieldViolation{
+			Field:       "canonical",
+			Description: "canonical name must not be a synonym and a synonym must not be a canonical name",
+		})
+	}
+
+	return nil
+}
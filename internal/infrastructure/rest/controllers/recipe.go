@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vostelmakh/mixturka/internal/application/processor/recipe"
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+)
+
+type RecipeController struct {
+	processor *recipe.Processor
+}
+
+func NewRecipeController(processor *recipe.Processor) *RecipeController {
+	return &RecipeController{
+		processor: processor,
+	}
+}
+
+type ingredientDTO struct {
+	ID       int64      `json:"id,omitempty"`
+	Name     string     `json:"name" binding:"required"`
+	Quantity float64    `json:"quantity" binding:"required,gt=0"`
+	Unit     units.Unit `json:"unit"`
+}
+
+type recipeDTO struct {
+	ID          int64           `json:"id"`
+	Name        string          `json:"name" binding:"required"`
+	Ingredients []ingredientDTO `json:"ingredients" binding:"required,dive"`
+	// BrewDuration длительность варки в формате Go, например "1m30s".
+	BrewDuration string `json:"brew_duration,omitempty"`
+}
+
+type recipeListQuery struct {
+	AllOf     []string `form:"all_of"`
+	AnyOf     []string `form:"any_of"`
+	NoneOf    []string `form:"none_of"`
+	PageSize  int      `form:"page_size"`
+	PageToken string   `form:"page_token"`
+	OrderBy   string   `form:"order_by"`
+}
+
+// List GET /v1/recipes?all_of=...&any_of=...&none_of=...&page_size=...&page_token=...&order_by=id|name
+func (rc *RecipeController) List(c *gin.Context) {
+	var query recipeListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(domainErrors.NewAppError(err, domainErrors.ValidationError))
+		return
+	}
+
+	page, err := rc.processor.GetRecipes(c.Request.Context(), recipe.ListQuery{
+		Filter: domain.RecipeFilter{
+			AllOf:  query.AllOf,
+			AnyOf:  query.AnyOf,
+			NoneOf: query.NoneOf,
+		},
+		OrderBy:   domain.RecipeOrder(query.OrderBy),
+		PageSize:  query.PageSize,
+		PageToken: query.PageToken,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := make([]recipeDTO, 0, len(page.Recipes))
+	for _, recipe := range page.Recipes {
+		response = append(response, toRecipeDTO(recipe))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recipes":         response,
+		"next_page_token": page.NextPageToken,
+	})
+}
+
+// Get GET /v1/recipes/:id
+func (rc *RecipeController) Get(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	recipe, err := rc.processor.GetRecipe(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRecipeDTO(*recipe))
+}
+
+// Create POST /v1/recipes
+func (rc *RecipeController) Create(c *gin.Context) {
+	var request recipeDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(domainErrors.NewAppError(err, domainErrors.ValidationError))
+		return
+	}
+
+	recipe, err := fromRecipeDTO(request)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := rc.processor.CreateRecipe(c.Request.Context(), &recipe); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRecipeDTO(recipe))
+}
+
+// Update PUT /v1/recipes/:id
+func (rc *RecipeController) Update(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var request recipeDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(domainErrors.NewAppError(err, domainErrors.ValidationError))
+		return
+	}
+
+	recipe, err := fromRecipeDTO(request)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	recipe.ID = id
+	if err := rc.processor.UpdateRecipe(c.Request.Context(), &recipe); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	updated, err := rc.processor.GetRecipe(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRecipeDTO(*updated))
+}
+
+// Delete DELETE /v1/recipes/:id
+func (rc *RecipeController) Delete(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := rc.processor.DeleteRecipe(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func pathID(c *gin.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		return 0, domainErrors.NewAppErrorWithType(domainErrors.ValidationError)
+	}
+
+	return id, nil
+}
+
+func toRecipeDTO(recipe domain.Recipe) recipeDTO {
+	dto := recipeDTO{
+		ID:          recipe.ID,
+		Name:        recipe.Name,
+		Ingredients: make([]ingredientDTO, 0, len(recipe.Ingredients)),
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		dto.Ingredients = append(dto.Ingredients, ingredientDTO{
+			ID:       ingredient.ID,
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+		})
+	}
+
+	if recipe.BrewDuration > 0 {
+		dto.BrewDuration = recipe.BrewDuration.String()
+	}
+
+	return dto
+}
+
+func fromRecipeDTO(dto recipeDTO) (domain.Recipe, error) {
+	recipe := domain.Recipe{
+		Name:        dto.Name,
+		Ingredients: make([]domain.Ingredient, 0, len(dto.Ingredients)),
+	}
+
+	if dto.BrewDuration != "" {
+		duration, err := time.ParseDuration(dto.BrewDuration)
+		if err != nil {
+			return recipe, domainErrors.NewValidationError(errors.New("invalid brew duration"), domainErrors.FieldViolation{
+				Field:       "brew_duration",
+				Description: "brew duration must be a duration like 1m30s",
+			})
+		}
+		recipe.BrewDuration = duration
+	}
+
+	for _, ingredient := range dto.Ingredients {
+		recipe.Ingredients = append(recipe.Ingredients, domain.Ingredient{
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+		})
+	}
+
+	return recipe, nil
+}
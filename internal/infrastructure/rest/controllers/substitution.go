@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vostelmakh/mixturka/internal/application/processor/substitution"
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+type SubstitutionController struct {
+	processor *substitution.Processor
+}
+
+func NewSubstitutionController(processor *substitution.Processor) *SubstitutionController {
+	return &SubstitutionController{
+		processor: processor,
+	}
+}
+
+type substitutionDTO struct {
+	ID         int64   `json:"id"`
+	Ingredient string  `json:"ingredient" binding:"required"`
+	Substitute string  `json:"substitute" binding:"required"`
+	Ratio      float64 `json:"ratio" binding:"required,gt=0"`
+	RecipeID   *int64  `json:"recipe_id,omitempty"`
+}
+
+// List GET /v1/substitutions
+func (sc *SubstitutionController) List(c *gin.Context) {
+	substitutions, err := sc.processor.GetSubstitutions(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := make([]substitutionDTO, 0, len(substitutions))
+	for _, substitution := range substitutions {
+		response = append(response, substitutionDTO(substitution))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"substitutions": response,
+	})
+}
+
+// Get GET /v1/substitutions/:id
+func (sc *SubstitutionController) Get(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	substitution, err := sc.processor.GetSubstitution(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, substitutionDTO(*substitution))
+}
+
+// Create POST /v1/substitutions
+func (sc *SubstitutionController) Create(c *gin.Context) {
+	var request substitutionDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(domainErrors.NewAppError(err, domainErrors.ValidationError))
+		return
+	}
+
+	substitution := domain.Substitution(request)
+	substitution.ID = 0
+	if err := sc.processor.CreateSubstitution(c.Request.Context(), &substitution); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, substitutionDTO(substitution))
+}
+
+// Update PUT /v1/substitutions/:id
+func (sc *SubstitutionController) Update(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var request substitutionDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(domainErrors.NewAppError(err, domainErrors.ValidationError))
+		return
+	}
+
+	substitution := domain.Substitution(request)
+	substitution.ID = id
+	if err := sc.processor.UpdateSubstitution(c.Request.Context(), &substitution); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, substitutionDTO(substitution))
+}
+
+// Delete DELETE /v1/substitutions/:id
+func (sc *SubstitutionController) Delete(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := sc.processor.DeleteSubstitution(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vostelmakh/mixturka/internal/application/processor/synonym"
+	"github.com/vostelmakh/mixturka/internal/domain"
+	domainErrors "github.com/vostelmakh/mixturka/internal/domain/errors"
+)
+
+type SynonymController struct {
+	processor *synonym.Processor
+}
+
+func NewSynonymController(processor *synonym.Processor) *SynonymController {
+	return &SynonymController{
+		processor: processor,
+	}
+}
+
+type synonymDTO struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name" binding:"required"`
+	Canonical string `json:"canonical" binding:"required"`
+}
+
+// List GET /v1/synonyms
+func (sc *SynonymController) List(c *gin.Context) {
+	synonyms, err := sc.processor.GetSynonyms(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := make([]synonymDTO, 0, len(synonyms))
+	for _, synonym := range synonyms {
+		response = append(response, synonymDTO(synonym))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"synonyms": response,
+	})
+}
+
+// Get GET /v1/synonyms/:id
+func (sc *SynonymController) Get(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	synonym, err := sc.processor.GetSynonym(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, synonymDTO(*synonym))
+}
+
+// Create POST /v1/synonyms
+func (sc *SynonymController) Create(c *gin.Context) {
+	var request synonymDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(domainErrors.NewAppError(err, domainErrors.ValidationError))
+		return
+	}
+
+	synonym := domain.Synonym(request)
+	synonym.ID = 0
+	if err := sc.processor.CreateSynonym(c.Request.Context(), &synonym); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, synonymDTO(synonym))
+}
+
+// Update PUT /v1/synonyms/:id
+func (sc *SynonymController) Update(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var request synonymDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(domainErrors.NewAppError(err, domainErrors.ValidationError))
+		return
+	}
+
+	synonym := domain.Synonym(request)
+	synonym.ID = id
+	if err := sc.processor.UpdateSynonym(c.Request.Context(), &synonym); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, synonymDTO(synonym))
+}
+
+// Delete DELETE /v1/synonyms/:id
+func (sc *SynonymController) Delete(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := sc.processor.DeleteSynonym(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
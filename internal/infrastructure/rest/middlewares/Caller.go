@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/vostelmakh/mixturka/internal/application/processor/brew"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/proxy"
+)
+
+// Caller передаёт в контекст запроса автора вызова для истории и отмены варок. Заголовки
+// X-Caller и X-Forwarded-For учитываются, только если запрос пришёл от доверенного прокси,
+// иначе автор — адрес клиента. JSON-RPC не аутентифицирует вызовы, поэтому вызовы не через
+// доверенный прокси не пишутся в историю варок.
+func Caller(trusted proxy.Trusted) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		caller, verified := trusted.Caller(c.Request.RemoteAddr, c.Request.Header.Values("X-Forwarded-For"), c.GetHeader("X-Caller"))
+
+		ctx := brew.WithCaller(c.Request.Context(), caller)
+		if !verified {
+			ctx = brew.WithoutHistory(ctx)
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
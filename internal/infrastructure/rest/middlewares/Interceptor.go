@@ -2,8 +2,10 @@ package middlewares
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -19,29 +21,37 @@ func (w bodyLogWriter) Write(b []byte) (int, error) {
 	return w.ResponseWriter.Write(b)
 }
 
-func GinBodyLogMiddleware(c *gin.Context) {
-	blw := &bodyLogWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer}
-	c.Writer = blw
-
-	buf := make([]byte, 4096)
-	num, err := c.Request.Body.Read(buf)
-	if err != nil && err.Error() != "EOF" {
-		_ = fmt.Errorf("error reading buffer: %s", err.Error())
-	}
-	reqBody := string(buf[0:num])
-	c.Request.Body = io.NopCloser(bytes.NewBuffer([]byte(reqBody)))
-
-	c.Next()
-
-	loc, _ := time.LoadLocation("America/Mexico_City")
-	allDataIO := map[string]any{
-		"ruta":          c.FullPath(),
-		"request_uri":   c.Request.RequestURI,
-		"raw_request":   reqBody,
-		"status_code":   c.Writer.Status(),
-		"body_response": blw.body.String(),
-		"errors":        c.Errors.Errors(),
-		"created_at":    time.Now().In(loc).Format("2006-01-02T15:04:05"),
+// GinBodyLogMiddleware логирует тело запроса и ответа. Тело запроса больше maxBodySize байт
+// не читается целиком и отклоняется с 413.
+func GinBodyLogMiddleware(maxBodySize int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		blw := &bodyLogWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer}
+		c.Writer = blw
+
+		buf, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodySize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body is too large"})
+			return
+		}
+		if err != nil {
+			_ = fmt.Errorf("error reading buffer: %s", err.Error())
+		}
+		reqBody := string(buf)
+		c.Request.Body = io.NopCloser(bytes.NewBuffer([]byte(reqBody)))
+
+		c.Next()
+
+		loc, _ := time.LoadLocation("America/Mexico_City")
+		allDataIO := map[string]any{
+			"ruta":          c.FullPath(),
+			"request_uri":   c.Request.RequestURI,
+			"raw_request":   reqBody,
+			"status_code":   c.Writer.Status(),
+			"body_response": blw.body.String(),
+			"errors":        c.Errors.Errors(),
+			"created_at":    time.Now().In(loc).Format("2006-01-02T15:04:05"),
+		}
+		_ = fmt.Sprintf("%v", allDataIO)
 	}
-	_ = fmt.Sprintf("%v", allDataIO)
 }
@@ -22,6 +22,10 @@ func ErrorHandler() gin.HandlerFunc {
 					c.JSON(http.StatusNotFound, gin.H{"error": appErr.Error()})
 				case domainErrors.ValidationError:
 					c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+				case domainErrors.ResourceAlreadyExists, domainErrors.FailedPrecondition:
+					c.JSON(http.StatusConflict, gin.H{"error": appErr.Error()})
+				case domainErrors.ResourceExhausted:
+					c.JSON(http.StatusTooManyRequests, gin.H{"error": appErr.Error()})
 				case domainErrors.RepositoryError:
 					c.JSON(http.StatusInternalServerError, gin.H{"error": appErr.Error()})
 				case domainErrors.NotAuthenticated:
@@ -4,9 +4,16 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/vostelmakh/mixturka/internal/application/server"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/gateway"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/jsonrpc"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/proxy"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/rest/controllers"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/rest/middlewares"
 )
 
-func ApplicationRouter(router *gin.Engine) {
+func ApplicationRouter(router *gin.Engine, rpcServer *jsonrpc.Server, grpcGateway *gateway.Gateway, recipeController *controllers.RecipeController, substitutionController *controllers.SubstitutionController, synonymController *controllers.SynonymController, trustedProxies proxy.Trusted) {
 	v1 := router.Group("/v1")
 
 	v1.GET("/version", func(c *gin.Context) {
@@ -15,4 +22,41 @@ func ApplicationRouter(router *gin.Engine) {
 			"service": "Mixturka",
 		})
 	})
+
+	recipes := v1.Group("/recipes")
+	recipes.GET("", recipeController.List)
+	recipes.GET("/:id", recipeController.Get)
+	recipes.POST("", recipeController.Create)
+	recipes.PUT("/:id", recipeController.Update)
+	recipes.DELETE("/:id", recipeController.Delete)
+
+	substitutions := v1.Group("/substitutions")
+	substitutions.GET("", substitutionController.List)
+	substitutions.GET("/:id", substitutionController.Get)
+	substitutions.POST("", substitutionController.Create)
+	substitutions.PUT("/:id", substitutionController.Update)
+	substitutions.DELETE("/:id", substitutionController.Delete)
+
+	synonyms := v1.Group("/synonyms")
+	synonyms.GET("", synonymController.List)
+	synonyms.GET("/:id", synonymController.Get)
+	synonyms.POST("", synonymController.Create)
+	synonyms.PUT("/:id", synonymController.Update)
+	synonyms.DELETE("/:id", synonymController.Delete)
+
+	rpcV1 := router.Group("/jsonrpc/v1", middlewares.Caller(trustedProxies))
+	rpcV1.POST("", rpcServer.Handler())
+	rpcV1.POST("/recipes/list", rpcServer.Handler(server.MethodRecipesList))
+	rpcV1.POST("/recipes/suggest", rpcServer.Handler(server.MethodRecipesSuggest))
+	rpcV1.POST("/recipes/yield", rpcServer.Handler(server.MethodRecipesYield))
+	rpcV1.POST("/pot/brew", rpcServer.Handler(server.MethodPotBrew))
+	rpcV1.POST("/shopping/list", rpcServer.Handler(server.MethodShoppingList))
+	rpcV1.POST("/production/plan", rpcServer.Handler(server.MethodProductionPlan))
+	rpcV1.POST("/brews/get", rpcServer.Handler(server.MethodBrewsGet))
+	rpcV1.POST("/brews/list", rpcServer.Handler(server.MethodBrewsList))
+	rpcV1.POST("/brews/cancel", rpcServer.Handler(server.MethodBrewsCancel))
+	rpcV1.POST("/brews/history", rpcServer.Handler(server.MethodBrewsHistory))
+
+	// HTTP/JSON-транскодирование gRPC-сервиса: пути задаются аннотациями в api/mixturka.proto.
+	router.Any(grpcGateway.Prefix()+"/*path", gin.WrapH(grpcGateway))
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log"
 	"net"
@@ -15,20 +16,39 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
+	grpcHealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/vostelmakh/mixturka/internal/application/processor/brew"
 	"github.com/vostelmakh/mixturka/internal/application/processor/recipe"
+	"github.com/vostelmakh/mixturka/internal/application/processor/substitution"
+	"github.com/vostelmakh/mixturka/internal/application/processor/synonym"
 	"github.com/vostelmakh/mixturka/internal/application/server"
+	"github.com/vostelmakh/mixturka/internal/domain/names"
+	"github.com/vostelmakh/mixturka/internal/domain/units"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/config"
 	"github.com/vostelmakh/mixturka/internal/infrastructure/db"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/gateway"
 	mixturkaGrpc "github.com/vostelmakh/mixturka/internal/infrastructure/grpc"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/grpc/interceptors"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/health"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/jsonrpc"
 	"github.com/vostelmakh/mixturka/internal/infrastructure/kafka"
 	"github.com/vostelmakh/mixturka/internal/infrastructure/repository"
+	"github.com/vostelmakh/mixturka/internal/infrastructure/rest/controllers"
 	"github.com/vostelmakh/mixturka/internal/infrastructure/rest/middlewares"
 	"github.com/vostelmakh/mixturka/internal/infrastructure/rest/routes"
 )
 
 func main() {
+	cfg := config.Load()
+
 	router := gin.Default()
+	// По умолчанию gin доверяет X-Forwarded-For от любого клиента.
+	if err := router.SetTrustedProxies(cfg.TrustedProxies.Strings()); err != nil {
+		log.Fatalf("failed to set trusted proxies: %v", err)
+	}
 	router.Use(cors.Default())
 
 	database, err := db.InitDB()
@@ -37,10 +57,59 @@ func main() {
 	}
 
 	router.Use(middlewares.ErrorHandler())
-	router.Use(middlewares.GinBodyLogMiddleware)
+	router.Use(middlewares.GinBodyLogMiddleware(cfg.MaxBodySize))
 	router.Use(middlewares.CommonHeaders)
 
-	routes.ApplicationRouter(router)
+	// Инициализация процессоров
+	repo := repository.NewRecipeRepository(database)
+	substitutionRepo := repository.NewSubstitutionRepository(database)
+	synonymRepo := repository.NewSynonymRepository(database)
+	brewRepo := repository.NewBrewRepository(database)
+	historyRepo := repository.NewBrewHistoryRepository(database)
+
+	normalizer := names.NewNormalizer(names.WithTransliteration(cfg.NameTransliteration))
+
+	recipeProcessor := recipe.NewRecipeProcessor(repo,
+		recipe.WithWatchConfig(cfg.RecipeWatch),
+		recipe.WithNames(normalizer, synonymRepo),
+	)
+	brewSessions := brew.NewSessions(brewRepo, cfg.BrewSessions)
+	brewProcessor := brew.NewGRPCProcessor(repo,
+		brew.WithDefaultMatch(cfg.BrewMatch),
+		brew.WithPlannerConfig(cfg.BrewPlanner),
+		brew.WithSubstitutions(substitutionRepo),
+		brew.WithUnits(units.NewRegistry(units.WithDensities(cfg.UnitDensities))),
+		brew.WithNames(normalizer, synonymRepo),
+		brew.WithFuzzyConfig(cfg.BrewFuzzy),
+		brew.WithSessions(brewSessions),
+		brew.WithHistory(historyRepo),
+	)
+	substitutionProcessor := substitution.NewSubstitutionProcessor(substitutionRepo)
+	synonymProcessor := synonym.NewSynonymProcessor(synonymRepo, normalizer,
+		synonym.WithOnChange(recipeProcessor.RefreshCanonicalNames),
+	)
+
+	// Канонические названия зависят от словаря синонимов и NAME_TRANSLITERATION, которые могли
+	// измениться с прошлого запуска, поэтому каталог пересчитывается до начала обслуживания.
+	if err := recipeProcessor.RefreshCanonicalNames(context.Background()); err != nil {
+		log.Fatalf("failed to refresh canonical ingredient names: %v", err)
+	}
+
+	rpcServer := jsonrpc.NewServer(cfg.JSONRPC)
+	server.NewJSONRPCServer(recipeProcessor, brewProcessor).Register(rpcServer)
+
+	mixturkaServer := server.NewMixturkaServer(recipeProcessor, brewProcessor, cfg.Server)
+
+	grpcGateway, err := gateway.NewGateway(context.Background(), mixturkaServer, interceptors.UnaryChain(cfg.GRPC.Interceptors), cfg.Gateway)
+	if err != nil {
+		log.Fatalf("failed to init grpc gateway: %v", err)
+	}
+
+	recipeController := controllers.NewRecipeController(recipeProcessor)
+	substitutionController := controllers.NewSubstitutionController(substitutionProcessor)
+	synonymController := controllers.NewSynonymController(synonymProcessor)
+
+	routes.ApplicationRouter(router, rpcServer, grpcGateway, recipeController, substitutionController, synonymController, cfg.TrustedProxies)
 
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
@@ -62,6 +131,27 @@ func main() {
 		}
 	}()
 
+	// Метрики expvar отдаются только на служебном порту, не на публичном API.
+	adminAddr := os.Getenv("ADMIN_ADDR")
+	if adminAddr == "" {
+		adminAddr = "127.0.0.1:9090"
+	}
+
+	adminMux := http.NewServeMux()
+	adminMux.Handle("/debug/vars", expvar.Handler())
+	adminServer := &http.Server{
+		Addr:              adminAddr,
+		Handler:           adminMux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Printf("Admin server running at http://%s\n", adminAddr)
+	go func() {
+		if err := adminServer.ListenAndServe(); err != nil {
+			log.Fatalf("failed to serve admin: %v", err)
+		}
+	}()
+
 	// Инициализация grpc сервера
 	grpcPort := os.Getenv("GRPC_PORT")
 	if grpcPort == "" {
@@ -73,17 +163,19 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	// Инициализация процессоров
-	repo := repository.NewRecipeRepository(database)
-
-	recipeProcessor := recipe.NewRecipeProcessor(repo)
-	brewProcessor := brew.NewGRPCProcessor(repo)
-
 	// Инициализация gRPC сервера
-	grpcServer := grpc.NewServer()
-	mixturkaServer := server.NewMixturkaServer(recipeProcessor, brewProcessor)
+	grpcServer := grpc.NewServer(interceptors.ServerOptions(cfg.GRPC.Interceptors)...)
 	mixturkaGrpc.RegisterMixturkaServer(grpcServer, mixturkaServer)
 
+	healthServer := grpcHealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	healthChecker := health.NewChecker(healthServer, cfg.Health, mixturkaGrpc.Mixturka_ServiceDesc.ServiceName)
+	healthChecker.AddCheck("database", database.PingContext)
+
+	if cfg.GRPC.Reflection {
+		reflection.Register(grpcServer)
+	}
+
 	go func() {
 		fmt.Printf("grpc server running at localhost:%s\n", grpcPort)
 		if err := grpcServer.Serve(lis); err != nil {
@@ -113,11 +205,22 @@ func main() {
 		log.Fatalf("Failed to start consumer: %v", err)
 	}
 
+	if err := brewSessions.Start(ctx); err != nil {
+		log.Fatalf("Failed to start brew sessions: %v", err)
+	}
+
+	healthChecker.AddCheck("kafka", consumer.Check)
+	healthChecker.Start(ctx)
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	<-sigChan
 
 	log.Println("Shutting down...")
 
+	// Сначала снимаем трафик через health-статус, затем дожидаемся завершения вызовов.
+	healthChecker.Shutdown()
+	time.Sleep(cfg.GRPC.DrainDelay)
+
 	grpcServer.GracefulStop()
 }
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE substitutions (
+    id BIGSERIAL PRIMARY KEY,
+    ingredient TEXT NOT NULL,
+    substitute TEXT NOT NULL,
+    ratio DOUBLE PRECISION NOT NULL,
+    recipe_id BIGINT,
+    CONSTRAINT fk_recipe_id FOREIGN KEY (recipe_id) REFERENCES recipes (id) ON DELETE CASCADE,
+    CONSTRAINT chk_ratio CHECK (ratio > 0),
+    CONSTRAINT chk_substitute CHECK (ingredient <> substitute)
+);
+
+CREATE UNIQUE INDEX idx_substitutions_global ON substitutions (ingredient, substitute) WHERE recipe_id IS NULL;
+CREATE UNIQUE INDEX idx_substitutions_recipe ON substitutions (ingredient, substitute, recipe_id) WHERE recipe_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS substitutions;
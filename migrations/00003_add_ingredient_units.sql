@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE ingredients ALTER COLUMN quantity TYPE DOUBLE PRECISION;
+ALTER TABLE ingredients ADD COLUMN unit TEXT NOT NULL DEFAULT 'pcs';
+ALTER TABLE ingredients ADD CONSTRAINT chk_unit CHECK (unit IN ('mg', 'g', 'kg', 'ml', 'l', 'pcs'));
+
+-- +goose Down
+ALTER TABLE ingredients DROP CONSTRAINT IF EXISTS chk_unit;
+ALTER TABLE ingredients DROP COLUMN IF EXISTS unit;
+ALTER TABLE ingredients ALTER COLUMN quantity TYPE INTEGER USING ROUND(quantity);
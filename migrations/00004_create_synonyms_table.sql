@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE synonyms (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    canonical TEXT NOT NULL,
+    CONSTRAINT chk_canonical CHECK (name <> canonical)
+);
+
+CREATE UNIQUE INDEX idx_synonyms_name ON synonyms (name);
+
+-- +goose Down
+DROP TABLE IF EXISTS synonyms;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE recipes ADD COLUMN brew_duration_ms BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE recipes ADD CONSTRAINT chk_brew_duration CHECK (brew_duration_ms >= 0);
+
+-- +goose Down
+ALTER TABLE recipes DROP CONSTRAINT IF EXISTS chk_brew_duration;
+ALTER TABLE recipes DROP COLUMN IF EXISTS brew_duration_ms;
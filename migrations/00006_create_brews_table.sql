@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE brews (
+    id UUID PRIMARY KEY,
+    recipe_id BIGINT,
+    recipe_name TEXT NOT NULL,
+    state TEXT NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    consumed JSONB NOT NULL DEFAULT '[]',
+    leftovers JSONB NOT NULL DEFAULT '[]',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    CONSTRAINT fk_recipe_id FOREIGN KEY (recipe_id) REFERENCES recipes (id) ON DELETE SET NULL,
+    CONSTRAINT chk_state CHECK (state IN ('queued', 'brewing', 'finished', 'failed', 'cancelled')),
+    CONSTRAINT chk_duration CHECK (duration_ms >= 0)
+);
+
+CREATE INDEX idx_brews_created_at ON brews (created_at DESC, id DESC);
+CREATE INDEX idx_brews_unfinished ON brews (state) WHERE state IN ('queued', 'brewing');
+
+-- +goose Down
+DROP TABLE IF EXISTS brews;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE brews ADD COLUMN cancelled_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE brews ADD COLUMN cancel_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE brews ADD COLUMN ingredients_returned BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE brews DROP COLUMN IF EXISTS ingredients_returned;
+ALTER TABLE brews DROP COLUMN IF EXISTS cancel_reason;
+ALTER TABLE brews DROP COLUMN IF EXISTS cancelled_by;
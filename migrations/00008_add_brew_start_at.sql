@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE brews ADD COLUMN start_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE brews DROP COLUMN IF EXISTS start_at;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE brew_history (
+    id UUID PRIMARY KEY,
+    caller TEXT NOT NULL DEFAULT '',
+    ingredients JSONB NOT NULL DEFAULT '[]',
+    match_mode TEXT NOT NULL DEFAULT '',
+    recipe_id BIGINT,
+    recipe_name TEXT NOT NULL DEFAULT '',
+    brew_id UUID,
+    outcome TEXT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    start_at TIMESTAMPTZ,
+    requested_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_recipe_id FOREIGN KEY (recipe_id) REFERENCES recipes (id) ON DELETE SET NULL,
+    CONSTRAINT fk_brew_id FOREIGN KEY (brew_id) REFERENCES brews (id) ON DELETE SET NULL,
+    CONSTRAINT chk_outcome CHECK (outcome IN ('started', 'scheduled', 'no_match', 'rejected', 'error'))
+);
+
+CREATE INDEX idx_brew_history_requested_at ON brew_history (requested_at DESC, id DESC);
+CREATE INDEX idx_brew_history_recipe_id ON brew_history (recipe_id, requested_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS brew_history;
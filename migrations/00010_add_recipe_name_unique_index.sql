@@ -0,0 +1,9 @@
+-- +goose Up
+-- Рецепты-дубликаты, сохранённые до появления индекса, получают в названии свой идентификатор.
+UPDATE recipes r SET name = r.name || ' (' || r.id || ')'
+WHERE EXISTS (SELECT 1 FROM recipes o WHERE LOWER(BTRIM(o.name)) = LOWER(BTRIM(r.name)) AND o.id < r.id);
+
+CREATE UNIQUE INDEX idx_recipes_name ON recipes (LOWER(BTRIM(name)));
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_recipes_name;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Каноническое название ингредиента для фильтра по составу, name остаётся в том виде, в каком его записали.
+-- Значения заполняет сервис при запуске: нормализация и словарь синонимов есть только в коде.
+ALTER TABLE ingredients ADD COLUMN canonical_name TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_ingredients_canonical_name ON ingredients (canonical_name);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_ingredients_canonical_name;
+ALTER TABLE ingredients DROP COLUMN IF EXISTS canonical_name;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Кто отменил варку по данным транспорта, в отличие от cancelled_by, который указывает клиент.
+ALTER TABLE brews ADD COLUMN cancelled_by_caller TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE brews DROP COLUMN IF EXISTS cancelled_by_caller;